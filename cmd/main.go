@@ -22,10 +22,14 @@ import (
 	"os"
 	"runtime"
 
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/data"
 	"dingoscheduler/internal/server"
 	"dingoscheduler/pkg/app"
 	"dingoscheduler/pkg/config"
 	log "dingoscheduler/pkg/logger"
+
+	"go.uber.org/zap"
 )
 
 var (
@@ -40,12 +44,30 @@ func init() {
 	flag.Parse()
 }
 
-func newApp(ts *server.HTTPServer, ss *server.SchedulerServer) *app.App {
+func newApp(ts *server.HTTPServer, ss *server.SchedulerServer, le *server.LeaderElector, ls *server.LivenessSweeper,
+	en *server.ExpiredNotifier, il *server.FileIndexLoader, pf *server.ProgressFlusher, sr *server.SessionRelayPoller,
+	qa *server.QuarantineAlerter) *app.App {
+	// pf放在最后，grpc服务停止后再写入剩余的进度上报
 	app := app.New(app.ID(id), app.Name(Name), app.Version(Version),
-		app.Server(ts, ss))
+		app.Server(ts, ss, le, ls, en, il, sr, qa, pf))
 	return app
 }
 
+// dedupe 合并重复的文件记录和下载进度，在添加唯一键的迁移之前执行
+func dedupe(conf *config.Config) error {
+	db, cleanup, err := data.OpenBizDB(conf)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	result, err := dao.Dedupe(db)
+	if err != nil {
+		return err
+	}
+	zap.S().Infof("dedupe finished, removed %d records and %d processes.", result.Records, result.Processes)
+	return nil
+}
+
 func main() {
 	conf, err := config.Scan(configPath)
 	if err != nil {
@@ -53,6 +75,20 @@ func main() {
 	}
 
 	log.InitLogger()
+	// dingoscheduler [-config path] migrate|dedupe：执行表结构迁移或合并重复数据后退出
+	switch flag.Arg(0) {
+	case "migrate":
+		if err = data.Migrate(conf); err != nil {
+			panic(err)
+		}
+		return
+	case "dedupe":
+		if err = dedupe(conf); err != nil {
+			panic(err)
+		}
+		return
+	}
+
 	myapp, f, err := wireApp(conf)
 	if err != nil {
 		panic(err)
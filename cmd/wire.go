@@ -26,12 +26,14 @@ import (
 	"dingoscheduler/internal/router"
 	"dingoscheduler/internal/server"
 	"dingoscheduler/internal/service"
+	"dingoscheduler/internal/session"
 	"dingoscheduler/pkg/app"
 	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/event"
 
 	"github.com/google/wire"
 )
 
 func wireApp(*config.Config) (*app.App, func(), error) {
-	panic(wire.Build(server.ServerProvider, router.RouterProvider, handler.HandlerProvider, service.ServiceProvider, dao.DaoProvider, data.BaseDataProvider, newApp))
+	panic(wire.Build(server.ServerProvider, router.RouterProvider, handler.HandlerProvider, service.ServiceProvider, dao.DaoProvider, data.BaseDataProvider, event.EventProvider, session.SessionProvider, newApp))
 }
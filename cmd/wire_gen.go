@@ -13,8 +13,10 @@ import (
 	"dingoscheduler/internal/router"
 	"dingoscheduler/internal/server"
 	"dingoscheduler/internal/service"
+	"dingoscheduler/internal/session"
 	"dingoscheduler/pkg/app"
 	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/event"
 )
 
 import (
@@ -36,25 +38,46 @@ func wireApp(configConfig *config.Config) (*app.App, func(), error) {
 	tagDao := dao.NewTagDao(baseData)
 	organizationDao := dao.NewOrganizationDao(baseData)
 	hfTokenDao := dao.NewHfTokenDao(baseData)
-	repositoryDao := dao.NewRepositoryDao(baseData, repositoryTagDao, tagDao, dingospeedDao, organizationDao, hfTokenDao)
+	leaseDao := dao.NewLeaseDao(baseData)
+	leaseLocker := dao.NewLeaseLocker(leaseDao)
+	repositoryDao := dao.NewRepositoryDao(baseData, repositoryTagDao, tagDao, dingospeedDao, organizationDao, hfTokenDao, leaseLocker)
 	cacheJobDao := dao.NewCacheJobDao(baseData, repositoryDao)
-	schedulerService := service.NewSchedulerService(baseData, dingospeedDao, modelFileRecordDao, modelFileProcessDao, repositoryDao, cacheJobDao)
-	repositoryService := service.NewRepositoryService(dingospeedDao, repositoryDao, baseData, organizationDao, tagDao, hfTokenDao)
+	manager := session.NewManager()
+	fileIndex := service.NewFileIndex(modelFileRecordDao, modelFileProcessDao)
+	progressService := service.NewProgressService(modelFileProcessDao, fileIndex)
+	bus := event.NewBus()
+	integrityDao := dao.NewIntegrityDao(baseData)
+	integrityService := service.NewIntegrityService(integrityDao, modelFileRecordDao, modelFileProcessDao, progressService, fileIndex, bus)
+	schedulerService := service.NewSchedulerService(baseData, dingospeedDao, modelFileRecordDao, modelFileProcessDao, repositoryDao, cacheJobDao, manager, progressService, fileIndex, integrityService, leaseLocker)
+	eventDao := dao.NewEventDao(baseData)
+	sessionRelay := service.NewSessionRelay(manager, leaseDao, eventDao)
+	repositoryService := service.NewRepositoryService(dingospeedDao, repositoryDao, baseData, organizationDao, tagDao, hfTokenDao, sessionRelay)
 	hfTokenService := service.NewHfTokenService(hfTokenDao)
-	lockDao := dao.NewLockDao(baseData)
-	cacheJobService := service.NewCacheJobService(dingospeedDao, modelFileProcessDao, cacheJobDao, hfTokenDao, lockDao)
+	lockDao := dao.NewLockDao()
+	cacheJobService := service.NewCacheJobService(dingospeedDao, modelFileProcessDao, cacheJobDao, hfTokenDao, lockDao, leaseLocker, sessionRelay)
 	managerService := service.NewManagerService(repositoryDao, repositoryService, cacheJobDao, cacheJobService)
 	managerHandler := handler.NewManagerHandler(schedulerService, repositoryService, hfTokenService, managerService)
-	sysService := service.NewSysService(repositoryDao, cacheJobDao)
+	leaderService := service.NewLeaderService(leaseDao)
+	sysService := service.NewSysService(repositoryDao, cacheJobDao, leaderService)
 	sysHandler := handler.NewSysHandler(sysService)
 	repositoryHandler := handler.NewRepositoryHandler(repositoryService)
 	tagService := service.NewTagService(tagDao)
 	tagHandler := handler.NewTagHandler(tagService)
 	cacheJobHandler := handler.NewCacheJobHandler(cacheJobService)
-	httpRouter := router.NewHttpRouter(echo, managerHandler, sysHandler, repositoryHandler, tagHandler, cacheJobHandler)
+	dingospeedService := service.NewDingospeedService(dingospeedDao)
+	dingospeedHandler := handler.NewDingospeedHandler(dingospeedService)
+	httpRouter := router.NewHttpRouter(echo, managerHandler, sysHandler, repositoryHandler, tagHandler, cacheJobHandler, dingospeedHandler)
 	httpServer := server.NewHTTPServer(configConfig, httpRouter)
 	schedulerServer := server.NewSchedulerServer(schedulerService)
-	appApp := newApp(httpServer, schedulerServer)
+	livenessService := service.NewLivenessService(baseData, dingospeedDao, eventDao, bus)
+	leaderElector := server.NewLeaderElector(leaderService)
+	livenessSweeper := server.NewLivenessSweeper(livenessService, integrityService, leaderService)
+	expiredNotifier := server.NewExpiredNotifier(schedulerService, livenessService, bus)
+	fileIndexLoader := server.NewFileIndexLoader(fileIndex)
+	progressFlusher := server.NewProgressFlusher(progressService)
+	sessionRelayPoller := server.NewSessionRelayPoller(sessionRelay)
+	quarantineAlerter := server.NewQuarantineAlerter(integrityService, bus)
+	appApp := newApp(httpServer, schedulerServer, leaderElector, livenessSweeper, expiredNotifier, fileIndexLoader, progressFlusher, sessionRelayPoller, quarantineAlerter)
 	return appApp, func() {
 		cleanup()
 	}, nil
@@ -15,14 +15,12 @@
 package dao
 
 import (
-	"fmt"
-	"strings"
+	"time"
 
 	"dingoscheduler/internal/data"
 	"dingoscheduler/internal/model"
 	"dingoscheduler/internal/model/query"
 	"dingoscheduler/pkg/consts"
-	"dingoscheduler/pkg/util"
 
 	"github.com/bytedance/sonic"
 	"go.uber.org/zap"
@@ -88,17 +86,17 @@ func (c *CacheJobDao) UpdateCacheStatus(statusReq *query.UpdateJobStatusReq) err
 		if err != nil {
 			return err
 		}
-		newMsgStr = strings.ReplaceAll(string(msgStr), "'", "''")
+		newMsgStr = string(msgStr)
+	}
+	values := map[string]interface{}{
+		"status":     statusReq.Status,
+		"error_msg":  newMsgStr,
+		"updated_at": time.Now(),
 	}
-	var sql string
 	if statusReq.Process > 0 {
-		sql = fmt.Sprintf("UPDATE cache_job SET  status = %d, error_msg = '%s', updated_at = '%s', process = %f WHERE id = %d",
-			statusReq.Status, newMsgStr, util.GetCurrentTimeStr(), statusReq.Process, statusReq.Id)
-	} else {
-		sql = fmt.Sprintf("UPDATE cache_job SET  status = %d, error_msg = '%s', updated_at = '%s' WHERE id = %d",
-			statusReq.Status, newMsgStr, util.GetCurrentTimeStr(), statusReq.Id)
+		values["process"] = statusReq.Process
 	}
-	if err := c.baseData.BizDB.Exec(sql).Error; err != nil {
+	if err := c.baseData.BizDB.Model(&model.CacheJob{}).Where("id = ?", statusReq.Id).Updates(values).Error; err != nil {
 		return err
 	}
 	return nil
@@ -151,8 +149,8 @@ func (c *CacheJobDao) ListCacheJob(condition *query.CacheJobQuery) ([]*model.Cac
 		zap.S().Error("统计数量失败", err)
 		return nil, 0, err
 	}
-	offset, pageSize := paginate(condition.Page, condition.PageSize)
-	db.Order(fmt.Sprintf("created_at desc offset %d limit %d", offset, pageSize))
+	offset, pageSize := Paginate(condition.Page, condition.PageSize)
+	db.Order("created_at desc").Offset(offset).Limit(pageSize)
 	if err := db.Find(&cacheJobs).Error; err != nil {
 		return nil, 0, err
 	}
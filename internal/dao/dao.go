@@ -17,4 +17,16 @@ package dao
 import "github.com/google/wire"
 
 var DaoProvider = wire.NewSet(NewDingospeedDao, NewModelFileRecordDao, NewModelFileProcessDao, NewCacheJobDao,
-	NewRepositoryDao, NewTagDao, NewRepositoryTagDao, NewOrganizationDao, NewHfTokenDao, NewLockDao)
+	NewRepositoryDao, NewTagDao, NewRepositoryTagDao, NewOrganizationDao, NewHfTokenDao, NewLockDao,
+	NewLeaseDao, NewLeaseLocker, NewEventDao, NewIntegrityDao,
+	wire.Bind(new(DingospeedStore), new(*DingospeedDao)),
+	wire.Bind(new(RecordStore), new(*ModelFileRecordDao)),
+	wire.Bind(new(ProcessStore), new(*ModelFileProcessDao)),
+	wire.Bind(new(RepositoryStore), new(*RepositoryDao)),
+	wire.Bind(new(CacheJobStore), new(*CacheJobDao)),
+	wire.Bind(new(TagStore), new(*TagDao)),
+	wire.Bind(new(OrganizationStore), new(*OrganizationDao)),
+	wire.Bind(new(HfTokenStore), new(*HfTokenDao)),
+	wire.Bind(new(LeaseStore), new(*LeaseDao)),
+	wire.Bind(new(EventStore), new(*EventDao)),
+	wire.Bind(new(IntegrityStore), new(*IntegrityDao)))
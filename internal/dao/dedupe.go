@@ -0,0 +1,152 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/model/dto"
+	"dingoscheduler/pkg/common"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// DedupeResult 合并重复数据时删除的行数
+type DedupeResult struct {
+	Records   int64
+	Processes int64
+}
+
+type duplicateRecord struct {
+	Datatype string
+	Org      string
+	Repo     string
+	Name     string
+	Etag     string
+}
+
+type duplicateProcess struct {
+	RecordID   int64
+	InstanceID string
+}
+
+// Dedupe 在一个事务中合并唯一键重复的文件记录和下载进度，添加唯一键前执行一次。
+// 重复记录保留id最小的一条，进度改挂到保留的记录上；同一节点的重复进度合并已完成区间后保留一条。
+func Dedupe(db *gorm.DB) (*DedupeResult, error) {
+	result := &DedupeResult{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if result.Records, err = dedupeRecords(tx); err != nil {
+			return err
+		}
+		result.Processes, err = dedupeProcesses(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func dedupeRecords(tx *gorm.DB) (int64, error) {
+	var groups []*duplicateRecord
+	if err := tx.Model(&model.ModelFileRecord{}).Select("datatype, org, repo, name, etag").
+		Group("datatype, org, repo, name, etag").Having("COUNT(*) > 1").Scan(&groups).Error; err != nil {
+		return 0, err
+	}
+	var deleted int64
+	for _, group := range groups {
+		// mysql分组时不区分name的大小写，组内按完整的name再分，只合并name完全相同的记录
+		var rows []*model.ModelFileRecord
+		if err := tx.Model(&model.ModelFileRecord{}).Select("id, name").Where("datatype = ? and org = ? and repo = ? and name = ? and etag = ?",
+			group.Datatype, group.Org, group.Repo, group.Name, group.Etag).Order("id").Find(&rows).Error; err != nil {
+			return 0, err
+		}
+		keepIds := make(map[string]int64)
+		duplicates := make(map[int64][]int64)
+		for _, row := range rows {
+			if keepId, ok := keepIds[row.Name]; ok {
+				duplicates[keepId] = append(duplicates[keepId], row.ID)
+			} else {
+				keepIds[row.Name] = row.ID
+			}
+		}
+		for name, keepId := range keepIds {
+			ids := duplicates[keepId]
+			if len(ids) == 0 {
+				continue
+			}
+			if err := tx.Model(&model.ModelFileProcess{}).Where("record_id in ?", ids).Update("record_id", keepId).Error; err != nil {
+				return 0, err
+			}
+			res := tx.Where("id in ?", ids).Delete(&model.ModelFileRecord{})
+			if res.Error != nil {
+				return 0, res.Error
+			}
+			deleted += res.RowsAffected
+			zap.S().Infof("[Dedupe] record %s/%s/%s merged into %d, removed %v.", group.Org, group.Repo, name, keepId, ids)
+		}
+	}
+	return deleted, nil
+}
+
+func dedupeProcesses(tx *gorm.DB) (int64, error) {
+	var groups []*duplicateProcess
+	if err := tx.Model(&model.ModelFileProcess{}).Select("record_id, instance_id").
+		Group("record_id, instance_id").Having("COUNT(*) > 1").Scan(&groups).Error; err != nil {
+		return 0, err
+	}
+	var deleted int64
+	for _, group := range groups {
+		var rows []*model.ModelFileProcess
+		if err := tx.Model(&model.ModelFileProcess{}).Where("record_id = ? and instance_id = ?", group.RecordID, group.InstanceID).
+			Order("id").Find(&rows).Error; err != nil {
+			return 0, err
+		}
+		// 保留进度最多的一条，状态以它为准，已完成区间取所有重复进度的并集
+		keep := rows[0]
+		rs := common.RangeSet{}
+		ids := make([]int64, 0, len(rows)-1)
+		for _, row := range rows {
+			if row.OffsetNum > keep.OffsetNum {
+				keep = row
+			}
+			for _, r := range (&dto.ModelFileProcessDto{OffsetNum: row.OffsetNum, Ranges: row.Ranges}).RangeSet() {
+				rs = rs.Add(r.Start, r.End)
+			}
+		}
+		for _, row := range rows {
+			if row.ID != keep.ID {
+				ids = append(ids, row.ID)
+			}
+		}
+		if err := tx.Model(&model.ModelFileProcess{}).Where("id = ?", keep.ID).Updates(map[string]interface{}{
+			"offset_num": rs.Prefix(),
+			"ranges":     rangesColumn(rs),
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+			return 0, err
+		}
+		res := tx.Where("id in ?", ids).Delete(&model.ModelFileProcess{})
+		if res.Error != nil {
+			return 0, res.Error
+		}
+		deleted += res.RowsAffected
+		zap.S().Infof("[Dedupe] process of record %d on %s merged into %d, removed %v.", group.RecordID, group.InstanceID, keep.ID, ids)
+	}
+	return deleted, nil
+}
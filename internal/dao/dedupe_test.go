@@ -0,0 +1,88 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package dao
+
+import (
+	"strings"
+	"testing"
+
+	"dingoscheduler/internal/model"
+	"dingoscheduler/pkg/consts"
+)
+
+// longName 超过mysql原唯一键前缀长度（200个字符）的文件名前缀
+var longName = strings.Repeat("layers/", 30)
+
+func TestDedupe(t *testing.T) {
+	baseData := newSqliteData(t)
+	db := baseData.BizDB
+	// 模拟添加唯一键之前写入的重复数据
+	for _, index := range []string{"uk_record_file", "uk_process_instance"} {
+		if err := db.Exec("DROP INDEX " + index).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	records := []*model.ModelFileRecord{
+		{Datatype: "models", Org: "org", Repo: "repo", Name: "model.bin", Etag: "etag", FileSize: 30},
+		{Datatype: "models", Org: "org", Repo: "repo", Name: "model.bin", Etag: "etag", FileSize: 30},
+		{Datatype: "models", Org: "org", Repo: "repo", Name: "other.bin", Etag: "etag", FileSize: 30},
+		// 前缀相同的长文件名按完整的name合并
+		{Datatype: "models", Org: "org", Repo: "repo", Name: longName + "a.bin", Etag: "etag", FileSize: 30},
+		{Datatype: "models", Org: "org", Repo: "repo", Name: longName + "a.bin", Etag: "etag", FileSize: 30},
+		{Datatype: "models", Org: "org", Repo: "repo", Name: longName + "b.bin", Etag: "etag", FileSize: 30},
+	}
+	for _, record := range records {
+		if err := db.Select("datatype", "org", "repo", "name", "etag", "file_size").Create(record).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	processes := []*model.ModelFileProcess{
+		{RecordID: records[0].ID, InstanceID: "speed-a", OffsetNum: 10, Status: consts.StatusDownloadBreak},
+		{RecordID: records[1].ID, InstanceID: "speed-a", OffsetNum: 0, Ranges: "[[20,30]]", Status: consts.StatusDownloading},
+		{RecordID: records[1].ID, InstanceID: "speed-b", OffsetNum: 30, Status: consts.StatusDownloaded},
+	}
+	for _, process := range processes {
+		if err := db.Select("record_id", "instance_id", "offset_num", "ranges", "status").Create(process).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := Dedupe(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Records != 2 || result.Processes != 1 {
+		t.Fatalf("result = %+v", result)
+	}
+	var rows []*model.ModelFileProcess
+	if err = db.Order("instance_id").Find(&rows).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0].RecordID != records[0].ID || rows[1].RecordID != records[0].ID {
+		t.Fatalf("processes = %+v", rows)
+	}
+	if rows[0].OffsetNum != 10 || rows[0].Ranges != "[[0,10],[20,30]]" {
+		t.Fatalf("merged process = %+v", rows[0])
+	}
+	// 合并后可以加回唯一键
+	for _, stmt := range []string{
+		"CREATE UNIQUE INDEX uk_record_file ON model_file_record (datatype, org, repo, name, etag)",
+		"CREATE UNIQUE INDEX uk_process_instance ON model_file_process (record_id, instance_id)",
+	} {
+		if err = db.Exec(stmt).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -17,12 +17,16 @@ package dao
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"dingoscheduler/internal/data"
 	"dingoscheduler/internal/model"
 	"dingoscheduler/pkg/common"
 	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/consts"
 	"dingoscheduler/pkg/util"
+
+	"gorm.io/gorm"
 )
 
 type DingospeedDao struct {
@@ -37,32 +41,89 @@ func NewDingospeedDao(data *data.BaseData) *DingospeedDao {
 }
 
 func (d *DingospeedDao) Save(speed *model.Dingospeed) (int64, error) {
-	insertSql := fmt.Sprintf("INSERT INTO dingospeed(instance_id, host, port, online) VALUES('%s','%s',%d,%v)", speed.InstanceID, speed.Host, speed.Port, speed.Online)
-	db, err := d.baseData.BizDB.DB()
-	if err != nil {
-		return 0, err
-	}
-	result, err := db.Exec(insertSql)
-	if err != nil {
+	row := &model.Dingospeed{InstanceID: speed.InstanceID, Host: speed.Host, Port: speed.Port, Online: speed.Online, Aidc: speed.Aidc}
+	if err := d.baseData.BizDB.Select("instance_id", "host", "port", "online", "aidc").Create(row).Error; err != nil {
 		return 0, err
 	}
-	return result.LastInsertId()
+	return int64(row.ID), nil
 }
 
 func (d *DingospeedDao) RegisterUpdate(speed *model.Dingospeed) error {
-	sql := fmt.Sprintf("UPDATE dingospeed SET host='%s', port=%d, updated_at = '%s' WHERE id = %d", speed.Host, speed.Port, util.GetCurrentTimeStr(), speed.ID)
-	if err := d.baseData.BizDB.Exec(sql).Error; err != nil {
+	if err := d.baseData.BizDB.Model(&model.Dingospeed{}).Where("id = ?", speed.ID).Updates(map[string]interface{}{
+		"host":       speed.Host,
+		"port":       speed.Port,
+		"aidc":       speed.Aidc,
+		"status":     consts.SpeedStatusAlive,
+		"updated_at": time.Now(),
+	}).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (d *DingospeedDao) HeartbeatUpdate(id int32) error {
-	sql := fmt.Sprintf("UPDATE dingospeed SET updated_at = '%s' WHERE id = %d", util.GetCurrentTimeStr(), id)
-	if err := d.baseData.BizDB.Exec(sql).Error; err != nil {
-		return err
+// HeartbeatUpdate 刷新心跳时间，同时保存心跳上报的负载信息
+func (d *DingospeedDao) HeartbeatUpdate(speed *model.Dingospeed) error {
+	return d.baseData.BizDB.Model(&model.Dingospeed{}).Where("id = ?", speed.ID).Updates(map[string]interface{}{
+		"active_uploads":     speed.ActiveUploads,
+		"outbound_bandwidth": speed.OutboundBandwidth,
+		"free_disk":          speed.FreeDisk,
+		"queued_cache_jobs":  speed.QueuedCacheJobs,
+		"max_uploads":        speed.MaxUploads,
+		"bandwidth_limit":    speed.BandwidthLimit,
+		"version":            speed.Version,
+		"status":             consts.SpeedStatusAlive,
+		"updated_at":         time.Now(),
+	}).Error
+}
+
+func (d *DingospeedDao) List() ([]*model.Dingospeed, error) {
+	speeds := make([]*model.Dingospeed, 0)
+	if err := d.baseData.BizDB.Model(&model.Dingospeed{}).Order("instance_id, online").Find(&speeds).Error; err != nil {
+		return nil, err
 	}
-	return nil
+	return speeds, nil
+}
+
+// ListExpired 查询心跳早于deadline但仍标记为存活的节点
+func (d *DingospeedDao) ListExpired(deadline time.Time) ([]*model.Dingospeed, error) {
+	speeds := make([]*model.Dingospeed, 0)
+	if err := d.baseData.BizDB.Model(&model.Dingospeed{}).Where("status = ? and updated_at < ?", consts.SpeedStatusAlive, deadline).Find(&speeds).Error; err != nil {
+		return nil, err
+	}
+	return speeds, nil
+}
+
+// Expire 标记节点心跳超时并清除以其为master的下载进度的master，与失效事件在同一个事务中写入，
+// 避免中途失败后节点已标记失效、下载方却收不到重新调度的通知
+func (d *DingospeedDao) Expire(id int32, instanceId string, deadline time.Time,
+	event func([]*model.ModelFileProcess) (*model.SchedulerEvent, error)) ([]*model.ModelFileProcess, bool, error) {
+	var processes []*model.ModelFileProcess
+	expired := false
+	err := d.baseData.BizDB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Dingospeed{}).Where("id = ? and status = ? and updated_at < ?", id, consts.SpeedStatusAlive, deadline).
+			Update("status", consts.SpeedStatusExpired)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		var err error
+		if processes, err = clearMaster(tx, instanceId); err != nil {
+			return err
+		}
+		expired = true
+		if event == nil {
+			return nil
+		}
+		e, err := event(processes)
+		if err != nil || e == nil {
+			return err
+		}
+		e.CreatedAt = time.Now()
+		return tx.Create(e).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return processes, expired, nil
 }
 
 func (d *DingospeedDao) GetEntityById(id int32) (*model.Dingospeed, error) {
@@ -75,15 +136,16 @@ func (d *DingospeedDao) GetEntityById(id int32) (*model.Dingospeed, error) {
 
 func (d *DingospeedDao) GetEntity(instanceId string, online bool) (*model.Dingospeed, error) {
 	speedKey := util.GetSpeedKey(instanceId, online)
-	if v, ok := d.baseData.Cache.Get(speedKey); ok {
-		d.baseData.Cache.Set(speedKey, v, config.SysConfig.GetSpeedExpiration())
-		return v.(*model.Dingospeed), nil
+	var cached *model.Dingospeed
+	if d.baseData.Cache.Get(speedKey, &cached) {
+		d.baseData.Cache.Set(speedKey, cached, config.SysConfig.GetSpeedExpiration())
+		return cached, nil
 	}
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	if v, ok := d.baseData.Cache.Get(speedKey); ok {
-		d.baseData.Cache.Set(speedKey, v, config.SysConfig.GetSpeedExpiration())
-		return v.(*model.Dingospeed), nil
+	if d.baseData.Cache.Get(speedKey, &cached) {
+		d.baseData.Cache.Set(speedKey, cached, config.SysConfig.GetSpeedExpiration())
+		return cached, nil
 	}
 	speeds := make([]model.Dingospeed, 0)
 	if err := d.baseData.BizDB.Table("dingospeed").Where("instance_id = ? and online = ?", instanceId, online).Find(&speeds).Error; err != nil {
@@ -0,0 +1,58 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"dingoscheduler/internal/data"
+	"dingoscheduler/internal/model"
+)
+
+type EventDao struct {
+	baseData *data.BaseData
+}
+
+func NewEventDao(data *data.BaseData) *EventDao {
+	return &EventDao{baseData: data}
+}
+
+func (d *EventDao) Append(topic string, payload []byte) error {
+	return d.baseData.BizDB.Create(&model.SchedulerEvent{Topic: topic, Payload: string(payload), CreatedAt: time.Now()}).Error
+}
+
+func (d *EventDao) ListAfter(afterId int64, limit int) ([]*model.SchedulerEvent, error) {
+	var events []*model.SchedulerEvent
+	if err := d.baseData.BizDB.Where("id > ?", afterId).Order("id").Limit(limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (d *EventDao) LastId() (int64, error) {
+	var ids []int64
+	if err := d.baseData.BizDB.Model(&model.SchedulerEvent{}).Order("id desc").Limit(1).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	return ids[0], nil
+}
+
+func (d *EventDao) DeleteBefore(t time.Time) (int64, error) {
+	res := d.baseData.BizDB.Where("created_at < ?", t).Delete(&model.SchedulerEvent{})
+	return res.RowsAffected, res.Error
+}
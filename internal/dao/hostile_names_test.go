@@ -0,0 +1,202 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package dao
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/model/dto"
+	"dingoscheduler/internal/model/query"
+	"dingoscheduler/pkg/common"
+	"dingoscheduler/pkg/consts"
+	pb "dingoscheduler/pkg/proto/manager"
+)
+
+// hostileNames 含引号、注释、通配符等字符的org/repo/文件名，拼接sql时会破坏语句
+var hostileNames = []string{
+	"it's",
+	`say "hi"`,
+	"back`tick",
+	`back\slash`,
+	"x'; DROP TABLE repository; --",
+	"' OR '1'='1",
+	"100%_done",
+	"!bang",
+	"/* c */",
+	"多字节名称",
+}
+
+func TestHostileNamesWritePaths(t *testing.T) {
+	for i, name := range hostileNames {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			checkHostileName(t, name)
+		})
+	}
+}
+
+// FuzzModelListName 任意名称作为模糊查询条件时只能匹配包含该子串的仓库
+func FuzzModelListName(f *testing.F) {
+	for _, name := range hostileNames {
+		f.Add(name)
+	}
+	baseData := newSqliteData(f)
+	repositories := NewRepositoryDao(baseData, NewRepositoryTagDao(baseData), NewTagDao(baseData), NewDingospeedDao(baseData),
+		NewOrganizationDao(baseData), NewHfTokenDao(baseData), NewLeaseLocker(NewLeaseDao(baseData)))
+	if err := repositories.RepoAndTagSave(&model.Repository{InstanceId: "speed-a", Datatype: "models", Org: "org", Repo: "plain",
+		OrgRepo: "org/plain"}, nil); err != nil {
+		f.Fatal(err)
+	}
+	f.Fuzz(func(t *testing.T, name string) {
+		if strings.ContainsRune(name, 0) {
+			t.Skip("sqlite在NUL处截断like模式，mysql、postgres无此问题")
+		}
+		list, total, err := repositories.ModelList(&query.ModelQuery{Name: name, Sort: name, Order: "desc"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int(total) != len(list) {
+			t.Fatalf("total %d, got %d", total, len(list))
+		}
+		for _, repository := range list {
+			// like在mysql、sqlite中不区分大小写
+			if !strings.Contains(strings.ToLower(repository.OrgRepo), strings.ToLower(name)) {
+				t.Fatalf("%q matched %q", name, repository.OrgRepo)
+			}
+		}
+	})
+}
+
+func checkHostileName(t *testing.T, name string) {
+	baseData := newSqliteData(t)
+	speeds := NewDingospeedDao(baseData)
+	records, processes := NewModelFileRecordDao(baseData), NewModelFileProcessDao(baseData)
+	organizations := NewOrganizationDao(baseData)
+	cacheJobs := NewCacheJobDao(baseData, nil)
+	tags := NewTagDao(baseData)
+	repositories := NewRepositoryDao(baseData, NewRepositoryTagDao(baseData), tags, speeds, organizations, NewHfTokenDao(baseData),
+		NewLeaseLocker(NewLeaseDao(baseData)))
+
+	speedId, err := speeds.Save(&model.Dingospeed{InstanceID: name, Host: name, Port: 8001, Online: true, Aidc: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = speeds.RegisterUpdate(&model.Dingospeed{ID: int32(speedId), Host: name, Port: 8002, Aidc: name}); err != nil {
+		t.Fatal(err)
+	}
+	speed, err := speeds.GetEntity(name, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if speed == nil || speed.Host != name || speed.Aidc != name {
+		t.Fatalf("speed = %+v", speed)
+	}
+
+	if err = records.BatchSave([]model.ModelFileRecord{{Datatype: "models", Org: name, Repo: name, Name: name + ".bak", Etag: name + "-bak"}}); err != nil {
+		t.Fatal(err)
+	}
+	processId, _, err := records.SaveSchedulerRecord(&pb.SchedulerFileRequest{DataType: "models", Org: name, Repo: name,
+		Name: name, Etag: name, FileSize: 10}, &model.ModelFileProcess{InstanceID: name, Status: consts.StatusDownloading})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = processes.BatchReportFileProcess(map[int64]*dto.ProcessReport{
+		processId: {Ranges: common.RangeSet{}.Add(0, 10), Status: consts.StatusDownloaded},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	files, err := processes.ListInstanceFiles(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Org != name || files[0].Name != name {
+		t.Fatalf("files = %+v", files)
+	}
+	found, err := processes.GetModelFileProcessByCondition("models", name, name, name, name, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 {
+		t.Fatalf("got %d processes, want 1", len(found))
+	}
+	if err = processes.MarkCorrupted(processId); err != nil {
+		t.Fatal(err)
+	}
+
+	free, err := repositories.GetFreeRepository(name, name, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(free) != 1 || free[0].Org != name || free[0].Repo != name {
+		t.Fatalf("free = %+v", free)
+	}
+	if err = tags.Create(&model.Tag{ID: name, Label: name, Type: name}); err != nil {
+		t.Fatal(err)
+	}
+	repository := &model.Repository{InstanceId: name, Datatype: "models", Org: name, Repo: name, OrgRepo: name + "/" + name,
+		PipelineTag: name, Sha: "sha"}
+	if err = repositories.RepoAndTagSave(repository, []*model.RepositoryTag{{TagId: name}}); err != nil {
+		t.Fatal(err)
+	}
+	if err = repositories.UpdateRepositoryMountStatus(&query.UpdateMountStatusReq{Id: repository.ID, Status: 1, ErrorMsg: name}); err != nil {
+		t.Fatal(err)
+	}
+	// 名称中的通配符按字面匹配
+	list, total, err := repositories.ModelList(&query.ModelQuery{Name: name, Library: name, Sort: name, Order: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(list) != 1 || list[0].OrgRepo != name+"/"+name {
+		t.Fatalf("got %d/%d: %+v", len(list), total, list)
+	}
+	if n, err := repositories.DeleteByInstanceIdAndDatatypeAndOrgAndRepo(name, "models", name, name); err != nil || n != 1 {
+		t.Fatalf("deleted %d, err %v", n, err)
+	}
+
+	if err = organizations.SaveOrgBySql(&model.Organization{Name: name, Icon: name}); err != nil {
+		t.Fatal(err)
+	}
+	if exists, err := organizations.ExistsByField("name", name); err != nil || !exists {
+		t.Fatalf("exists %v, err %v", exists, err)
+	}
+	if err = organizations.UpdateByField("name", name, &model.Organization{Icon: name + "-new"}); err != nil {
+		t.Fatal(err)
+	}
+	if icon, err := organizations.GetOrganization(name); err != nil || icon != name+"-new" {
+		t.Fatalf("icon %q, err %v", icon, err)
+	}
+
+	if err = cacheJobs.Save(&model.CacheJob{InstanceId: name, Datatype: "models", Org: name, Repo: name, Commit: name,
+		Status: consts.RunningStatusJobWait}); err != nil {
+		t.Fatal(err)
+	}
+	job, err := cacheJobs.GetCacheJob(&query.CacheJobQuery{InstanceId: name, Org: name, Repo: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job == nil {
+		t.Fatal("cache job not found")
+	}
+	if err = cacheJobs.UpdateCacheStatus(&query.UpdateJobStatusReq{Id: job.ID, Status: consts.RunningStatusJobBreak, ErrorMsg: name, Process: 50}); err != nil {
+		t.Fatal(err)
+	}
+
+	var count int64
+	if err = baseData.BizDB.Table("repository").Count(&count).Error; err != nil {
+		t.Fatalf("repository table damaged: %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"dingoscheduler/internal/data"
+	"dingoscheduler/internal/model"
+
+	"gorm.io/gorm/clause"
+)
+
+type IntegrityDao struct {
+	baseData *data.BaseData
+}
+
+func NewIntegrityDao(data *data.BaseData) *IntegrityDao {
+	return &IntegrityDao{baseData: data}
+}
+
+func (d *IntegrityDao) SaveChecksums(checksums []*model.FileChecksum) error {
+	if len(checksums) == 0 {
+		return nil
+	}
+	return d.baseData.BizDB.Select("process_id", "etag", "start_pos", "end_pos", "sha256").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "process_id"}, {Name: "start_pos"}, {Name: "end_pos"}},
+		DoUpdates: clause.AssignmentColumns([]string{"sha256"}),
+	}).Create(checksums).Error
+}
+
+func (d *IntegrityDao) VerifyChecksums(processId int64) error {
+	return d.baseData.BizDB.Model(&model.FileChecksum{}).Where("process_id = ?", processId).Update("verified", true).Error
+}
+
+func (d *IntegrityDao) ReferenceChecksums(etag string) ([]*model.FileChecksum, error) {
+	var checksums []*model.FileChecksum
+	if err := d.baseData.BizDB.Where("etag = ? and verified = ?", etag, true).Order("id").Find(&checksums).Error; err != nil {
+		return nil, err
+	}
+	return checksums, nil
+}
+
+func (d *IntegrityDao) DeleteChecksums(processId int64) error {
+	return d.baseData.BizDB.Where("process_id = ?", processId).Delete(&model.FileChecksum{}).Error
+}
+
+func (d *IntegrityDao) Quarantine(etag, instanceId string, expiresAt time.Time) error {
+	return d.baseData.BizDB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "etag"}, {Name: "instance_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"expires_at"}),
+	}).Create(&model.PeerQuarantine{Etag: etag, InstanceID: instanceId, ExpiresAt: expiresAt.UnixMilli()}).Error
+}
+
+func (d *IntegrityDao) QuarantinedInstances(etag string, now time.Time) ([]string, error) {
+	var instanceIds []string
+	if err := d.baseData.BizDB.Model(&model.PeerQuarantine{}).Where("etag = ? and expires_at > ?", etag, now.UnixMilli()).
+		Pluck("instance_id", &instanceIds).Error; err != nil {
+		return nil, err
+	}
+	return instanceIds, nil
+}
+
+func (d *IntegrityDao) Purge(now time.Time) (int64, error) {
+	db := d.baseData.BizDB
+	res := db.Where("process_id not in (select id from model_file_process)").Delete(&model.FileChecksum{})
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	purged := res.RowsAffected
+	res = db.Where("expires_at <= ?", now.UnixMilli()).Delete(&model.PeerQuarantine{})
+	return purged + res.RowsAffected, res.Error
+}
@@ -0,0 +1,82 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"dingoscheduler/internal/data"
+	"dingoscheduler/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LeaseDao struct {
+	baseData *data.BaseData
+}
+
+func NewLeaseDao(data *data.BaseData) *LeaseDao {
+	return &LeaseDao{baseData: data}
+}
+
+// Acquire 租约不存在、已过期或本就由holder持有时取得或续约，过期时间以数据库时钟计算，不受各副本时钟偏差影响
+func (d *LeaseDao) Acquire(name, holder string, ttl time.Duration) (bool, error) {
+	db := d.baseData.BizDB
+	now := dbNowMillis(db)
+	expiresAt := gorm.Expr(now+" + ?", ttl.Milliseconds())
+	res := db.Model(&model.SchedulerLease{}).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(map[string]interface{}{"name": name, "holder": holder, "expires_at": expiresAt})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected > 0 {
+		return true, nil
+	}
+	res = db.Model(&model.SchedulerLease{}).
+		Where("name = ? and (holder = ? or expires_at < "+now+")", name, holder).
+		Updates(map[string]interface{}{"holder": holder, "expires_at": expiresAt})
+	return res.RowsAffected > 0, res.Error
+}
+
+// Release 释放holder持有的租约，已被其他持有者接管时不做处理
+func (d *LeaseDao) Release(name, holder string) error {
+	return d.baseData.BizDB.Where("name = ? and holder = ?", name, holder).Delete(&model.SchedulerLease{}).Error
+}
+
+// Holder 返回租约当前的持有者，无人持有或已过期时为空串
+func (d *LeaseDao) Holder(name string) (string, error) {
+	var holders []string
+	if err := d.baseData.BizDB.Model(&model.SchedulerLease{}).Where("name = ? and expires_at >= "+dbNowMillis(d.baseData.BizDB), name).
+		Limit(1).Pluck("holder", &holders).Error; err != nil {
+		return "", err
+	}
+	if len(holders) == 0 {
+		return "", nil
+	}
+	return holders[0], nil
+}
+
+// dbNowMillis 数据库当前时间的unix毫秒时间戳表达式
+func dbNowMillis(db *gorm.DB) string {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return "CAST(EXTRACT(EPOCH FROM CLOCK_TIMESTAMP()) * 1000 AS BIGINT)"
+	case "sqlite":
+		return "CAST((JULIANDAY('now') - 2440587.5) * 86400000 AS INTEGER)"
+	default:
+		return "CAST(UNIX_TIMESTAMP(NOW(3)) * 1000 AS SIGNED)"
+	}
+}
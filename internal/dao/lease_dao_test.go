@@ -0,0 +1,128 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"dingoscheduler/internal/model"
+	"dingoscheduler/pkg/config"
+)
+
+func TestLeaseAcquireRelease(t *testing.T) {
+	leases := NewLeaseDao(newSqliteData(t))
+	if ok, err := leases.Acquire("leader", "a", time.Minute); err != nil || !ok {
+		t.Fatalf("a acquire %v, err %v", ok, err)
+	}
+	var lease model.SchedulerLease
+	if err := leases.baseData.BizDB.Where("name = ?", "leader").First(&lease).Error; err != nil {
+		t.Fatal(err)
+	}
+	// 过期时间由数据库时钟计算
+	if d := lease.ExpiresAt - time.Now().Add(time.Minute).UnixMilli(); d < -5000 || d > 5000 {
+		t.Fatalf("expires at %d, off by %dms", lease.ExpiresAt, d)
+	}
+	if ok, err := leases.Acquire("leader", "b", time.Minute); err != nil || ok {
+		t.Fatalf("b acquired a held lease, err %v", err)
+	}
+	// 持有者续约成功
+	if ok, err := leases.Acquire("leader", "a", time.Minute); err != nil || !ok {
+		t.Fatalf("a renew %v, err %v", ok, err)
+	}
+	if holder, err := leases.Holder("leader"); err != nil || holder != "a" {
+		t.Fatalf("holder %q, err %v", holder, err)
+	}
+	// 非持有者释放不生效
+	if err := leases.Release("leader", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if holder, _ := leases.Holder("leader"); holder != "a" {
+		t.Fatalf("holder %q after foreign release", holder)
+	}
+	if err := leases.Release("leader", "a"); err != nil {
+		t.Fatal(err)
+	}
+	if holder, _ := leases.Holder("leader"); holder != "" {
+		t.Fatalf("holder %q after release", holder)
+	}
+}
+
+func TestLeaseTakeOverExpired(t *testing.T) {
+	leases := NewLeaseDao(newSqliteData(t))
+	if ok, err := leases.Acquire("leader", "a", -time.Second); err != nil || !ok {
+		t.Fatalf("a acquire %v, err %v", ok, err)
+	}
+	if holder, _ := leases.Holder("leader"); holder != "" {
+		t.Fatalf("expired lease still held by %q", holder)
+	}
+	if ok, err := leases.Acquire("leader", "b", time.Minute); err != nil || !ok {
+		t.Fatalf("b take over %v, err %v", ok, err)
+	}
+	if ok, _ := leases.Acquire("leader", "a", time.Minute); ok {
+		t.Fatal("a renewed a lease taken over by b")
+	}
+}
+
+func TestLeaseLockerExcludesReplicas(t *testing.T) {
+	baseData := newSqliteData(t)
+	config.SysConfig.Scheduler.HA = config.HA{Enabled: true, LockWait: 1}
+	// 两个LeaseLocker模拟两个副本，共用同一张租约表
+	first, second := NewLeaseLocker(NewLeaseDao(baseData)), NewLeaseLocker(NewLeaseDao(baseData))
+	key := "scheduler/models/org/repo/" + strings.Repeat("e", 300)
+	_, unlock, err := first.Lock(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = second.Lock(context.Background(), key); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("second lock err %v, want deadline exceeded", err)
+	}
+	unlock()
+	unlock()
+	_, unlockAll, err := second.LockAll(context.Background(), []string{key, "persistRepo", key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlockAll()
+	if holder, _ := NewLeaseDao(baseData).Holder(leaseName(key)); holder != "" {
+		t.Fatalf("lease still held by %q", holder)
+	}
+}
+
+func TestLeaseLockerCancelsOnLoss(t *testing.T) {
+	baseData := newSqliteData(t)
+	config.SysConfig.Scheduler.HA = config.HA{Enabled: true, LockLease: 1, LockWait: 1}
+	locker := NewLeaseLocker(NewLeaseDao(baseData))
+	ctx, unlock, err := locker.LockAll(context.Background(), []string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+	// 其他副本接管了租约
+	if err = baseData.BizDB.Model(&model.SchedulerLease{}).Where("name = ?", "b").Update("holder", "other").Error; err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(3 * time.Second):
+		t.Fatal("ctx not canceled after the lease was lost")
+	}
+	if !errors.Is(context.Cause(ctx), ErrLeaseLost) {
+		t.Fatalf("cause %v, want lease lost", context.Cause(ctx))
+	}
+}
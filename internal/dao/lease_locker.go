@@ -0,0 +1,168 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/util"
+
+	"go.uber.org/zap"
+)
+
+// 租约名的最大长度，与scheduler_lease.name一致
+const maxLeaseName = 255
+
+// ErrLeaseLost 持有期间租约被其他持有者接管或未能按时续约
+var ErrLeaseLost = errors.New("lease lost")
+
+// lockSeq 区分本进程的每次加锁，释放时只删除自己取得的租约
+var lockSeq atomic.Int64
+
+var holder = sync.OnceValue(func() string {
+	return fmt.Sprintf("%s/%s", config.SysConfig.GetNodeId(), util.UUID()[:8])
+})
+
+// Holder 本进程持有租约时使用的标识，由副本标识和启动时生成的随机串组成，重启后不会沿用旧进程的租约
+func Holder() string {
+	return holder()
+}
+
+// LeaseLocker 基于租约表的跨副本互斥锁，未开启多副本部署时不访问数据库。
+// 只负责副本间的互斥，同一进程内的并发仍由调用方先用本地锁控制，避免本进程的请求轮询数据库。
+type LeaseLocker struct {
+	leases LeaseStore
+}
+
+func NewLeaseLocker(leases LeaseStore) *LeaseLocker {
+	return &LeaseLocker{leases: leases}
+}
+
+// Lock 获取key的租约并在持有期间定期续约，返回持有期间有效的ctx和释放函数，等待超过lockWait或ctx结束时返回错误。
+// 租约丢失时ctx被取消，context.Cause为ErrLeaseLost，调用方在写入前检查ctx，不在失去互斥后继续执行临界区。
+func (l *LeaseLocker) Lock(ctx context.Context, key string) (context.Context, func(), error) {
+	if !config.SysConfig.GetHAEnabled() {
+		return ctx, func() {}, nil
+	}
+	name, owner, ttl := leaseName(key), fmt.Sprintf("%s/%d", Holder(), lockSeq.Add(1)), config.SysConfig.GetLockLease()
+	waitCtx, cancel := context.WithTimeout(ctx, config.SysConfig.GetLockWait())
+	defer cancel()
+	backoff := 10 * time.Millisecond
+	for {
+		ok, err := l.leases.Acquire(name, owner, ttl)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			break
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-waitCtx.Done():
+			timer.Stop()
+			return nil, nil, fmt.Errorf("lock %s: %w", key, waitCtx.Err())
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, 200*time.Millisecond)
+	}
+	heldCtx, lost := context.WithCancelCause(ctx)
+	stop, done := make(chan struct{}), make(chan struct{})
+	go l.keepAlive(key, name, owner, ttl, lost, stop, done)
+	var once sync.Once
+	return heldCtx, func() {
+		once.Do(func() {
+			close(stop)
+			<-done
+			lost(nil)
+			if err := l.leases.Release(name, owner); err != nil {
+				zap.S().Errorf("release lock %s err.%v", key, err)
+			}
+		})
+	}, nil
+}
+
+// LockAll 按排序后的固定顺序获取多个key的租约，避免副本间死锁，失败时释放已取得的租约。
+// 返回的ctx在任一租约丢失时被取消。
+func (l *LeaseLocker) LockAll(ctx context.Context, keys []string) (context.Context, func(), error) {
+	keys = slices.Clone(keys)
+	sort.Strings(keys)
+	keys = slices.Compact(keys)
+	unlocks := make([]func(), 0, len(keys))
+	unlockAll := func() {
+		for i := len(unlocks) - 1; i >= 0; i-- {
+			unlocks[i]()
+		}
+	}
+	for _, key := range keys {
+		heldCtx, unlock, err := l.Lock(ctx, key)
+		if err != nil {
+			unlockAll()
+			return nil, nil, err
+		}
+		ctx = heldCtx
+		unlocks = append(unlocks, unlock)
+	}
+	return ctx, unlockAll, nil
+}
+
+// keepAlive 持有期间每隔租约的三分之一续约一次，临界区执行时间超过租约时不会被其他副本抢占。
+// 租约被接管或超过一个租约时长未能续约时视为丢失，取消持有者的ctx并停止续约。
+func (l *LeaseLocker) keepAlive(key, name, owner string, ttl time.Duration, lost context.CancelCauseFunc, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+	renewed := time.Now()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ok, err := l.leases.Acquire(name, owner, ttl)
+			switch {
+			case err != nil && time.Since(renewed) < ttl:
+				zap.S().Errorf("renew lock %s err.%v", key, err)
+			case err != nil:
+				zap.S().Errorf("renew lock %s err.%v, lease expired.", key, err)
+				lost(fmt.Errorf("lock %s: %w", key, ErrLeaseLost))
+				return
+			case !ok:
+				zap.S().Warnf("lock %s lost, lease taken over by another holder.", key)
+				lost(fmt.Errorf("lock %s: %w", key, ErrLeaseLost))
+				return
+			default:
+				renewed = time.Now()
+			}
+		}
+	}
+}
+
+// leaseName 超长的key取摘要作为租约名
+func leaseName(key string) string {
+	if len(key) <= maxLeaseName {
+		return key
+	}
+	sum := sha256.Sum256([]byte(key))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -1,37 +1,25 @@
 package dao
 
 import (
+	"context"
 	"fmt"
-	"sync"
-	"time"
 
-	"dingoscheduler/internal/data"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/keylock"
 )
 
+// LockDao 缓存任务的创建按仓库、停止恢复删除按任务id互斥
 type LockDao struct {
-	baseData           *data.BaseData
-	cacheJobReqMu      sync.Mutex
-	cacheJobReqTimeout time.Duration
+	cacheJobLocks *keylock.Manager
 }
 
-func NewLockDao(baseData *data.BaseData) *LockDao {
-	return &LockDao{baseData: baseData, cacheJobReqTimeout: 60 * time.Second}
+func NewLockDao() *LockDao {
+	return &LockDao{cacheJobLocks: keylock.New("cacheJob", config.SysConfig.GetLockTimeout())}
 }
 
-func (f *LockDao) GetCacheJobReqLock(orgRepoKey string) *sync.RWMutex {
-	if val, ok := f.baseData.Cache.Get(orgRepoKey); ok {
-		f.baseData.Cache.Set(orgRepoKey, val, f.cacheJobReqTimeout)
-		return val.(*sync.RWMutex)
-	}
-	f.cacheJobReqMu.Lock()
-	defer f.cacheJobReqMu.Unlock()
-	if val, ok := f.baseData.Cache.Get(orgRepoKey); ok {
-		f.baseData.Cache.Set(orgRepoKey, val, f.cacheJobReqTimeout)
-		return val.(*sync.RWMutex)
-	}
-	newLock := &sync.RWMutex{}
-	f.baseData.Cache.Set(orgRepoKey, newLock, f.cacheJobReqTimeout)
-	return newLock
+// LockCacheJob 获取缓存任务的锁，返回释放函数
+func (f *LockDao) LockCacheJob(ctx context.Context, key string) (func(), error) {
+	return f.cacheJobLocks.Lock(ctx, GetCacheJobOrgRepoKey(key))
 }
 
 func GetCacheJobOrgRepoKey(orgRepo string) string {
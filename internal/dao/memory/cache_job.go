@@ -0,0 +1,159 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package memory
+
+import (
+	"slices"
+	"sort"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/model/query"
+	"dingoscheduler/pkg/consts"
+	"dingoscheduler/pkg/util"
+)
+
+type CacheJobStore struct {
+	db           *DB
+	repositories *RepositoryStore
+}
+
+var _ dao.CacheJobStore = (*CacheJobStore)(nil)
+
+func NewCacheJobStore(db *DB, repositories *RepositoryStore) *CacheJobStore {
+	return &CacheJobStore{db: db, repositories: repositories}
+}
+
+func (s *CacheJobStore) Save(preheatJob *model.CacheJob) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	now := util.Now()
+	if preheatJob.ID == 0 {
+		preheatJob.ID = s.db.nextId()
+		preheatJob.CreatedAt = now
+	}
+	preheatJob.UpdatedAt = now
+	row := *preheatJob
+	s.db.cacheJobs[row.ID] = &row
+	return nil
+}
+
+func (s *CacheJobStore) GetCacheJob(condition *query.CacheJobQuery) (*model.CacheJob, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	for _, row := range s.sortedCacheJobs() {
+		if matchCacheJob(condition, row) {
+			cacheJob := *row
+			return &cacheJob, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *CacheJobStore) ListCacheJob(condition *query.CacheJobQuery) ([]*model.CacheJob, int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	cacheJobs := make([]*model.CacheJob, 0)
+	for _, row := range s.sortedCacheJobs() {
+		if matchCacheJob(condition, row) {
+			cacheJob := *row
+			cacheJobs = append(cacheJobs, &cacheJob)
+		}
+	}
+	sort.SliceStable(cacheJobs, func(i, j int) bool { return cacheJobs[i].CreatedAt.After(cacheJobs[j].CreatedAt) })
+	count := int64(len(cacheJobs))
+	offset, pageSize := dao.Paginate(condition.Page, condition.PageSize)
+	if offset >= len(cacheJobs) {
+		return []*model.CacheJob{}, count, nil
+	}
+	return cacheJobs[offset:min(offset+pageSize, len(cacheJobs))], count, nil
+}
+
+func (s *CacheJobStore) GetUnCacheJob(instanceId string, ids []int, runningStatus []int32, limit int) ([]*model.CacheJob, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	cacheJobs := make([]*model.CacheJob, 0)
+	for _, row := range s.sortedCacheJobs() {
+		if limit > 0 && len(cacheJobs) >= limit {
+			break
+		}
+		if instanceId != "" && row.InstanceId != instanceId {
+			continue
+		}
+		if len(ids) > 0 && !slices.Contains(ids, int(row.ID)) {
+			continue
+		}
+		if len(runningStatus) > 0 && !slices.Contains(runningStatus, row.Status) {
+			continue
+		}
+		cacheJob := *row
+		cacheJobs = append(cacheJobs, &cacheJob)
+	}
+	return cacheJobs, nil
+}
+
+func (s *CacheJobStore) UpdateCacheStatus(statusReq *query.UpdateJobStatusReq) error {
+	errorMsg, err := errorMsgColumn(statusReq.ErrorMsg)
+	if err != nil {
+		return err
+	}
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if row, ok := s.db.cacheJobs[statusReq.Id]; ok {
+		row.Status, row.ErrorMsg = statusReq.Status, errorMsg
+		if statusReq.Process > 0 {
+			row.Process = statusReq.Process
+		}
+		row.UpdatedAt = util.Now()
+	}
+	return nil
+}
+
+func (s *CacheJobStore) UpdateStatusAndRepo(jobStatusReq *query.UpdateJobStatusReq) error {
+	if err := s.UpdateCacheStatus(jobStatusReq); err != nil {
+		return err
+	}
+	if jobStatusReq.Status == consts.RunningStatusJobComplete {
+		return s.repositories.PersistRepo(&query.PersistRepoReq{InstanceIds: []string{jobStatusReq.InstanceId},
+			Org: jobStatusReq.Org, Repo: jobStatusReq.Repo, OffVerify: true})
+	}
+	return nil
+}
+
+func (s *CacheJobStore) Delete(id int64) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	delete(s.db.cacheJobs, id)
+	return nil
+}
+
+func matchCacheJob(condition *query.CacheJobQuery, row *model.CacheJob) bool {
+	return (condition.Id == 0 || row.ID == condition.Id) &&
+		(condition.Type == 0 || row.Type == condition.Type) &&
+		(condition.InstanceId == "" || row.InstanceId == condition.InstanceId) &&
+		(condition.Datatype == "" || row.Datatype == condition.Datatype) &&
+		(condition.Org == "" || row.Org == condition.Org) &&
+		(condition.Repo == "" || row.Repo == condition.Repo)
+}
+
+// sortedCacheJobs 按id排序，调用方须持有mu
+func (s *CacheJobStore) sortedCacheJobs() []*model.CacheJob {
+	rows := make([]*model.CacheJob, 0, len(s.db.cacheJobs))
+	for _, row := range s.db.cacheJobs {
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+	return rows
+}
@@ -0,0 +1,82 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package memory
+
+import (
+	"sync"
+
+	"dingoscheduler/internal/model"
+
+	"github.com/bytedance/sonic"
+)
+
+// DB 各存储共用的内存表，所有读写在同一把锁下进行，相当于每次操作都是一个事务。
+// 写入的时间取自util.Now，回放trace时与录制的时间一致
+type DB struct {
+	mu           sync.Mutex
+	speeds       map[int32]*model.Dingospeed
+	records      map[int64]*model.ModelFileRecord
+	processes    map[int64]*model.ModelFileProcess
+	recordKeys   map[recordKey]int64  // 与数据库的唯一键一致，(datatype, org, repo, name, etag) -> recordId
+	processKeys  map[processKey]int64 // (record_id, instance_id) -> processId
+	repositories map[int64]*model.Repository
+	cacheJobs    map[int64]*model.CacheJob
+	tags         map[string]*model.Tag
+	repoTags     map[int64][]string // repoId -> tagIds
+	orgs         map[string]*model.Organization
+	hfTokens     []*model.HfToken
+	leases       map[string]*model.SchedulerLease
+	events       []*model.SchedulerEvent // 按id递增
+	checksums    map[int64]*model.FileChecksum
+	quarantines  map[quarantineKey]*model.PeerQuarantine
+	lastSpeedId  int32
+	lastId       int64
+}
+
+func NewDB() *DB {
+	return &DB{
+		speeds:       make(map[int32]*model.Dingospeed),
+		records:      make(map[int64]*model.ModelFileRecord),
+		processes:    make(map[int64]*model.ModelFileProcess),
+		recordKeys:   make(map[recordKey]int64),
+		processKeys:  make(map[processKey]int64),
+		repositories: make(map[int64]*model.Repository),
+		cacheJobs:    make(map[int64]*model.CacheJob),
+		tags:         make(map[string]*model.Tag),
+		repoTags:     make(map[int64][]string),
+		orgs:         make(map[string]*model.Organization),
+		leases:       make(map[string]*model.SchedulerLease),
+		checksums:    make(map[int64]*model.FileChecksum),
+		quarantines:  make(map[quarantineKey]*model.PeerQuarantine),
+	}
+}
+
+// nextId 所有表共用一个自增序列，调用方须持有mu
+func (db *DB) nextId() int64 {
+	db.lastId++
+	return db.lastId
+}
+
+// errorMsgColumn 与MySQL实现一致，错误信息以{"msg": ...}的形式保存
+func errorMsgColumn(errorMsg string) (string, error) {
+	if errorMsg == "" {
+		return "", nil
+	}
+	b, err := sonic.Marshal(map[string]string{"msg": errorMsg})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
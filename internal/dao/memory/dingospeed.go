@@ -0,0 +1,157 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/data"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/consts"
+	"dingoscheduler/pkg/util"
+)
+
+type DingospeedStore struct {
+	db       *DB
+	baseData *data.BaseData
+}
+
+var _ dao.DingospeedStore = (*DingospeedStore)(nil)
+
+func NewDingospeedStore(db *DB, baseData *data.BaseData) *DingospeedStore {
+	return &DingospeedStore{db: db, baseData: baseData}
+}
+
+func (s *DingospeedStore) Save(speed *model.Dingospeed) (int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.lastSpeedId++
+	now := util.Now()
+	s.db.speeds[s.db.lastSpeedId] = &model.Dingospeed{
+		ID:         s.db.lastSpeedId,
+		InstanceID: speed.InstanceID,
+		Host:       speed.Host,
+		Port:       speed.Port,
+		Online:     speed.Online,
+		Aidc:       speed.Aidc,
+		Status:     consts.SpeedStatusAlive,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	return int64(s.db.lastSpeedId), nil
+}
+
+func (s *DingospeedStore) RegisterUpdate(speed *model.Dingospeed) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if row, ok := s.db.speeds[speed.ID]; ok {
+		row.Host, row.Port, row.Aidc = speed.Host, speed.Port, speed.Aidc
+		row.Status = consts.SpeedStatusAlive
+		row.UpdatedAt = util.Now()
+	}
+	return nil
+}
+
+func (s *DingospeedStore) HeartbeatUpdate(speed *model.Dingospeed) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if row, ok := s.db.speeds[speed.ID]; ok {
+		row.CopyLoad(speed)
+		row.Status = consts.SpeedStatusAlive
+		row.UpdatedAt = util.Now()
+	}
+	return nil
+}
+
+// GetEntity 与MySQL实现一样，查询结果放入缓存，调度从缓存读取节点信息
+func (s *DingospeedStore) GetEntity(instanceId string, online bool) (*model.Dingospeed, error) {
+	speedKey := util.GetSpeedKey(instanceId, online)
+	var cached *model.Dingospeed
+	if s.baseData.Cache.Get(speedKey, &cached) {
+		s.baseData.Cache.Set(speedKey, cached, config.SysConfig.GetSpeedExpiration())
+		return cached, nil
+	}
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	for _, row := range s.db.speeds {
+		if row.InstanceID == instanceId && row.Online == online {
+			speed := *row
+			s.baseData.Cache.Set(speedKey, &speed, config.SysConfig.GetSpeedExpiration())
+			return &speed, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *DingospeedStore) ListExpired(deadline time.Time) ([]*model.Dingospeed, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	speeds := make([]*model.Dingospeed, 0)
+	for _, row := range s.db.speeds {
+		if row.Status == consts.SpeedStatusAlive && row.UpdatedAt.Before(deadline) {
+			speed := *row
+			speeds = append(speeds, &speed)
+		}
+	}
+	return speeds, nil
+}
+
+func (s *DingospeedStore) Expire(id int32, instanceId string, deadline time.Time,
+	event func([]*model.ModelFileProcess) (*model.SchedulerEvent, error)) ([]*model.ModelFileProcess, bool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	row, ok := s.db.speeds[id]
+	if !ok || row.Status != consts.SpeedStatusAlive || !row.UpdatedAt.Before(deadline) {
+		return nil, false, nil
+	}
+	// 先生成事件，失败时不改动任何数据，与事务回滚一致
+	processes := s.db.masterProcesses(instanceId)
+	var e *model.SchedulerEvent
+	if event != nil {
+		var err error
+		if e, err = event(processes); err != nil {
+			return nil, false, err
+		}
+	}
+	row.Status = consts.SpeedStatusExpired
+	for _, p := range processes {
+		s.db.processes[p.ID].MasterInstanceID = ""
+	}
+	if e != nil {
+		e.ID, e.CreatedAt = s.db.nextId(), util.Now()
+		s.db.events = append(s.db.events, e)
+	}
+	return processes, true, nil
+}
+
+func (s *DingospeedStore) List() ([]*model.Dingospeed, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	speeds := make([]*model.Dingospeed, 0, len(s.db.speeds))
+	for _, row := range s.db.speeds {
+		speed := *row
+		speeds = append(speeds, &speed)
+	}
+	sort.Slice(speeds, func(i, j int) bool {
+		if speeds[i].InstanceID != speeds[j].InstanceID {
+			return speeds[i].InstanceID < speeds[j].InstanceID
+		}
+		return !speeds[i].Online && speeds[j].Online
+	})
+	return speeds, nil
+}
@@ -0,0 +1,80 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package memory
+
+import (
+	"time"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/pkg/util"
+)
+
+type EventStore struct {
+	db *DB
+}
+
+var _ dao.EventStore = (*EventStore)(nil)
+
+func NewEventStore(db *DB) *EventStore {
+	return &EventStore{db: db}
+}
+
+func (s *EventStore) Append(topic string, payload []byte) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.events = append(s.db.events, &model.SchedulerEvent{ID: s.db.nextId(), Topic: topic, Payload: string(payload), CreatedAt: util.Now()})
+	return nil
+}
+
+func (s *EventStore) ListAfter(afterId int64, limit int) ([]*model.SchedulerEvent, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	result := make([]*model.SchedulerEvent, 0)
+	for _, row := range s.db.events {
+		if row.ID <= afterId {
+			continue
+		}
+		if len(result) >= limit {
+			break
+		}
+		ev := *row
+		result = append(result, &ev)
+	}
+	return result, nil
+}
+
+func (s *EventStore) LastId() (int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if len(s.db.events) == 0 {
+		return 0, nil
+	}
+	return s.db.events[len(s.db.events)-1].ID, nil
+}
+
+func (s *EventStore) DeleteBefore(t time.Time) (int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	kept := s.db.events[:0]
+	for _, row := range s.db.events {
+		if !row.CreatedAt.Before(t) {
+			kept = append(kept, row)
+		}
+	}
+	deleted := int64(len(s.db.events) - len(kept))
+	s.db.events = kept
+	return deleted, nil
+}
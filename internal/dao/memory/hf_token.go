@@ -0,0 +1,63 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package memory
+
+import (
+	"fmt"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/model"
+)
+
+type HfTokenStore struct {
+	db *DB
+}
+
+var _ dao.HfTokenStore = (*HfTokenStore)(nil)
+
+func NewHfTokenStore(db *DB) *HfTokenStore {
+	return &HfTokenStore{db: db}
+}
+
+func (s *HfTokenStore) Save(hfToken *model.HfToken) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	row := *hfToken
+	if row.ID == 0 {
+		row.ID = s.db.nextId()
+	}
+	s.db.hfTokens = append(s.db.hfTokens, &row)
+	return nil
+}
+
+// RefreshToken 不缓存token，每次都读取第一个启用的token
+func (s *HfTokenStore) RefreshToken() string {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	for _, row := range s.db.hfTokens {
+		if row.Enabled {
+			return row.Token
+		}
+	}
+	return ""
+}
+
+func (s *HfTokenStore) GetHeaders() map[string]string {
+	m := make(map[string]string)
+	if token := s.RefreshToken(); token != "" {
+		m["Authorization"] = fmt.Sprintf("Bearer %s", token)
+	}
+	return m
+}
@@ -0,0 +1,138 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/model"
+)
+
+type quarantineKey struct {
+	etag, instanceId string
+}
+
+type IntegrityStore struct {
+	db *DB
+}
+
+var _ dao.IntegrityStore = (*IntegrityStore)(nil)
+
+func NewIntegrityStore(db *DB) *IntegrityStore {
+	return &IntegrityStore{db: db}
+}
+
+func (s *IntegrityStore) SaveChecksums(checksums []*model.FileChecksum) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	for _, checksum := range checksums {
+		if row := s.findChecksum(checksum.ProcessID, checksum.StartPos, checksum.EndPos); row != nil {
+			row.Sha256 = checksum.Sha256
+			continue
+		}
+		row := *checksum
+		row.ID, row.Verified = s.db.nextId(), false
+		s.db.checksums[row.ID] = &row
+	}
+	return nil
+}
+
+// findChecksum 与数据库的唯一键(process_id, start_pos, end_pos)一致，调用方须持有mu
+func (s *IntegrityStore) findChecksum(processId, startPos, endPos int64) *model.FileChecksum {
+	for _, row := range s.db.checksums {
+		if row.ProcessID == processId && row.StartPos == startPos && row.EndPos == endPos {
+			return row
+		}
+	}
+	return nil
+}
+
+func (s *IntegrityStore) VerifyChecksums(processId int64) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	for _, row := range s.db.checksums {
+		if row.ProcessID == processId {
+			row.Verified = true
+		}
+	}
+	return nil
+}
+
+func (s *IntegrityStore) ReferenceChecksums(etag string) ([]*model.FileChecksum, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	checksums := make([]*model.FileChecksum, 0)
+	for _, row := range s.db.checksums {
+		if row.Etag == etag && row.Verified {
+			checksum := *row
+			checksums = append(checksums, &checksum)
+		}
+	}
+	sort.Slice(checksums, func(i, j int) bool { return checksums[i].ID < checksums[j].ID })
+	return checksums, nil
+}
+
+func (s *IntegrityStore) DeleteChecksums(processId int64) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	for id, row := range s.db.checksums {
+		if row.ProcessID == processId {
+			delete(s.db.checksums, id)
+		}
+	}
+	return nil
+}
+
+func (s *IntegrityStore) Quarantine(etag, instanceId string, expiresAt time.Time) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.quarantines[quarantineKey{etag, instanceId}] = &model.PeerQuarantine{Etag: etag, InstanceID: instanceId,
+		ExpiresAt: expiresAt.UnixMilli()}
+	return nil
+}
+
+func (s *IntegrityStore) QuarantinedInstances(etag string, now time.Time) ([]string, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	instanceIds := make([]string, 0)
+	for key, row := range s.db.quarantines {
+		if key.etag == etag && row.ExpiresAt > now.UnixMilli() {
+			instanceIds = append(instanceIds, key.instanceId)
+		}
+	}
+	sort.Strings(instanceIds)
+	return instanceIds, nil
+}
+
+func (s *IntegrityStore) Purge(now time.Time) (int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	var purged int64
+	for id, row := range s.db.checksums {
+		if _, ok := s.db.processes[row.ProcessID]; !ok {
+			delete(s.db.checksums, id)
+			purged++
+		}
+	}
+	for key, row := range s.db.quarantines {
+		if row.ExpiresAt <= now.UnixMilli() {
+			delete(s.db.quarantines, key)
+			purged++
+		}
+	}
+	return purged, nil
+}
@@ -0,0 +1,62 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package memory
+
+import (
+	"time"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/pkg/util"
+)
+
+type LeaseStore struct {
+	db *DB
+}
+
+var _ dao.LeaseStore = (*LeaseStore)(nil)
+
+func NewLeaseStore(db *DB) *LeaseStore {
+	return &LeaseStore{db: db}
+}
+
+func (s *LeaseStore) Acquire(name, holder string, ttl time.Duration) (bool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	now := util.Now()
+	if row, ok := s.db.leases[name]; ok && row.Holder != holder && row.ExpiresAt >= now.UnixMilli() {
+		return false, nil
+	}
+	s.db.leases[name] = &model.SchedulerLease{Name: name, Holder: holder, ExpiresAt: now.Add(ttl).UnixMilli()}
+	return true, nil
+}
+
+func (s *LeaseStore) Release(name, holder string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if row, ok := s.db.leases[name]; ok && row.Holder == holder {
+		delete(s.db.leases, name)
+	}
+	return nil
+}
+
+func (s *LeaseStore) Holder(name string) (string, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if row, ok := s.db.leases[name]; ok && row.ExpiresAt >= util.Now().UnixMilli() {
+		return row.Holder, nil
+	}
+	return "", nil
+}
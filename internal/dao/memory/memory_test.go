@@ -0,0 +1,215 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package memory
+
+import (
+	"testing"
+
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/model/dto"
+	"dingoscheduler/internal/model/query"
+	"dingoscheduler/pkg/common"
+	"dingoscheduler/pkg/consts"
+	pb "dingoscheduler/pkg/proto/manager"
+)
+
+func newFile(t *testing.T, records *RecordStore, instanceId, repo, name string, fileSize int64) int64 {
+	t.Helper()
+	processId, _, err := records.SaveSchedulerRecord(&pb.SchedulerFileRequest{
+		DataType: "models", Org: "org", Repo: repo, Name: name, Etag: repo + "/" + name, FileSize: fileSize,
+	}, &model.ModelFileProcess{InstanceID: instanceId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return processId
+}
+
+func report(t *testing.T, processes *ProcessStore, processId int64, ranges common.RangeSet) {
+	t.Helper()
+	if err := processes.BatchReportFileProcess(map[int64]*dto.ProcessReport{
+		processId: {Ranges: ranges, Status: consts.StatusDownloading},
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReportFileProcessMergesRanges(t *testing.T) {
+	db := NewDB()
+	records, processes := NewRecordStore(db), NewProcessStore(db)
+	processId := newFile(t, records, "speed-a", "repo", "model.bin", 30)
+
+	report(t, processes, processId, common.RangeSet{}.Add(0, 10).Add(20, 30))
+	process, err := processes.GetById(processId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if process.OffsetNum != 10 {
+		t.Fatalf("offset = %d, want 10", process.OffsetNum)
+	}
+	p, err := processes.GetModelFileProcessByInstanceId(process.RecordID, "speed-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.HasRange(20, 30) || p.HasRange(10, 20) {
+		t.Fatalf("ranges = %q, want [0,10) and [20,30)", p.Ranges)
+	}
+
+	report(t, processes, processId, common.RangeSet{}.Add(10, 20))
+	if process, _ = processes.GetById(processId); process.OffsetNum != 30 {
+		t.Fatalf("offset = %d, want 30", process.OffsetNum)
+	}
+}
+
+func TestSaveSchedulerRecordIsIdempotent(t *testing.T) {
+	db := NewDB()
+	records, processes := NewRecordStore(db), NewProcessStore(db)
+	first := newFile(t, records, "speed-a", "repo", "model.bin", 30)
+	if second := newFile(t, records, "speed-a", "repo", "model.bin", 30); second != first {
+		t.Fatalf("second save created process %d, want %d", second, first)
+	}
+	other := newFile(t, records, "speed-b", "repo", "model.bin", 30)
+	a, _ := processes.GetById(first)
+	b, _ := processes.GetById(other)
+	if other == first || a.RecordID != b.RecordID {
+		t.Fatalf("processes %+v and %+v should share one record", a, b)
+	}
+	if _, err := processes.DeleteByIds([]int64{first}); err != nil {
+		t.Fatal(err)
+	}
+	if again := newFile(t, records, "speed-a", "repo", "model.bin", 30); again == first {
+		t.Fatal("deleted process was returned again")
+	}
+}
+
+func TestPersistRepoRequiresCompleteFiles(t *testing.T) {
+	db := NewDB()
+	records, processes, repositories := NewRecordStore(db), NewProcessStore(db), NewRepositoryStore(db)
+	req := &query.PersistRepoReq{InstanceIds: []string{"speed-a"}}
+	if err := repositories.PersistRepo(req); err == nil {
+		t.Fatal("persist for unregistered dingospeed should fail")
+	}
+	if _, err := NewDingospeedStore(db, nil).Save(&model.Dingospeed{InstanceID: "speed-a", Online: true}); err != nil {
+		t.Fatal(err)
+	}
+	first := newFile(t, records, "speed-a", "repo", "a.bin", 10)
+	second := newFile(t, records, "speed-a", "repo", "b.bin", 20)
+	report(t, processes, first, common.RangeSet{}.Add(0, 10))
+	report(t, processes, second, common.RangeSet{}.Add(0, 5))
+
+	if err := repositories.PersistRepo(req); err != nil {
+		t.Fatal(err)
+	}
+	if list, _ := repositories.ListByInstanceId("speed-a"); len(list) != 0 {
+		t.Fatalf("incomplete repo persisted: %+v", list[0])
+	}
+
+	report(t, processes, second, common.RangeSet{}.Add(5, 20))
+	for i := 0; i < 2; i++ {
+		if err := repositories.PersistRepo(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	list, total, err := repositories.ModelList(&query.ModelQuery{InstanceId: "speed-a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(list) != 1 {
+		t.Fatalf("got %d repositories, want 1", total)
+	}
+	if list[0].OrgRepo != "org/repo" || list[0].UsedStorage != 30 {
+		t.Fatalf("repository = %+v", list[0])
+	}
+
+	// 不完整的仓库重新校验通过后只清除标记，不重复登记
+	if err = repositories.UpdateIncomplete([]int64{list[0].ID}, true); err != nil {
+		t.Fatal(err)
+	}
+	if list, _, _ = repositories.ModelList(&query.ModelQuery{Incomplete: "false"}); len(list) != 0 {
+		t.Fatalf("complete repositories = %+v, want none", list)
+	}
+	if err = repositories.PersistRepo(req); err != nil {
+		t.Fatal(err)
+	}
+	list, total, _ = repositories.ModelList(&query.ModelQuery{Incomplete: "false"})
+	if total != 1 || list[0].Incomplete {
+		t.Fatalf("got %d complete repositories, first %+v", total, list)
+	}
+}
+
+func TestCacheJobCompletePersistsRepo(t *testing.T) {
+	db := NewDB()
+	records, repositories := NewRecordStore(db), NewRepositoryStore(db)
+	cacheJobs := NewCacheJobStore(db, repositories)
+	if _, err := NewDingospeedStore(db, nil).Save(&model.Dingospeed{InstanceID: "speed-a", Online: true}); err != nil {
+		t.Fatal(err)
+	}
+	newFile(t, records, "speed-a", "repo", "a.bin", 10)
+	job := &model.CacheJob{InstanceId: "speed-a", Datatype: "models", Org: "org", Repo: "repo", Status: consts.RunningStatusJobIng}
+	if err := cacheJobs.Save(job); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cacheJobs.UpdateStatusAndRepo(&query.UpdateJobStatusReq{Id: job.ID, Status: consts.RunningStatusJobBreak, ErrorMsg: "timeout"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := cacheJobs.GetCacheJob(&query.CacheJobQuery{Id: job.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != consts.RunningStatusJobBreak || got.ErrorMsg != `{"msg":"timeout"}` {
+		t.Fatalf("cache job = %+v", got)
+	}
+
+	// 缓存任务完成时不校验文件是否下载完整
+	if err := cacheJobs.UpdateStatusAndRepo(&query.UpdateJobStatusReq{Id: job.ID, Status: consts.RunningStatusJobComplete,
+		InstanceId: "speed-a", Org: "org", Repo: "repo"}); err != nil {
+		t.Fatal(err)
+	}
+	if list, _ := repositories.ListByInstanceId("speed-a"); len(list) != 1 {
+		t.Fatalf("got %d repositories, want 1", len(list))
+	}
+}
+
+func TestModelListFilterSortPaginate(t *testing.T) {
+	db := NewDB()
+	repositories, tags := NewRepositoryStore(db), NewTagStore(db)
+	for i, name := range []string{"bert", "llama", "llama-chat"} {
+		id := db.nextId()
+		db.repositories[id] = &model.Repository{ID: id, InstanceId: "speed-a", Datatype: "models", Org: "org", Repo: name,
+			OrgRepo: "org/" + name, LikeNum: i}
+		if name != "bert" {
+			db.repoTags[id] = []string{"pytorch"}
+		}
+	}
+	if err := tags.Create(&model.Tag{ID: "pytorch", Label: "PyTorch", Type: "library"}); err != nil {
+		t.Fatal(err)
+	}
+
+	list, total, err := repositories.ModelList(&query.ModelQuery{Name: "llama", Library: "pytorch", Sort: "like_num", Order: "desc", PageSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || len(list) != 1 || list[0].Repo != "llama-chat" {
+		t.Fatalf("got %d/%d, first %+v", len(list), total, list)
+	}
+	list, _, _ = repositories.ModelList(&query.ModelQuery{Name: "llama", Sort: "like_num", Order: "desc", Page: 2, PageSize: 1})
+	if len(list) != 1 || list[0].Repo != "llama" {
+		t.Fatalf("second page = %+v", list)
+	}
+	repoTags, _ := tags.GetTagByRepoId(list[0].ID)
+	if len(repoTags) != 1 || repoTags[0].Label != "PyTorch" {
+		t.Fatalf("tags = %+v", repoTags)
+	}
+}
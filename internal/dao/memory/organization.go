@@ -0,0 +1,50 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package memory
+
+import (
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/model"
+)
+
+type OrganizationStore struct {
+	db *DB
+}
+
+var _ dao.OrganizationStore = (*OrganizationStore)(nil)
+
+func NewOrganizationStore(db *DB) *OrganizationStore {
+	return &OrganizationStore{db: db}
+}
+
+func (s *OrganizationStore) Insert(org *model.Organization) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	row := *org
+	if row.ID == 0 {
+		row.ID = s.db.nextId()
+	}
+	s.db.orgs[row.Name] = &row
+	return nil
+}
+
+func (s *OrganizationStore) GetOrganization(orgName string) (string, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if row, ok := s.db.orgs[orgName]; ok {
+		return row.Icon, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,321 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package memory
+
+import (
+	"sort"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/model/dto"
+	"dingoscheduler/pkg/common"
+	"dingoscheduler/pkg/consts"
+	"dingoscheduler/pkg/util"
+)
+
+type ProcessStore struct {
+	db *DB
+}
+
+var _ dao.ProcessStore = (*ProcessStore)(nil)
+
+func NewProcessStore(db *DB) *ProcessStore {
+	return &ProcessStore{db: db}
+}
+
+type processKey struct {
+	recordId   int64
+	instanceId string
+}
+
+// insertProcess 唯一键已存在时返回已有进度的id，不覆盖已有进度，调用方须持有mu
+func (db *DB) insertProcess(process *model.ModelFileProcess) int64 {
+	key := processKey{process.RecordID, process.InstanceID}
+	if id, ok := db.processKeys[key]; ok {
+		return id
+	}
+	now := util.Now()
+	row := model.ModelFileProcess{
+		ID:               db.nextId(),
+		RecordID:         process.RecordID,
+		InstanceID:       process.InstanceID,
+		OffsetNum:        process.OffsetNum,
+		Status:           process.Status,
+		MasterInstanceID: process.MasterInstanceID,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	db.processes[row.ID] = &row
+	db.processKeys[key] = row.ID
+	return row.ID
+}
+
+// deleteProcess 调用方须持有mu
+func (db *DB) deleteProcess(row *model.ModelFileProcess) {
+	delete(db.processes, row.ID)
+	delete(db.processKeys, processKey{row.RecordID, row.InstanceID})
+}
+
+func processDto(row *model.ModelFileProcess) *dto.ModelFileProcessDto {
+	return &dto.ModelFileProcessDto{
+		ID:         row.ID,
+		RecordID:   row.RecordID,
+		InstanceID: row.InstanceID,
+		OffsetNum:  row.OffsetNum,
+		Ranges:     row.Ranges,
+		UpdatedAt:  row.UpdatedAt,
+	}
+}
+
+// rangesColumn 与MySQL实现一致，只有存在非连续区间时才保存ranges
+func rangesColumn(rs common.RangeSet) string {
+	if len(rs) == 0 || (len(rs) == 1 && rs[0].Start == 0) {
+		return ""
+	}
+	return rs.Truncate(common.MaxStoredRanges).String()
+}
+
+func (s *ProcessStore) Save(process *model.ModelFileProcess) (int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	return s.db.insertProcess(process), nil
+}
+
+func (s *ProcessStore) ResetProcess(process *model.ModelFileProcess) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if row, ok := s.db.processes[process.ID]; ok {
+		row.OffsetNum, row.Ranges, row.Status = process.OffsetNum, "", process.Status
+		row.Integrity = consts.IntegrityUnverified
+		row.UpdatedAt = util.Now()
+	}
+	return nil
+}
+
+func (s *ProcessStore) GetById(id int64) (*model.ModelFileProcess, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	row, ok := s.db.processes[id]
+	if !ok {
+		return nil, nil
+	}
+	process := *row
+	return &process, nil
+}
+
+func (s *ProcessStore) UpdateIntegrity(id int64, integrity int32) error {
+	return s.update(id, func(row *model.ModelFileProcess) {
+		row.Integrity = integrity
+	})
+}
+
+func (s *ProcessStore) MarkCorrupted(id int64) error {
+	return s.update(id, func(row *model.ModelFileProcess) {
+		row.OffsetNum, row.Ranges = 0, ""
+		row.Status, row.Integrity = consts.StatusDownloadBreak, consts.IntegrityCorrupted
+	})
+}
+
+func (s *ProcessStore) UpdateMaster(id int64, masterInstanceId string) error {
+	return s.update(id, func(row *model.ModelFileProcess) {
+		row.MasterInstanceID = masterInstanceId
+	})
+}
+
+func (s *ProcessStore) update(id int64, fn func(row *model.ModelFileProcess)) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if row, ok := s.db.processes[id]; ok {
+		fn(row)
+		row.UpdatedAt = util.Now()
+	}
+	return nil
+}
+
+func (s *ProcessStore) BatchReportFileProcess(reports map[int64]*dto.ProcessReport) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.reportFileProcess(reports)
+	return nil
+}
+
+func (s *ProcessStore) SyncFileProcess(batch *dto.ProcessSync) (map[int64]struct{}, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	for _, np := range batch.Processes {
+		if np.Record != nil && np.Record.ID == 0 {
+			np.Record.ID, np.RecordInserted = s.db.insertRecord(np.Record)
+		}
+		if np.Record != nil {
+			np.Process.RecordID = np.Record.ID
+		}
+		np.Process.ID = s.db.insertProcess(np.Process)
+	}
+	return s.reportFileProcess(batch.Reports), nil
+}
+
+// reportFileProcess 与MySQL实现一致，上报的区间并入已完成区间，调用方须持有mu
+func (s *ProcessStore) reportFileProcess(reports map[int64]*dto.ProcessReport) map[int64]struct{} {
+	found := make(map[int64]struct{}, len(reports))
+	now := util.Now()
+	for id, report := range reports {
+		row, ok := s.db.processes[id]
+		if !ok {
+			continue
+		}
+		if len(report.Ranges) > 0 {
+			rs := processDto(row).RangeSet()
+			for _, r := range report.Ranges {
+				rs = rs.Add(r.Start, r.End)
+			}
+			row.OffsetNum, row.Ranges = rs.Prefix(), rangesColumn(rs)
+		}
+		row.Status = report.Status
+		row.UpdatedAt = now
+		found[id] = struct{}{}
+	}
+	return found
+}
+
+func (s *ProcessStore) GetModelFileProcess(recordId int64) ([]*dto.ModelFileProcessDto, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	processes := make([]*dto.ModelFileProcessDto, 0)
+	for _, row := range s.sortedProcesses() {
+		if row.RecordID == recordId {
+			processes = append(processes, processDto(row))
+		}
+	}
+	sort.SliceStable(processes, func(i, j int) bool { return processes[i].OffsetNum > processes[j].OffsetNum })
+	return processes, nil
+}
+
+func (s *ProcessStore) ScanProcesses(afterId int64, limit int) ([]*dto.ModelFileProcessDto, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	processes := make([]*dto.ModelFileProcessDto, 0, limit)
+	for _, row := range s.sortedProcesses() {
+		if row.ID <= afterId {
+			continue
+		}
+		if len(processes) >= limit {
+			break
+		}
+		processes = append(processes, processDto(row))
+	}
+	return processes, nil
+}
+
+func (s *ProcessStore) GetModelFileProcessByInstanceId(recordId int64, instanceId string) (*dto.ModelFileProcessDto, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	for _, row := range s.sortedProcesses() {
+		if row.RecordID == recordId && row.InstanceID == instanceId {
+			return processDto(row), nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *ProcessStore) ListInstanceFiles(instanceId string) ([]*dto.InstanceFileDto, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	files := make([]*dto.InstanceFileDto, 0)
+	for _, row := range s.sortedProcesses() {
+		record, ok := s.db.records[row.RecordID]
+		if row.InstanceID != instanceId || !ok {
+			continue
+		}
+		files = append(files, &dto.InstanceFileDto{
+			ProcessID: row.ID,
+			RecordID:  row.RecordID,
+			Datatype:  record.Datatype,
+			Org:       record.Org,
+			Repo:      record.Repo,
+			Name:      record.Name,
+			Etag:      record.Etag,
+			FileSize:  record.FileSize,
+			OffsetNum: row.OffsetNum,
+			UpdatedAt: row.UpdatedAt,
+		})
+	}
+	return files, nil
+}
+
+func (s *ProcessStore) ResetOffsets(processes []*model.ModelFileProcess) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	now := util.Now()
+	for _, process := range processes {
+		if row, ok := s.db.processes[process.ID]; ok {
+			row.OffsetNum, row.Ranges, row.Status = process.OffsetNum, "", process.Status
+			row.Integrity = consts.IntegrityUnverified
+			row.UpdatedAt = now
+		}
+	}
+	return nil
+}
+
+func (s *ProcessStore) DeleteByIds(ids []int64) (int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	var deleted int64
+	for _, id := range ids {
+		if row, ok := s.db.processes[id]; ok {
+			s.db.deleteProcess(row)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (s *ProcessStore) DeleteByRecordIDAndInstanceID(recordID []int64, instanceID string) (int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	recordIds := make(map[int64]struct{}, len(recordID))
+	for _, id := range recordID {
+		recordIds[id] = struct{}{}
+	}
+	var deleted int64
+	for _, row := range s.db.processes {
+		if _, ok := recordIds[row.RecordID]; ok && row.InstanceID == instanceID {
+			s.db.deleteProcess(row)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// masterProcesses 以instanceId为master的下载进度，只含id和instance_id，调用方须持有mu
+func (db *DB) masterProcesses(instanceId string) []*model.ModelFileProcess {
+	processes := make([]*model.ModelFileProcess, 0)
+	for _, row := range db.processes {
+		if row.MasterInstanceID == instanceId {
+			processes = append(processes, &model.ModelFileProcess{ID: row.ID, InstanceID: row.InstanceID})
+		}
+	}
+	sort.Slice(processes, func(i, j int) bool { return processes[i].ID < processes[j].ID })
+	return processes
+}
+
+// sortedProcesses 按id排序，调用方须持有mu
+func (s *ProcessStore) sortedProcesses() []*model.ModelFileProcess {
+	rows := make([]*model.ModelFileProcess, 0, len(s.db.processes))
+	for _, row := range s.db.processes {
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+	return rows
+}
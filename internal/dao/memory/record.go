@@ -0,0 +1,173 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package memory
+
+import (
+	"sort"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/model/query"
+	pb "dingoscheduler/pkg/proto/manager"
+	"dingoscheduler/pkg/util"
+)
+
+type RecordStore struct {
+	db *DB
+}
+
+var _ dao.RecordStore = (*RecordStore)(nil)
+
+func NewRecordStore(db *DB) *RecordStore {
+	return &RecordStore{db: db}
+}
+
+type recordKey struct {
+	datatype, org, repo, name, etag string
+}
+
+// insertRecord 唯一键已存在时返回已有记录的id，第二个返回值表示是否新建，调用方须持有mu
+func (db *DB) insertRecord(record *model.ModelFileRecord) (int64, bool) {
+	key := recordKey{record.Datatype, record.Org, record.Repo, record.Name, record.Etag}
+	if id, ok := db.recordKeys[key]; ok {
+		return id, false
+	}
+	now := util.Now()
+	row := *record
+	row.ID = db.nextId()
+	row.CreatedAt, row.UpdatedAt = now, now
+	db.records[row.ID] = &row
+	db.recordKeys[key] = row.ID
+	return row.ID, true
+}
+
+func schedulerRecord(req *pb.SchedulerFileRequest) *model.ModelFileRecord {
+	return &model.ModelFileRecord{
+		Datatype: req.DataType,
+		Org:      req.Org,
+		Repo:     req.Repo,
+		Name:     req.Name,
+		Etag:     req.Etag,
+		FileSize: req.FileSize,
+	}
+}
+
+func (s *RecordStore) SaveSchedulerRecord(req *pb.SchedulerFileRequest, process *model.ModelFileProcess) (int64, bool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	var inserted bool
+	process.RecordID, inserted = s.db.insertRecord(schedulerRecord(req))
+	return s.db.insertProcess(process), inserted, nil
+}
+
+func (s *RecordStore) BatchSaveSchedulerRecords(reqs []*pb.SchedulerFileRequest, processes []*model.ModelFileProcess) ([]bool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	inserted := make([]bool, len(reqs))
+	for i, req := range reqs {
+		processes[i].RecordID, inserted[i] = s.db.insertRecord(schedulerRecord(req))
+		processes[i].ID = s.db.insertProcess(processes[i])
+	}
+	return inserted, nil
+}
+
+func (s *RecordStore) FirstModelFileRecord(condition *query.ModelFileRecordQuery) (*model.ModelFileRecord, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	for _, row := range s.sortedRecords() {
+		if (condition.Datatype == "" || row.Datatype == condition.Datatype) &&
+			(condition.Org == "" || row.Org == condition.Org) &&
+			(condition.Repo == "" || row.Repo == condition.Repo) &&
+			(condition.FileName == "" || row.Name == condition.FileName) &&
+			(condition.Etag == "" || row.Etag == condition.Etag) {
+			record := *row
+			return &record, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *RecordStore) GetByIDs(ids []int64) ([]model.ModelFileRecord, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	records := make([]model.ModelFileRecord, 0, len(ids))
+	for _, id := range ids {
+		if row, ok := s.db.records[id]; ok {
+			records = append(records, *row)
+		}
+	}
+	return records, nil
+}
+
+func (s *RecordStore) BatchQueryByEtags(etags []string) ([]model.ModelFileRecord, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	set := make(map[string]struct{}, len(etags))
+	for _, etag := range etags {
+		set[etag] = struct{}{}
+	}
+	records := make([]model.ModelFileRecord, 0)
+	for _, row := range s.sortedRecords() {
+		if _, ok := set[row.Etag]; ok {
+			records = append(records, *row)
+		}
+	}
+	return records, nil
+}
+
+func (s *RecordStore) GetIDsByEtagsOrFields(etag, datatype, org, repo, name string) ([]int64, error) {
+	hasEtagCondition := etag != ""
+	hasFieldCondition := datatype != "" && org != "" && repo != "" && name != ""
+	if !hasEtagCondition && !hasFieldCondition {
+		return []int64{}, nil
+	}
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	ids := make([]int64, 0)
+	for _, row := range s.sortedRecords() {
+		if (hasEtagCondition && row.Etag == etag) ||
+			(hasFieldCondition && row.Datatype == datatype && row.Org == org && row.Repo == repo && row.Name == name) {
+			ids = append(ids, row.ID)
+		}
+	}
+	return ids, nil
+}
+
+func (s *RecordStore) ScanRecords(afterId int64, limit int) ([]*model.ModelFileRecord, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	records := make([]*model.ModelFileRecord, 0, limit)
+	for _, row := range s.sortedRecords() {
+		if row.ID <= afterId {
+			continue
+		}
+		if len(records) >= limit {
+			break
+		}
+		record := *row
+		records = append(records, &record)
+	}
+	return records, nil
+}
+
+// sortedRecords 按id排序，与数据库默认的主键顺序一致，调用方须持有mu
+func (s *RecordStore) sortedRecords() []*model.ModelFileRecord {
+	rows := make([]*model.ModelFileRecord, 0, len(s.db.records))
+	for _, row := range s.db.records {
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+	return rows
+}
@@ -0,0 +1,334 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package memory
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/model/query"
+	"dingoscheduler/pkg/consts"
+	myerr "dingoscheduler/pkg/error"
+	"dingoscheduler/pkg/util"
+
+	"go.uber.org/zap"
+)
+
+type RepositoryStore struct {
+	db *DB
+}
+
+var _ dao.RepositoryStore = (*RepositoryStore)(nil)
+
+func NewRepositoryStore(db *DB) *RepositoryStore {
+	return &RepositoryStore{db: db}
+}
+
+func (s *RepositoryStore) DeleteByInstanceIdAndDatatypeAndOrgAndRepo(instanceId string, datatype string, org string, repo string) (int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	var deleted int64
+	for id, row := range s.db.repositories {
+		if row.InstanceId == instanceId && row.Datatype == datatype && row.Org == org && row.Repo == repo {
+			delete(s.db.repositories, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (s *RepositoryStore) UpdateRepositoryMountStatus(statusReq *query.UpdateMountStatusReq) error {
+	errorMsg, err := errorMsgColumn(statusReq.ErrorMsg)
+	if err != nil {
+		return err
+	}
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if row, ok := s.db.repositories[statusReq.Id]; ok {
+		row.Status, row.ErrorMsg = statusReq.Status, errorMsg
+		row.UpdatedAt = util.Now()
+	}
+	return nil
+}
+
+func (s *RepositoryStore) ListByInstanceId(instanceId string) ([]*model.Repository, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	repositories := make([]*model.Repository, 0)
+	for _, row := range s.db.repositories {
+		if row.InstanceId == instanceId {
+			repository := *row
+			repositories = append(repositories, &repository)
+		}
+	}
+	sort.Slice(repositories, func(i, j int) bool { return repositories[i].ID < repositories[j].ID })
+	return repositories, nil
+}
+
+func (s *RepositoryStore) UpdateIncomplete(ids []int64, incomplete bool) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	now := util.Now()
+	for _, id := range ids {
+		if row, ok := s.db.repositories[id]; ok {
+			row.Incomplete = incomplete
+			row.UpdatedAt = now
+		}
+	}
+	return nil
+}
+
+func (s *RepositoryStore) Get(id int64) (*model.Repository, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	row, ok := s.db.repositories[id]
+	if !ok {
+		return nil, fmt.Errorf("No record found")
+	}
+	repository := *row
+	return &repository, nil
+}
+
+func (s *RepositoryStore) GetUnmountRepository(instanceId string, ids []int, runningStatus []int32, limit int) ([]*model.Repository, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	idSet := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		idSet[int64(id)] = struct{}{}
+	}
+	repositories := make([]*model.Repository, 0)
+	for _, row := range s.sortedRepositories() {
+		if limit > 0 && len(repositories) >= limit {
+			break
+		}
+		if instanceId != "" && row.InstanceId != instanceId {
+			continue
+		}
+		if _, ok := idSet[row.ID]; len(ids) > 0 && !ok {
+			continue
+		}
+		if len(runningStatus) > 0 && !slices.Contains(runningStatus, row.Status) {
+			continue
+		}
+		repository := *row
+		repositories = append(repositories, &repository)
+	}
+	return repositories, nil
+}
+
+func (s *RepositoryStore) ModelList(query *query.ModelQuery) ([]*model.Repository, int64, error) {
+	tags := make([]string, 0)
+	for _, v := range []string{query.Library, query.Apps, query.InferenceProvider, query.Language, query.License, query.Other} {
+		if v != "" {
+			tags = append(tags, strings.Split(v, ",")...)
+		}
+	}
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	repositories := make([]*model.Repository, 0)
+	for _, row := range s.sortedRepositories() {
+		if query.InstanceId != "" && row.InstanceId != query.InstanceId {
+			continue
+		}
+		if query.Name != "" && !strings.Contains(row.OrgRepo, query.Name) {
+			continue
+		}
+		if query.PipelineTag != "" && row.PipelineTagId != query.PipelineTag {
+			continue
+		}
+		if query.Datatype != "" && row.Datatype != query.Datatype {
+			continue
+		}
+		if query.Status != "" && row.Status != int32(util.Atoi(query.Status)) {
+			continue
+		}
+		if query.Incomplete != "" && row.Incomplete != (query.Incomplete == "true") {
+			continue
+		}
+		if len(tags) > 0 && !slices.ContainsFunc(s.db.repoTags[row.ID], func(tagId string) bool { return slices.Contains(tags, tagId) }) {
+			continue
+		}
+		repository := *row
+		repositories = append(repositories, &repository)
+	}
+	if query.Sort != "" && query.Order != "" {
+		less := repositoryLess(query.Sort)
+		desc := strings.EqualFold(query.Order, "desc")
+		sort.SliceStable(repositories, func(i, j int) bool {
+			if desc {
+				return less(repositories[j], repositories[i])
+			}
+			return less(repositories[i], repositories[j])
+		})
+	}
+	count := int64(len(repositories))
+	offset, pageSize := dao.Paginate(query.Page, query.PageSize)
+	if offset >= len(repositories) {
+		return []*model.Repository{}, count, nil
+	}
+	return repositories[offset:min(offset+pageSize, len(repositories))], count, nil
+}
+
+// repositoryLess 支持页面可排序的列，其他列按id排序
+func repositoryLess(column string) func(a, b *model.Repository) bool {
+	switch column {
+	case "like_num":
+		return func(a, b *model.Repository) bool { return a.LikeNum < b.LikeNum }
+	case "download_num":
+		return func(a, b *model.Repository) bool { return a.DownloadNum < b.DownloadNum }
+	case "last_modified":
+		return func(a, b *model.Repository) bool { return a.LastModified < b.LastModified }
+	case "used_storage":
+		return func(a, b *model.Repository) bool { return a.UsedStorage < b.UsedStorage }
+	case "created_at":
+		return func(a, b *model.Repository) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	default:
+		return func(a, b *model.Repository) bool { return a.ID < b.ID }
+	}
+}
+
+// PersistRepo 不请求远端元数据，校验完整性时以调度器已知的文件为准：仓库的每个文件在该节点都已下载完成且未损坏
+func (s *RepositoryStore) PersistRepo(persistRepoReq *query.PersistRepoReq) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	for _, instanceId := range persistRepoReq.InstanceIds {
+		if instanceId == "" {
+			continue
+		}
+		if !s.speedRegistered(instanceId) {
+			return myerr.New("该区域dingospeed未注册。")
+		}
+		for _, free := range s.freeRepositories(instanceId, persistRepoReq.Org, persistRepoReq.Repo) {
+			key := repoKey{free.Datatype, free.Org, free.Repo}
+			usedStorage, complete := s.repoComplete(instanceId, key)
+			if !complete && !persistRepoReq.OffVerify {
+				zap.S().Infof("repo file unComplete.%s", util.GetOrgRepo(key.org, key.repo))
+				continue
+			}
+			now := util.Now()
+			if free.ID != 0 {
+				row := s.db.repositories[free.ID]
+				row.Incomplete, row.UsedStorage, row.UpdatedAt = false, usedStorage, now
+				continue
+			}
+			id := s.db.nextId()
+			s.db.repositories[id] = &model.Repository{
+				ID:          id,
+				InstanceId:  instanceId,
+				Datatype:    key.datatype,
+				Org:         key.org,
+				Repo:        key.repo,
+				OrgRepo:     util.GetOrgRepo(key.org, key.repo),
+				UsedStorage: usedStorage,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			}
+		}
+	}
+	return nil
+}
+
+// speedRegistered 调用方须持有mu
+func (s *RepositoryStore) speedRegistered(instanceId string) bool {
+	for _, row := range s.db.speeds {
+		if row.InstanceID == instanceId && row.Online {
+			return true
+		}
+	}
+	return false
+}
+
+type repoKey struct {
+	datatype, org, repo string
+}
+
+// freeRepositories 节点有下载进度但尚未登记或被标记为不完整的仓库，后者带有仓库id，调用方须持有mu
+func (s *RepositoryStore) freeRepositories(instanceId, org, repo string) []*model.Repository {
+	registered := make(map[repoKey]*model.Repository)
+	for _, row := range s.db.repositories {
+		if row.InstanceId == instanceId {
+			registered[repoKey{row.Datatype, row.Org, row.Repo}] = row
+		}
+	}
+	seen := make(map[repoKey]struct{})
+	repositories := make([]*model.Repository, 0)
+	for _, process := range s.db.processes {
+		record, ok := s.db.records[process.RecordID]
+		if !ok || process.InstanceID != instanceId {
+			continue
+		}
+		if org != "" && repo != "" && (record.Org != org || record.Repo != repo) {
+			continue
+		}
+		key := repoKey{record.Datatype, record.Org, record.Repo}
+		row, ok := registered[key]
+		if ok && !row.Incomplete {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		free := &model.Repository{Datatype: key.datatype, Org: key.org, Repo: key.repo}
+		if row != nil {
+			free.ID, free.Incomplete = row.ID, true
+		}
+		repositories = append(repositories, free)
+	}
+	sort.Slice(repositories, func(i, j int) bool {
+		a, b := repositories[i], repositories[j]
+		return a.Datatype+"/"+a.Org+"/"+a.Repo < b.Datatype+"/"+b.Org+"/"+b.Repo
+	})
+	return repositories
+}
+
+// repoComplete 返回仓库在节点上占用的空间及是否所有文件都已下载完成，调用方须持有mu
+func (s *RepositoryStore) repoComplete(instanceId string, key repoKey) (int64, bool) {
+	var usedStorage int64
+	complete := true
+	for _, record := range s.db.records {
+		if record.Datatype != key.datatype || record.Org != key.org || record.Repo != key.repo {
+			continue
+		}
+		done := false
+		for _, process := range s.db.processes {
+			if process.RecordID == record.ID && process.InstanceID == instanceId &&
+				process.OffsetNum == record.FileSize && process.Integrity != consts.IntegrityCorrupted {
+				done = true
+				break
+			}
+		}
+		if done {
+			usedStorage += record.FileSize
+		} else {
+			complete = false
+		}
+	}
+	return usedStorage, complete
+}
+
+// sortedRepositories 按id排序，调用方须持有mu
+func (s *RepositoryStore) sortedRepositories() []*model.Repository {
+	rows := make([]*model.Repository, 0, len(s.db.repositories))
+	for _, row := range s.db.repositories {
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+	return rows
+}
@@ -0,0 +1,106 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package memory
+
+import (
+	"sort"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/model/query"
+)
+
+type TagStore struct {
+	db *DB
+}
+
+var _ dao.TagStore = (*TagStore)(nil)
+
+func NewTagStore(db *DB) *TagStore {
+	return &TagStore{db: db}
+}
+
+func (s *TagStore) Create(tag *model.Tag) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	row := *tag
+	s.db.tags[row.ID] = &row
+	return nil
+}
+
+func (s *TagStore) GetTagByRepoId(repoId int64) ([]*model.Tag, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	tags := make([]*model.Tag, 0)
+	for _, tagId := range s.db.repoTags[repoId] {
+		if row, ok := s.db.tags[tagId]; ok {
+			tag := *row
+			tags = append(tags, &tag)
+		}
+	}
+	sortTags(tags)
+	return tags, nil
+}
+
+// TagListByCondition 与MySQL实现一致，数据集的标签类型带data_前缀
+func (s *TagStore) TagListByCondition(condition *query.TagQuery) ([]*model.Tag, error) {
+	if condition.DataType == "datasets" && len(condition.Types) > 0 && condition.Types[0] != "language" && condition.Types[0] != "license" {
+		condition.Types[0] = "data_" + condition.Types[0]
+	}
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	tags := make([]*model.Tag, 0)
+	for _, row := range s.db.tags {
+		if condition.Id != "" && row.ID != condition.Id {
+			continue
+		}
+		if !matchAny(condition.Labels, row.Label) || !matchAny(condition.Types, row.Type) || !matchAny(condition.SubTypes, row.SubType) {
+			continue
+		}
+		tag := *row
+		tags = append(tags, &tag)
+	}
+	sortTags(tags)
+	return tags, nil
+}
+
+func (s *TagStore) TagCountByCondition(condition *query.TagQuery) (int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	var count int64
+	for _, row := range s.db.tags {
+		if matchAny(condition.Types, row.Type) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func sortTags(tags []*model.Tag) {
+	sort.Slice(tags, func(i, j int) bool { return tags[i].ID < tags[j].ID })
+}
+
+// matchAny values为空时不过滤
+func matchAny(values []string, v string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -17,16 +17,17 @@ package dao
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"dingoscheduler/internal/data"
 	"dingoscheduler/internal/model"
 	"dingoscheduler/internal/model/dto"
+	"dingoscheduler/pkg/common"
 	"dingoscheduler/pkg/consts"
-	pb "dingoscheduler/pkg/proto/manager"
-	"dingoscheduler/pkg/util"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ModelFileProcessDao struct {
@@ -43,17 +44,29 @@ func (d *ModelFileProcessDao) Save(process *model.ModelFileProcess) (int64, erro
 	return SaveProcessBySql(d.baseData.BizDB, process)
 }
 
+// processColumns 新建进度时写入的列，其余列使用数据库默认值
+var processColumns = []string{"record_id", "instance_id", "offset_num", "status", "master_instance_id"}
+
+// SaveProcessBySql 按唯一键(record_id, instance_id)插入进度，已存在时返回已有进度的id，不覆盖已有进度
 func SaveProcessBySql(tx *gorm.DB, process *model.ModelFileProcess) (int64, error) {
-	recordSql := fmt.Sprintf("INSERT INTO model_file_process(record_id, instance_id, offset_num, status, master_instance_id) VALUES (%d, '%s',%d,%d,'%s')", process.RecordID, process.InstanceID, process.OffsetNum, process.Status, process.MasterInstanceID)
-	db, err := tx.DB()
-	if err != nil {
-		return 0, err
+	row := &model.ModelFileProcess{RecordID: process.RecordID, InstanceID: process.InstanceID, OffsetNum: process.OffsetNum,
+		Status: process.Status, MasterInstanceID: process.MasterInstanceID}
+	result := tx.Select(processColumns).Clauses(clause.OnConflict{DoNothing: true}).Create(row)
+	if result.Error != nil {
+		return 0, result.Error
 	}
-	result, err := db.Exec(recordSql)
-	if err != nil {
+	if result.RowsAffected > 0 {
+		return row.ID, nil
+	}
+	var ids []int64
+	if err := tx.Model(&model.ModelFileProcess{}).Where("record_id = ? and instance_id = ?", process.RecordID, process.InstanceID).
+		Pluck("id", &ids).Error; err != nil {
 		return 0, err
 	}
-	return result.LastInsertId()
+	if len(ids) == 0 {
+		return 0, fmt.Errorf("process of record %d on %s not found after conflict", process.RecordID, process.InstanceID)
+	}
+	return ids[0], nil
 }
 
 func (d *ModelFileProcessDao) BatchSave(processes []model.ModelFileProcess) error {
@@ -63,31 +76,12 @@ func (d *ModelFileProcessDao) BatchSave(processes []model.ModelFileProcess) erro
 		return tx.Error
 	}
 
-	db, err := tx.DB()
-	if err != nil {
-		tx.Rollback()
-		zap.S().Error("从事务获取 DB 实例失败: %v", err)
-		return err
-	}
-
-	for _, process := range processes {
-		sql := fmt.Sprintf(
-			"INSERT INTO model_file_process(record_id, instance_id, offset_num, status, master_instance_id) VALUES(%d,'%s',%d,%d,'%s')",
-			process.RecordID,
-			process.InstanceID,
-			process.OffsetNum,
-			process.Status,
-			process.MasterInstanceID,
-		)
-
-		result, err := db.Exec(sql)
-		if err != nil {
+	for i := range processes {
+		if _, err := SaveProcessBySql(tx, &processes[i]); err != nil {
 			tx.Rollback()
-			zap.S().Error("批量插入失败: %v, SQL: %s", err, sql)
+			zap.S().Error("批量插入失败: %v", err)
 			return err
 		}
-
-		_, _ = result.LastInsertId()
 	}
 
 	if err := tx.Commit().Error; err != nil {
@@ -100,31 +94,175 @@ func (d *ModelFileProcessDao) BatchSave(processes []model.ModelFileProcess) erro
 }
 
 func (d *ModelFileProcessDao) ResetProcess(process *model.ModelFileProcess) error {
-	sql := fmt.Sprintf("UPDATE model_file_process SET offset_num = %d, status = %d, updated_at = '%s' WHERE id = %d",
-		process.OffsetNum, process.Status, util.GetCurrentTimeStr(), process.ID)
-	if err := d.baseData.BizDB.Exec(sql).Error; err != nil {
+	if err := d.baseData.BizDB.Model(&model.ModelFileProcess{}).Where("id = ?", process.ID).Updates(map[string]interface{}{
+		"offset_num": process.OffsetNum,
+		"ranges":     "",
+		"status":     process.Status,
+		"integrity":  consts.IntegrityUnverified,
+		"updated_at": time.Now(),
+	}).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (d *ModelFileProcessDao) ReportFileProcess(req *pb.FileProcessRequest) error {
-	var sql string
-	if req.Status == consts.StatusDownloadBreak {
-		sql = fmt.Sprintf("UPDATE model_file_process SET status = %d, updated_at = '%s' WHERE id = %d", req.Status, util.GetCurrentTimeStr(), req.ProcessId)
-	} else {
-		sql = fmt.Sprintf("UPDATE model_file_process SET offset_num = %d, status = %d, updated_at = '%s' WHERE id = %d and offset_num <= %d",
-			req.EndPos, req.Status, util.GetCurrentTimeStr(), req.ProcessId, req.StaPos)
+func (d *ModelFileProcessDao) GetById(id int64) (*model.ModelFileProcess, error) {
+	processes := make([]*model.ModelFileProcess, 0)
+	if err := d.baseData.BizDB.Model(&model.ModelFileProcess{}).Where("id = ?", id).Find(&processes).Error; err != nil {
+		return nil, err
+	}
+	if len(processes) == 0 {
+		return nil, nil
+	}
+	return processes[0], nil
+}
+
+func (d *ModelFileProcessDao) UpdateIntegrity(id int64, integrity int32) error {
+	return d.baseData.BizDB.Model(&model.ModelFileProcess{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"integrity":  integrity,
+		"updated_at": time.Now(),
+	}).Error
+}
+
+// MarkCorrupted 数据校验失败，重置进度以便重新下载
+func (d *ModelFileProcessDao) MarkCorrupted(id int64) error {
+	return d.baseData.BizDB.Model(&model.ModelFileProcess{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"offset_num": 0,
+		"ranges":     "",
+		"status":     consts.StatusDownloadBreak,
+		"integrity":  consts.IntegrityCorrupted,
+		"updated_at": time.Now(),
+	}).Error
+}
+
+// UpdateMaster 只更新master，不改动下载进度
+func (d *ModelFileProcessDao) UpdateMaster(id int64, masterInstanceId string) error {
+	return d.baseData.BizDB.Model(&model.ModelFileProcess{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"master_instance_id": masterInstanceId,
+		"updated_at":         time.Now(),
+	}).Error
+}
+
+// clearMaster 清除以instanceId为master的下载进度的master，返回受影响的进度
+func clearMaster(tx *gorm.DB, instanceId string) ([]*model.ModelFileProcess, error) {
+	processes := make([]*model.ModelFileProcess, 0)
+	if err := tx.Model(&model.ModelFileProcess{}).Select("id, instance_id").Where("master_instance_id = ?", instanceId).Find(&processes).Error; err != nil {
+		return nil, err
+	}
+	if len(processes) == 0 {
+		return processes, nil
+	}
+	ids := make([]int64, 0, len(processes))
+	for _, p := range processes {
+		ids = append(ids, p.ID)
+	}
+	if err := tx.Model(&model.ModelFileProcess{}).Where("id in ?", ids).Update("master_instance_id", "").Error; err != nil {
+		return nil, err
+	}
+	return processes, nil
+}
+
+// BatchReportFileProcess 在一个事务中合并多个进度的上报，上报的区间并入已完成区间，
+// offset_num保持为从0开始连续完成的位置，乱序、并行下载的区间不再丢弃。
+func (d *ModelFileProcessDao) BatchReportFileProcess(reports map[int64]*dto.ProcessReport) error {
+	if len(reports) == 0 {
+		return nil
 	}
-	if err := d.baseData.BizDB.Exec(sql).Error; err != nil {
+	return d.baseData.BizDB.Transaction(func(tx *gorm.DB) error {
+		_, err := reportFileProcess(tx, reports)
 		return err
+	})
+}
+
+// SyncFileProcess 在一个事务中写入一批离线同步的进度，新建的记录和进度回填id，返回实际更新的已有进度id
+func (d *ModelFileProcessDao) SyncFileProcess(batch *dto.ProcessSync) (map[int64]struct{}, error) {
+	var updated map[int64]struct{}
+	err := d.baseData.BizDB.Transaction(func(tx *gorm.DB) error {
+		for _, np := range batch.Processes {
+			if np.Record != nil && np.Record.ID == 0 {
+				recordId, inserted, err := SaveRecordBySql(tx, np.Record)
+				if err != nil {
+					return err
+				}
+				np.Record.ID = recordId
+				np.RecordInserted = inserted
+			}
+			if np.Record != nil {
+				np.Process.RecordID = np.Record.ID
+			}
+			processId, err := SaveProcessBySql(tx, np.Process)
+			if err != nil {
+				return err
+			}
+			np.Process.ID = processId
+		}
+		var err error
+		updated, err = reportFileProcess(tx, batch.Reports)
+		return err
+	})
+	if err != nil {
+		// 事务已回滚，回填的id无效
+		for _, np := range batch.Processes {
+			np.Process.ID = 0
+			if np.Record != nil {
+				np.Record.ID = 0
+			}
+		}
+		return nil, err
 	}
-	return nil
+	return updated, nil
+}
+
+// reportFileProcess 按id顺序锁定并合并进度上报，返回数据库中存在的进度id
+func reportFileProcess(tx *gorm.DB, reports map[int64]*dto.ProcessReport) (map[int64]struct{}, error) {
+	found := make(map[int64]struct{}, len(reports))
+	if len(reports) == 0 {
+		return found, nil
+	}
+	ids := make([]int64, 0, len(reports))
+	for id := range reports {
+		ids = append(ids, id)
+	}
+	var processes []*dto.ModelFileProcessDto
+	// 按id顺序加锁，避免并发批次之间死锁
+	if err := tx.Table("model_file_process").Select("id, offset_num, ranges").Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id in ?", ids).Order("id").Find(&processes).Error; err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, process := range processes {
+		report := reports[process.ID]
+		values := map[string]interface{}{
+			"status":     report.Status,
+			"updated_at": now,
+		}
+		if len(report.Ranges) > 0 {
+			rs := process.RangeSet()
+			for _, r := range report.Ranges {
+				rs = rs.Add(r.Start, r.End)
+			}
+			values["offset_num"] = rs.Prefix()
+			values["ranges"] = rangesColumn(rs)
+		}
+		if err := tx.Model(&model.ModelFileProcess{}).Where("id = ?", process.ID).Updates(values).Error; err != nil {
+			return nil, err
+		}
+		found[process.ID] = struct{}{}
+	}
+	return found, nil
+}
+
+// rangesColumn 只有存在非连续区间时才保存ranges，从0开始的连续进度由offset_num表示即可
+func rangesColumn(rs common.RangeSet) string {
+	if len(rs) == 0 || (len(rs) == 1 && rs[0].Start == 0) {
+		return ""
+	}
+	return rs.Truncate(common.MaxStoredRanges).String()
 }
 
 func (d *ModelFileProcessDao) GetModelFileProcess(recordId int64) ([]*dto.ModelFileProcessDto, error) {
 	var processes []*dto.ModelFileProcessDto
-	if err := d.baseData.BizDB.Table("model_file_process t1").Select("t1.id, t1.record_id, t1.instance_id, t1.offset_num").
+	if err := d.baseData.BizDB.Table("model_file_process t1").Select("t1.id, t1.record_id, t1.instance_id, t1.offset_num, t1.ranges").
 		Where("t1.record_id=?", recordId).Order("t1.offset_num desc").Find(&processes).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -134,6 +272,16 @@ func (d *ModelFileProcessDao) GetModelFileProcess(recordId int64) ([]*dto.ModelF
 	return processes, nil
 }
 
+// ScanProcesses 按id顺序分页读取id大于afterId的下载进度
+func (d *ModelFileProcessDao) ScanProcesses(afterId int64, limit int) ([]*dto.ModelFileProcessDto, error) {
+	processes := make([]*dto.ModelFileProcessDto, 0, limit)
+	if err := d.baseData.BizDB.Table("model_file_process").Select("id, record_id, instance_id, offset_num, ranges").
+		Where("id > ?", afterId).Order("id").Limit(limit).Find(&processes).Error; err != nil {
+		return nil, err
+	}
+	return processes, nil
+}
+
 func (d *ModelFileProcessDao) GetModelFileProcessByInstanceId(recordId int64, instanceId string) (*dto.ModelFileProcessDto, error) {
 	var processes []*dto.ModelFileProcessDto
 	if err := d.baseData.BizDB.Table("model_file_process t1").Select("t1.id, t1.record_id, t1.instance_id, t1.offset_num").
@@ -149,6 +297,51 @@ func (d *ModelFileProcessDao) GetModelFileProcessByInstanceId(recordId int64, in
 	return nil, nil
 }
 
+// ListInstanceFiles 查询节点的全部下载进度及所属文件
+func (d *ModelFileProcessDao) ListInstanceFiles(instanceId string) ([]*dto.InstanceFileDto, error) {
+	files := make([]*dto.InstanceFileDto, 0)
+	if err := d.baseData.BizDB.Table("model_file_process t1").
+		Select("t1.id as process_id, t1.record_id, t2.datatype, t2.org, t2.repo, t2.name, t2.etag, t2.file_size, t1.offset_num, t1.updated_at").
+		Joins("inner join model_file_record t2 on t1.record_id = t2.id").
+		Where("t1.instance_id = ?", instanceId).Find(&files).Error; err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// ResetOffsets 在一个事务中按节点磁盘上的实际数据重置进度，重置后的数据需重新校验
+func (d *ModelFileProcessDao) ResetOffsets(processes []*model.ModelFileProcess) error {
+	if len(processes) == 0 {
+		return nil
+	}
+	now := time.Now()
+	return d.baseData.BizDB.Transaction(func(tx *gorm.DB) error {
+		for _, process := range processes {
+			if err := tx.Model(&model.ModelFileProcess{}).Where("id = ?", process.ID).Updates(map[string]interface{}{
+				"offset_num": process.OffsetNum,
+				"ranges":     "",
+				"status":     process.Status,
+				"integrity":  consts.IntegrityUnverified,
+				"updated_at": now,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (d *ModelFileProcessDao) DeleteByIds(ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := d.baseData.BizDB.Where("id in ?", ids).Delete(&model.ModelFileProcess{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
 // ExistRecordIDs 查询指定InstanceID下，哪些RecordID已存在对应的ModelFileProcess记录
 func (d *ModelFileProcessDao) ExistRecordIDs(instanceID string, recordIDs []int64) ([]int64, error) {
 	if len(recordIDs) == 0 {
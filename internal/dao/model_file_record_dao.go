@@ -24,6 +24,7 @@ import (
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ModelFileRecordDao struct {
@@ -42,29 +43,12 @@ func (d *ModelFileRecordDao) BatchSave(records []model.ModelFileRecord) error {
 		zap.S().Error("开启事务失败: %v", tx.Error)
 		return tx.Error
 	}
-	db, err := tx.DB()
-	if err != nil {
-		tx.Rollback()
-		zap.S().Error("从事务获取 DB 实例失败: %v", err)
-		return err
-	}
-	for _, record := range records {
-		sql := fmt.Sprintf(
-			"INSERT INTO model_file_record(datatype, org, repo, name, etag, file_size) VALUES ('%s','%s','%s','%s','%s',%d)",
-			record.Datatype,
-			record.Org,
-			record.Repo,
-			record.Name,
-			record.Etag,
-			record.FileSize,
-		)
-		result, err := db.Exec(sql)
-		if err != nil {
+	for i := range records {
+		if _, _, err := SaveRecordBySql(tx, &records[i]); err != nil {
 			tx.Rollback()
-			zap.S().Error("批量插入失败: %v, SQL: %s", err, sql)
+			zap.S().Error("批量插入失败: %v", err)
 			return err
 		}
-		_, _ = result.LastInsertId()
 	}
 
 	if err := tx.Commit().Error; err != nil {
@@ -75,17 +59,29 @@ func (d *ModelFileRecordDao) BatchSave(records []model.ModelFileRecord) error {
 	return nil
 }
 
-func SaveRecordBySql(tx *gorm.DB, record *model.ModelFileRecord) (int64, error) {
-	recordSql := fmt.Sprintf("INSERT INTO model_file_record(datatype, org, repo, name, etag, file_size) VALUES ('%s','%s','%s','%s','%s',%d)", record.Datatype, record.Org, record.Repo, record.Name, record.Etag, record.FileSize)
-	db, err := tx.DB()
-	if err != nil {
-		return 0, err
+// SaveRecordBySql 按唯一键(datatype, org, repo, name, etag)插入记录，已存在时返回已有记录的id，第二个返回值表示是否新建
+func SaveRecordBySql(tx *gorm.DB, record *model.ModelFileRecord) (int64, bool, error) {
+	row := &model.ModelFileRecord{Datatype: record.Datatype, Org: record.Org, Repo: record.Repo, Name: record.Name,
+		Etag: record.Etag, FileSize: record.FileSize}
+	result := tx.Select("datatype", "org", "repo", "name", "etag", "file_size").Clauses(clause.OnConflict{DoNothing: true}).Create(row)
+	if result.Error != nil {
+		return 0, false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return row.ID, true, nil
 	}
-	result, err := db.Exec(recordSql)
-	if err != nil {
-		return 0, err
+	// mysql按不区分大小写的排序规则比较name，可能同时查到大小写不同的文件，按完整的name取已有记录
+	var rows []*model.ModelFileRecord
+	if err := tx.Model(&model.ModelFileRecord{}).Select("id, name").Where("datatype = ? and org = ? and repo = ? and name = ? and etag = ?",
+		record.Datatype, record.Org, record.Repo, record.Name, record.Etag).Order("id").Find(&rows).Error; err != nil {
+		return 0, false, err
 	}
-	return result.LastInsertId()
+	for _, r := range rows {
+		if r.Name == record.Name {
+			return r.ID, false, nil
+		}
+	}
+	return 0, false, fmt.Errorf("record %s/%s/%s not found after insert conflict", record.Org, record.Repo, record.Name)
 }
 
 func (d *ModelFileRecordDao) FirstModelFileRecord(condition *query.ModelFileRecordQuery) (*model.ModelFileRecord, error) {
@@ -106,7 +102,7 @@ func (d *ModelFileRecordDao) FirstModelFileRecord(condition *query.ModelFileReco
 	if condition.Etag != "" {
 		db.Where("etag = ?", condition.Etag)
 	}
-	if err := db.Find(&records).Error; err != nil {
+	if err := db.Order("id").Limit(1).Find(&records).Error; err != nil {
 		return nil, err
 	}
 
@@ -116,8 +112,19 @@ func (d *ModelFileRecordDao) FirstModelFileRecord(condition *query.ModelFileReco
 	return nil, nil
 }
 
-func (d *ModelFileRecordDao) SaveSchedulerRecord(req *pb.SchedulerFileRequest, process *model.ModelFileProcess) (int64, error) {
+// ScanRecords 按id顺序分页读取id大于afterId的记录
+func (d *ModelFileRecordDao) ScanRecords(afterId int64, limit int) ([]*model.ModelFileRecord, error) {
+	records := make([]*model.ModelFileRecord, 0, limit)
+	if err := d.baseData.BizDB.Model(&model.ModelFileRecord{}).Select("id, datatype, org, repo, name, etag, file_size").
+		Where("id > ?", afterId).Order("id").Limit(limit).Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (d *ModelFileRecordDao) SaveSchedulerRecord(req *pb.SchedulerFileRequest, process *model.ModelFileProcess) (int64, bool, error) {
 	var processId int64
+	var inserted bool
 	if err := d.baseData.BizDB.Transaction(func(tx *gorm.DB) error {
 		record := &model.ModelFileRecord{
 			Datatype: req.DataType,
@@ -127,7 +134,7 @@ func (d *ModelFileRecordDao) SaveSchedulerRecord(req *pb.SchedulerFileRequest, p
 			Etag:     req.Etag,
 			FileSize: req.FileSize,
 		}
-		lastId, err := SaveRecordBySql(tx, record)
+		lastId, ok, err := SaveRecordBySql(tx, record)
 		if err != nil {
 			return err
 		}
@@ -136,12 +143,45 @@ func (d *ModelFileRecordDao) SaveSchedulerRecord(req *pb.SchedulerFileRequest, p
 		if err != nil {
 			return err
 		}
+		inserted = ok
 		return nil
 	}); err != nil {
 		zap.S().Errorf("SaveSchedulerRecord err.%v", err)
-		return 0, err
+		return 0, false, err
+	}
+	return processId, inserted, nil
+}
+
+// BatchSaveSchedulerRecords 在一个事务中保存多个新文件的记录及请求方的下载进度，processes与reqs一一对应，
+// 返回各记录是否由本次新建
+func (d *ModelFileRecordDao) BatchSaveSchedulerRecords(reqs []*pb.SchedulerFileRequest, processes []*model.ModelFileProcess) ([]bool, error) {
+	inserted := make([]bool, len(reqs))
+	if err := d.baseData.BizDB.Transaction(func(tx *gorm.DB) error {
+		for i, req := range reqs {
+			record := &model.ModelFileRecord{
+				Datatype: req.DataType,
+				Org:      req.Org,
+				Repo:     req.Repo,
+				Name:     req.Name,
+				Etag:     req.Etag,
+				FileSize: req.FileSize,
+			}
+			lastId, ok, err := SaveRecordBySql(tx, record)
+			if err != nil {
+				return err
+			}
+			inserted[i] = ok
+			processes[i].RecordID = lastId
+			if processes[i].ID, err = SaveProcessBySql(tx, processes[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		zap.S().Errorf("BatchSaveSchedulerRecords err.%v", err)
+		return nil, err
 	}
-	return processId, nil
+	return inserted, nil
 }
 
 // ExistEtags 查询指定Etag列表中已存在的Etag
@@ -1,7 +1,6 @@
 package dao
 
 import (
-	"fmt"
 	"path/filepath"
 	"sync"
 	"time"
@@ -12,6 +11,7 @@ import (
 	"dingoscheduler/pkg/util"
 
 	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
 )
 
 var mu sync.Mutex
@@ -29,7 +29,7 @@ func NewOrganizationDao(data *data.BaseData) *OrganizationDao {
 // organizationDao的相关方法示例
 func (o *OrganizationDao) ExistsByField(field, value string) (bool, error) {
 	var count int64
-	err := o.baseData.BizDB.Table("organization").Where(field+" = ?", value).Count(&count).Error
+	err := o.baseData.BizDB.Table("organization").Where(clause.Eq{Column: clause.Column{Name: field}, Value: value}).Count(&count).Error
 	return count > 0, err
 }
 
@@ -38,20 +38,38 @@ func (o *OrganizationDao) Insert(org *model.Organization) error {
 }
 
 func (o *OrganizationDao) UpdateByField(field, value string, org *model.Organization) error {
-	return o.baseData.BizDB.Table("organization").Where(field+" = ?", value).Updates(org).Error
+	if err := o.baseData.BizDB.Table("organization").Where(clause.Eq{Column: clause.Column{Name: field}, Value: value}).Updates(org).Error; err != nil {
+		return err
+	}
+	// 图标缓存按组织名存放，按其他字段更新时无法确定影响的组织，全部删除
+	if field == "name" {
+		o.baseData.Cache.Delete(util.GetOrgNameKey(value), util.GetOrgNameKey(org.Name))
+		return nil
+	}
+	names, err := o.FindAllNames()
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		keys = append(keys, util.GetOrgNameKey(name))
+	}
+	o.baseData.Cache.Delete(keys...)
+	return nil
 }
 
 func (o *OrganizationDao) GetOrganization(orgName string) (string, error) {
 	orgKey := util.GetOrgNameKey(orgName)
-	if v, ok := o.baseData.Cache.Get(orgKey); ok {
-		o.baseData.Cache.Set(orgKey, v, config.SysConfig.GetDefaultExpiration())
-		return v.(string), nil
+	var icon string
+	if o.baseData.Cache.Get(orgKey, &icon) {
+		o.baseData.Cache.Set(orgKey, icon, config.SysConfig.GetDefaultExpiration())
+		return icon, nil
 	}
 	mu.Lock()
 	defer mu.Unlock()
-	if v, ok := o.baseData.Cache.Get(orgKey); ok {
-		o.baseData.Cache.Set(orgKey, v, config.SysConfig.GetDefaultExpiration())
-		return v.(string), nil
+	if o.baseData.Cache.Get(orgKey, &icon) {
+		o.baseData.Cache.Set(orgKey, icon, config.SysConfig.GetDefaultExpiration())
+		return icon, nil
 	}
 	orgs := make([]*model.Organization, 0)
 	if err := o.baseData.BizDB.Table("organization").Find(&orgs).Error; err != nil {
@@ -60,8 +78,10 @@ func (o *OrganizationDao) GetOrganization(orgName string) (string, error) {
 	for _, org := range orgs {
 		o.baseData.Cache.Set(util.GetOrgNameKey(org.Name), org.Icon, config.SysConfig.GetDefaultExpiration())
 	}
-	if v, ok := o.baseData.Cache.Get(orgKey); ok {
-		return v.(string), nil
+	for _, org := range orgs {
+		if org.Name == orgName {
+			return org.Icon, nil
+		}
 	}
 	return "", nil
 }
@@ -77,12 +97,11 @@ func (o *OrganizationDao) FindAllNames() ([]string, error) {
 }
 
 func (o *OrganizationDao) SaveOrgBySql(org *model.Organization) error {
-	orgSql := fmt.Sprintf("INSERT INTO organization(name, icon) VALUES ('%s','%s')", org.Name, org.Icon)
-	if err := o.baseData.BizDB.Exec(orgSql).Error; err != nil {
+	if err := o.baseData.BizDB.Select("name", "icon").Create(org).Error; err != nil {
 		return err
 	}
-	orgKey := util.GetOrgNameKey(org.Name)
-	o.baseData.Cache.Delete(orgKey)
+	// 删除后各副本重新加载
+	o.baseData.Cache.Delete(util.GetOrgNameKey(org.Name))
 	return nil
 }
 
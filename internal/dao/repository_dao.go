@@ -15,21 +15,25 @@
 package dao
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	"dingoscheduler/internal/data"
 	"dingoscheduler/internal/model"
 	"dingoscheduler/internal/model/dto"
 	"dingoscheduler/internal/model/query"
+	"dingoscheduler/pkg/consts"
 	myerr "dingoscheduler/pkg/error"
 	"dingoscheduler/pkg/util"
 
 	"github.com/bytedance/sonic"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type RepositoryDao struct {
@@ -39,11 +43,12 @@ type RepositoryDao struct {
 	organizationDao  *OrganizationDao
 	tagDao           *TagDao
 	hfTokenDao       *HfTokenDao
+	leaseLocker      *LeaseLocker
 	persistSync      sync.Mutex
 }
 
 func NewRepositoryDao(data *data.BaseData, repositoryTagDao *RepositoryTagDao, tagDao *TagDao,
-	dingospeedDao *DingospeedDao, organizationDao *OrganizationDao, hfTokenDao *HfTokenDao) *RepositoryDao {
+	dingospeedDao *DingospeedDao, organizationDao *OrganizationDao, hfTokenDao *HfTokenDao, leaseLocker *LeaseLocker) *RepositoryDao {
 	return &RepositoryDao{
 		baseData:         data,
 		tagDao:           tagDao,
@@ -51,6 +56,7 @@ func NewRepositoryDao(data *data.BaseData, repositoryTagDao *RepositoryTagDao, t
 		dingospeedDao:    dingospeedDao,
 		organizationDao:  organizationDao,
 		hfTokenDao:       hfTokenDao,
+		leaseLocker:      leaseLocker,
 	}
 }
 
@@ -62,6 +68,12 @@ func (r *RepositoryDao) PersistRepo(persistRepoReq *query.PersistRepoReq) error
 	)
 	r.persistSync.Lock()
 	defer r.persistSync.Unlock()
+	// 多副本时定时任务只在主副本执行，但手动触发的请求可能落在任一副本
+	ctx, unlock, err := r.leaseLocker.Lock(context.Background(), "persistRepo")
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	pipelineMap, err = r.cachePipelineTags()
 	if err != nil {
 		return err
@@ -88,6 +100,9 @@ func (r *RepositoryDao) PersistRepo(persistRepoReq *query.PersistRepoReq) error
 		}
 		speedDomain := fmt.Sprintf("http://%s:%d", speed.Host, speed.Port)
 		for _, repository := range freeRepositories {
+			if err = context.Cause(ctx); err != nil {
+				return err
+			}
 			if err = r.singleRepositoryPersist(repository, instanceId, speedDomain, pipelineMap, persistRepoReq.OffVerify); err != nil {
 				zap.S().Errorf("singleRepositoryPersist err.%v", err)
 				continue
@@ -123,6 +138,10 @@ func (r *RepositoryDao) singleRepositoryPersist(repository *model.Repository, in
 			return nil
 		}
 	}
+	if repository.Incomplete {
+		// 已登记的仓库重新下载完整，只清除不完整标记
+		return r.UpdateIncomplete([]int64{repository.ID}, false)
+	}
 	// 保存组织图片
 	err = r.organizationDao.PersistOrgLogo(repository.Org)
 	if err != nil {
@@ -183,18 +202,11 @@ func (r *RepositoryDao) verifyRepoComplete(metaData *dto.CommitHfSha, instanceId
 }
 
 func (r *RepositoryDao) SaveBySql(tx *gorm.DB, repo *model.Repository) (int64, error) {
-	recordSql := fmt.Sprintf("INSERT INTO repository (instance_id, datatype, org, repo, org_repo, like_num, download_num, pipeline_tag_id, pipeline_tag, last_modified, used_storage, sha)"+
-		" VALUES( '%s', '%s', '%s', '%s', '%s', %d, %d, '%s', '%s', '%s', %d, '%s')",
-		repo.InstanceId, repo.Datatype, repo.Org, repo.Repo, repo.OrgRepo, repo.LikeNum, repo.DownloadNum, repo.PipelineTagId, repo.PipelineTag, repo.LastModified, repo.UsedStorage, repo.Sha)
-	db, err := tx.DB()
-	if err != nil {
+	if err := tx.Select("instance_id", "datatype", "org", "repo", "org_repo", "like_num", "download_num", "pipeline_tag_id",
+		"pipeline_tag", "last_modified", "used_storage", "sha").Create(repo).Error; err != nil {
 		return 0, err
 	}
-	result, err := db.Exec(recordSql)
-	if err != nil {
-		return 0, err
-	}
-	return result.LastInsertId()
+	return repo.ID, nil
 }
 
 func (r *RepositoryDao) Get(id int64) (*model.Repository, error) {
@@ -230,30 +242,32 @@ func (r *RepositoryDao) RepoAndTagSave(repository *model.Repository, tags []*mod
 
 func (r *RepositoryDao) GetFreeRepository(instanceId, org, repo string) ([]*model.Repository, error) {
 	var repositories []*model.Repository
-	tx := r.baseData.BizDB.Table("model_file_record t1").Select("distinct t1.datatype, t1.org, t1.repo ")
+	// 已登记但被对账标记为不完整的仓库同样返回，带上其id以便重新校验后清除标记
+	tx := r.baseData.BizDB.Table("model_file_record t1").Select("distinct t1.datatype, t1.org, t1.repo, t2.id, t2.incomplete").
+		Joins("left join repository t2 on t2.instance_id = ? and t2.datatype = t1.datatype and t2.org = t1.org and t2.repo = t1.repo", instanceId)
 	if org != "" && repo != "" {
-		tx.Where(fmt.Sprintf(" t1.org = '%s' and t1.repo= '%s'", org, repo))
+		tx.Where("t1.org = ? and t1.repo = ?", org, repo)
 	}
-	err := tx.Where("t1.id in (SELECT x.record_id FROM dingo.model_file_process x where x.instance_id = ?) "+
-		"and t1.repo not in (select repo from repository where instance_id = ?)", instanceId, instanceId).Find(&repositories).Error
+	err := tx.Where("t1.id in (SELECT x.record_id FROM model_file_process x where x.instance_id = ?) "+
+		"and (t2.id is null or t2.incomplete = ?)", instanceId, true).Find(&repositories).Error
 	return repositories, err
 }
 
 func (r *RepositoryDao) VerifyRepoComplete(instanceId, datatype, org, repo string) (int64, error) {
 	var recordCount int64
-	err := r.baseData.BizDB.Table("model_file_record t1").Select("t1.id").InnerJoins(", model_file_process t2").
-		Where("t1.datatype = ? and t1.org=? and t1.repo= ? and t1.id = t2.record_id and t2.instance_id = ? and t1.file_size = t2.offset_num", datatype, org, repo, instanceId).Count(&recordCount).Error
+	err := r.baseData.BizDB.Table("model_file_record t1").Select("t1.id").Joins("join model_file_process t2 on t1.id = t2.record_id").
+		Where("t1.datatype = ? and t1.org=? and t1.repo= ? and t2.instance_id = ? and t1.file_size = t2.offset_num and t2.integrity <> ?", datatype, org, repo, instanceId, consts.IntegrityCorrupted).Count(&recordCount).Error
 	return recordCount, err
 }
 
 func (r *RepositoryDao) ModelList(query *query.ModelQuery) ([]*model.Repository, int64, error) {
 	repositories := make([]*model.Repository, 0)
-	db := r.baseData.BizDB.Table("repository t1").Select("t1.id, t1.org, t1.org_repo, t1.like_num, t1.download_num, t1.sha, t1.pipeline_tag, t1.last_modified, t1.used_storage, t1.status")
+	db := r.baseData.BizDB.Table("repository t1").Select("t1.id, t1.org, t1.org_repo, t1.like_num, t1.download_num, t1.sha, t1.pipeline_tag, t1.last_modified, t1.used_storage, t1.status, t1.incomplete")
 	if query.InstanceId != "" {
 		db.Where("t1.instance_id = ?", query.InstanceId)
 	}
 	if query.Name != "" {
-		db.Where(fmt.Sprintf("t1.org_repo like '%s'", "%"+query.Name+"%"))
+		db.Where("t1.org_repo like ? escape '!'", "%"+escapeLike(query.Name)+"%")
 	}
 	if query.PipelineTag != "" {
 		db.Where("t1.pipeline_tag_id = ?", query.PipelineTag)
@@ -265,6 +279,9 @@ func (r *RepositoryDao) ModelList(query *query.ModelQuery) ([]*model.Repository,
 	if query.Status != "" {
 		db.Where("t1.status = ?", util.Atoi(query.Status))
 	}
+	if query.Incomplete != "" {
+		db.Where("t1.incomplete = ?", query.Incomplete == "true")
+	}
 
 	tags := make([]string, 0)
 	if query.Library != "" {
@@ -293,17 +310,37 @@ func (r *RepositoryDao) ModelList(query *query.ModelQuery) ([]*model.Repository,
 		zap.S().Error("统计数量失败", err)
 		return nil, 0, err
 	}
-	offset, pageSize := paginate(query.Page, query.PageSize)
+	offset, pageSize := Paginate(query.Page, query.PageSize)
 	if query.Sort != "" && query.Order != "" {
-		db.Order(fmt.Sprintf("%s %s offset %d limit %d", query.Sort, query.Order, offset, pageSize))
-	} else {
-		db.Order(fmt.Sprintf("offset %d limit %d", offset, pageSize))
+		// 排序字段只允许白名单中的列，其余按id排序
+		column := "id"
+		if _, ok := sortableRepositoryColumns[query.Sort]; ok {
+			column = query.Sort
+		}
+		db.Order(clause.OrderByColumn{Column: clause.Column{Table: "t1", Name: column}, Desc: strings.EqualFold(query.Order, "desc")})
 	}
+	db.Offset(offset).Limit(pageSize)
 	err := db.Find(&repositories).Error
 	return repositories, count, err
 }
 
-func paginate(page, pageSize int) (int, int) {
+var sortableRepositoryColumns = map[string]struct{}{
+	"like_num":      {},
+	"download_num":  {},
+	"last_modified": {},
+	"used_storage":  {},
+	"created_at":    {},
+}
+
+// escapeLike 转义like中的通配符，配合escape '!'使用
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// Paginate 将页码和每页条数换算为offset和limit，每页最多100条
+func Paginate(page, pageSize int) (int, int) {
 	if page <= 0 {
 		page = 1
 	}
@@ -318,17 +355,21 @@ func paginate(page, pageSize int) (int, int) {
 }
 
 func (r *RepositoryDao) DeleteByInstanceIdAndDatatypeAndOrgAndRepo(instanceId string, datatype string, org string, repo string) (int64, error) {
-	result := r.baseData.BizDB.Model(&model.Repository{}).
-		Where("instance_id = ?", instanceId).
-		Where("datatype = ?", datatype).
-		Where("org = ?", org).
-		Where("repo = ?", repo).
-		Delete(&model.Repository{})
+	var ids []int64
+	if err := r.baseData.BizDB.Model(&model.Repository{}).
+		Where("instance_id = ? and datatype = ? and org = ? and repo = ?", instanceId, datatype, org, repo).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.baseData.BizDB.Where("id in ?", ids).Delete(&model.Repository{})
 
 	if result.Error != nil {
 		return 0, result.Error
 	}
-
+	r.invalidateCards(ids...)
 	return result.RowsAffected, nil
 }
 
@@ -345,13 +386,16 @@ func (r *RepositoryDao) UpdateRepositoryMountStatus(statusReq *query.UpdateMount
 		if err != nil {
 			return err
 		}
-		newMsgStr = strings.ReplaceAll(string(msgStr), "'", "''")
+		newMsgStr = string(msgStr)
 	}
-	sql := fmt.Sprintf("UPDATE repository SET  status = %d, error_msg = '%s', updated_at = '%s' WHERE id = %d",
-		statusReq.Status, newMsgStr, util.GetCurrentTimeStr(), statusReq.Id)
-	if err = r.baseData.BizDB.Exec(sql).Error; err != nil {
+	if err = r.baseData.BizDB.Model(&model.Repository{}).Where("id = ?", statusReq.Id).Updates(map[string]interface{}{
+		"status":     statusReq.Status,
+		"error_msg":  newMsgStr,
+		"updated_at": time.Now(),
+	}).Error; err != nil {
 		return err
 	}
+	r.invalidateCards(statusReq.Id)
 	return nil
 }
 
@@ -373,3 +417,41 @@ func (r *RepositoryDao) GetUnmountRepository(instanceId string, ids []int, runni
 	err := db.Find(&repositories).Error // 中断或等待中的
 	return repositories, err
 }
+
+func (r *RepositoryDao) ListByInstanceId(instanceId string) ([]*model.Repository, error) {
+	repositories := make([]*model.Repository, 0)
+	err := r.baseData.BizDB.Table("repository t1").Select("t1.id, t1.datatype, t1.org, t1.repo, t1.incomplete").
+		Where("t1.instance_id = ?", instanceId).Find(&repositories).Error
+	return repositories, err
+}
+
+// UpdateIncomplete 标记节点磁盘上的仓库是否缺少文件
+func (r *RepositoryDao) UpdateIncomplete(ids []int64, incomplete bool) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := r.baseData.BizDB.Model(&model.Repository{}).Where("id in ?", ids).Updates(map[string]interface{}{
+		"incomplete": incomplete,
+		"updated_at": time.Now(),
+	}).Error; err != nil {
+		return err
+	}
+	r.invalidateCards(ids...)
+	return nil
+}
+
+// invalidateCards 仓库行变化后删除该仓库在各节点上的卡片缓存，共享缓存时各副本同时失效
+func (r *RepositoryDao) invalidateCards(ids ...int64) {
+	speeds, err := r.dingospeedDao.List()
+	if err != nil {
+		zap.S().Errorf("invalidate repository cards %v err.%v", ids, err)
+		return
+	}
+	keys := make([]string, 0, len(speeds)*len(ids))
+	for _, speed := range speeds {
+		for _, id := range ids {
+			keys = append(keys, util.GetCardKey(speed.InstanceID, id))
+		}
+	}
+	r.baseData.Cache.Delete(keys...)
+}
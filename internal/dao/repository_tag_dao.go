@@ -1,8 +1,6 @@
 package dao
 
 import (
-	"fmt"
-
 	"dingoscheduler/internal/data"
 	"dingoscheduler/internal/model"
 
@@ -21,28 +19,19 @@ func NewRepositoryTagDao(data *data.BaseData) *RepositoryTagDao {
 }
 
 func (r *RepositoryTagDao) SaveBySql(tx *gorm.DB, repo *model.RepositoryTag) (int64, error) {
-	recordSql := fmt.Sprintf("INSERT INTO repository_tag (repo_id, tag_id) VALUES(%d, '%s')",
-		repo.RepoId, repo.TagId)
-	db, err := tx.DB()
-	if err != nil {
-		return 0, err
-	}
-	result, err := db.Exec(recordSql)
-	if err != nil {
+	row := &model.RepositoryTag{RepoId: repo.RepoId, TagId: repo.TagId}
+	if err := tx.Select("repo_id", "tag_id").Create(row).Error; err != nil {
 		return 0, err
 	}
-	return result.LastInsertId()
+	return row.ID, nil
 }
 
 func (r *RepositoryTagDao) BatchSave(tx *gorm.DB, repositoryTags []*model.RepositoryTag) error {
 	for _, repositoryTag := range repositoryTags {
-		recordSql := fmt.Sprintf("INSERT INTO repository_tag (repo_id, tag_id) VALUES(%d, '%s')",
-			repositoryTag.RepoId, repositoryTag.TagId)
-		result := tx.Exec(recordSql)
-		if result.Error != nil {
+		if _, err := r.SaveBySql(tx, repositoryTag); err != nil {
 			// 出错回滚事务
-			zap.S().Error("批量插入失败: %v", result.Error)
-			return result.Error
+			zap.S().Error("批量插入失败: %v", err)
+			return err
 		}
 	}
 	return nil
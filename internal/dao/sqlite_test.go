@@ -0,0 +1,481 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package dao
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"dingoscheduler/internal/data"
+	"dingoscheduler/internal/migrate"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/model/dto"
+	"dingoscheduler/internal/model/query"
+	"dingoscheduler/pkg/common"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/consts"
+	myorm "dingoscheduler/pkg/gorm"
+	pb "dingoscheduler/pkg/proto/manager"
+)
+
+// newSqliteData 基于sqlite内存数据库的BaseData，表结构由内置迁移创建，用于验证查询在非MySQL数据库上可执行
+func newSqliteData(t testing.TB) *data.BaseData {
+	t.Helper()
+	config.SysConfig = &config.Config{}
+	config.SysConfig.SetDefaults()
+	db, err := myorm.NewSqliteClient(&config.DBConfig{Type: consts.DB_SQLITE, Database: ":memory:"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = migrate.Up(db, consts.DB_SQLITE); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+	return &data.BaseData{BizDB: db, Cache: data.NewMemoryCache(time.Minute, time.Minute)}
+}
+
+func TestSqliteDingospeed(t *testing.T) {
+	speeds := NewDingospeedDao(newSqliteData(t))
+	id, err := speeds.Save(&model.Dingospeed{InstanceID: "speed-a", Host: "127.0.0.1", Port: 8001, Online: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id <= 0 {
+		t.Fatalf("id = %d", id)
+	}
+	if err = speeds.RegisterUpdate(&model.Dingospeed{ID: int32(id), Host: "127.0.0.2", Port: 8002}); err != nil {
+		t.Fatal(err)
+	}
+	speed, err := speeds.GetEntity("speed-a", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if speed == nil || speed.Host != "127.0.0.2" || speed.Status != consts.SpeedStatusAlive {
+		t.Fatalf("speed = %+v", speed)
+	}
+	expired, err := speeds.ListExpired(time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expired) != 1 {
+		t.Fatalf("got %d expired speeds, want 1", len(expired))
+	}
+}
+
+// TestSqliteExpire 标记失效、清除master和写入失效事件要么一起生效，要么一起回滚
+func TestSqliteExpire(t *testing.T) {
+	baseData := newSqliteData(t)
+	speeds, records, events := NewDingospeedDao(baseData), NewModelFileRecordDao(baseData), NewEventDao(baseData)
+	id, err := speeds.Save(&model.Dingospeed{InstanceID: "speed-a", Online: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	processId, _, err := records.SaveSchedulerRecord(&pb.SchedulerFileRequest{DataType: "models", Org: "org", Repo: "repo",
+		Name: "model.bin", Etag: "etag", FileSize: 30}, &model.ModelFileProcess{InstanceID: "speed-b", MasterInstanceID: "speed-a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Hour)
+	failed := errors.New("marshal failed")
+	if _, ok, err := speeds.Expire(int32(id), "speed-a", deadline, func([]*model.ModelFileProcess) (*model.SchedulerEvent, error) {
+		return nil, failed
+	}); !errors.Is(err, failed) || ok {
+		t.Fatalf("expire = %v, %v", ok, err)
+	}
+	// 事务回滚，下次检查仍能查到该节点及以其为master的进度
+	if expired, err := speeds.ListExpired(deadline); err != nil || len(expired) != 1 {
+		t.Fatalf("expired = %+v, err %v", expired, err)
+	}
+
+	processes, ok, err := speeds.Expire(int32(id), "speed-a", deadline, func(processes []*model.ModelFileProcess) (*model.SchedulerEvent, error) {
+		return &model.SchedulerEvent{Topic: "expired", Payload: fmt.Sprint(len(processes))}, nil
+	})
+	if err != nil || !ok || len(processes) != 1 || processes[0].ID != processId || processes[0].InstanceID != "speed-b" {
+		t.Fatalf("expire = %+v, %v, %v", processes, ok, err)
+	}
+	rows, err := events.ListAfter(0, 10)
+	if err != nil || len(rows) != 1 || rows[0].Topic != "expired" || rows[0].Payload != "1" {
+		t.Fatalf("events = %+v, err %v", rows, err)
+	}
+	if expired, err := speeds.ListExpired(deadline); err != nil || len(expired) != 0 {
+		t.Fatalf("expired = %+v, err %v", expired, err)
+	}
+	// 已标记失效的节点不会重复通知
+	if _, ok, err = speeds.Expire(int32(id), "speed-a", deadline, nil); err != nil || ok {
+		t.Fatalf("expire again = %v, %v", ok, err)
+	}
+}
+
+func TestSqliteFileProcess(t *testing.T) {
+	baseData := newSqliteData(t)
+	records, processes := NewModelFileRecordDao(baseData), NewModelFileProcessDao(baseData)
+	processId, _, err := records.SaveSchedulerRecord(&pb.SchedulerFileRequest{DataType: "models", Org: "org", Repo: "repo",
+		Name: "model.bin", Etag: "etag", FileSize: 30}, &model.ModelFileProcess{InstanceID: "speed-a", Status: consts.StatusDownloading})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = processes.BatchReportFileProcess(map[int64]*dto.ProcessReport{
+		processId: {Ranges: common.RangeSet{}.Add(0, 10).Add(20, 30), Status: consts.StatusDownloading},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	files, err := processes.ListInstanceFiles("speed-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].ProcessID != processId || files[0].OffsetNum != 10 || files[0].Name != "model.bin" {
+		t.Fatalf("files = %+v", files)
+	}
+	list, err := processes.GetModelFileProcess(files[0].RecordID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || !list[0].HasRange(20, 30) {
+		t.Fatalf("processes = %+v", list)
+	}
+
+	// 重置进度后数据需重新校验
+	for _, reset := range []func() error{
+		func() error {
+			return processes.ResetProcess(&model.ModelFileProcess{ID: processId, Status: consts.StatusDownloading})
+		},
+		func() error {
+			return processes.ResetOffsets([]*model.ModelFileProcess{{ID: processId, OffsetNum: 10, Status: consts.StatusDownloadBreak}})
+		},
+	} {
+		if err = processes.UpdateIntegrity(processId, consts.IntegrityVerified); err != nil {
+			t.Fatal(err)
+		}
+		if err = reset(); err != nil {
+			t.Fatal(err)
+		}
+		process, err := processes.GetById(processId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if process.Integrity != consts.IntegrityUnverified {
+			t.Fatalf("integrity = %d after reset, want unverified", process.Integrity)
+		}
+	}
+}
+
+func TestSqliteUpsertRecordAndProcess(t *testing.T) {
+	baseData := newSqliteData(t)
+	records, processes := NewModelFileRecordDao(baseData), NewModelFileProcessDao(baseData)
+	req := &pb.SchedulerFileRequest{DataType: "models", Org: "org", Repo: "repo", Name: "model.bin", Etag: "etag", FileSize: 30}
+	first, inserted, err := records.SaveSchedulerRecord(req, &model.ModelFileProcess{InstanceID: "speed-a", Status: consts.StatusDownloading})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !inserted {
+		t.Fatal("first save did not insert the record")
+	}
+	if err = processes.BatchReportFileProcess(map[int64]*dto.ProcessReport{
+		first: {Ranges: common.RangeSet{}.Add(0, 10), Status: consts.StatusDownloading},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// 并发调度时另一个副本重复保存同一文件
+	second, inserted, err := records.SaveSchedulerRecord(req, &model.ModelFileProcess{InstanceID: "speed-a", Status: consts.StatusDownloading})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inserted {
+		t.Fatal("second save reported a new record")
+	}
+	if second != first {
+		t.Fatalf("second save created process %d, want %d", second, first)
+	}
+	process, err := processes.GetById(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if process.OffsetNum != 10 {
+		t.Fatalf("existing progress overwritten, offset = %d", process.OffsetNum)
+	}
+	other, _, err := records.SaveSchedulerRecord(req, &model.ModelFileProcess{InstanceID: "speed-b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := processes.GetModelFileProcess(process.RecordID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == first || len(list) != 2 {
+		t.Fatalf("got %d processes for record %d", len(list), process.RecordID)
+	}
+	var count int64
+	if err = baseData.BizDB.Model(&model.ModelFileRecord{}).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("got %d records, want 1", count)
+	}
+}
+
+// TestSqliteSaveRecordLongNames 前缀相同的长文件名是不同的记录，重复保存时按完整的name取回已有记录
+func TestSqliteSaveRecordLongNames(t *testing.T) {
+	db := newSqliteData(t).BizDB
+	ids := make(map[string]int64)
+	for _, name := range []string{longName + "a.bin", longName + "b.bin", longName + "a.bin"} {
+		id, inserted, err := SaveRecordBySql(db, &model.ModelFileRecord{Datatype: "models", Org: "org", Repo: "repo", Name: name,
+			Etag: "etag", FileSize: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if existing, ok := ids[name]; ok {
+			if inserted || id != existing {
+				t.Fatalf("resave %s got id %d inserted %v, want %d", name[len(longName):], id, inserted, existing)
+			}
+			continue
+		}
+		if !inserted {
+			t.Fatalf("%s not inserted", name[len(longName):])
+		}
+		ids[name] = id
+	}
+	if ids[longName+"a.bin"] == ids[longName+"b.bin"] {
+		t.Fatal("names with the same prefix share one record")
+	}
+}
+
+func TestSqliteRepository(t *testing.T) {
+	baseData := newSqliteData(t)
+	records, processes := NewModelFileRecordDao(baseData), NewModelFileProcessDao(baseData)
+	tags := NewTagDao(baseData)
+	repositories := NewRepositoryDao(baseData, NewRepositoryTagDao(baseData), tags, NewDingospeedDao(baseData),
+		NewOrganizationDao(baseData), NewHfTokenDao(baseData), NewLeaseLocker(NewLeaseDao(baseData)))
+	for _, name := range []string{"a.bin", "b.bin"} {
+		processId, _, err := records.SaveSchedulerRecord(&pb.SchedulerFileRequest{DataType: "models", Org: "org", Repo: "repo",
+			Name: name, Etag: name, FileSize: 10}, &model.ModelFileProcess{InstanceID: "speed-a"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = processes.BatchReportFileProcess(map[int64]*dto.ProcessReport{
+			processId: {Ranges: common.RangeSet{}.Add(0, 10), Status: consts.StatusDownloaded},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	free, err := repositories.GetFreeRepository("speed-a", "org", "repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(free) != 1 {
+		t.Fatalf("got %d free repositories, want 1", len(free))
+	}
+	complete, err := repositories.VerifyRepoComplete("speed-a", "models", "org", "repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if complete != 2 {
+		t.Fatalf("complete files = %d, want 2", complete)
+	}
+
+	if err = tags.Create(&model.Tag{ID: "pytorch", Label: "PyTorch", Type: "library"}); err != nil {
+		t.Fatal(err)
+	}
+	for i, repo := range []string{"repo", "repo-2"} {
+		if err = repositories.RepoAndTagSave(&model.Repository{InstanceId: "speed-a", Datatype: "models", Org: "org", Repo: repo,
+			OrgRepo: "org/" + repo, LikeNum: i}, []*model.RepositoryTag{{TagId: "pytorch"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	list, total, err := repositories.ModelList(&query.ModelQuery{InstanceId: "speed-a", Library: "pytorch", Sort: "like_num", Order: "desc", PageSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || len(list) != 1 || list[0].OrgRepo != "org/repo-2" {
+		t.Fatalf("got %d/%d, first %+v", len(list), total, list)
+	}
+	repoTags, err := tags.GetTagByRepoId(list[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repoTags) != 1 || repoTags[0].Label != "PyTorch" {
+		t.Fatalf("tags = %+v", repoTags)
+	}
+	if free, _ = repositories.GetFreeRepository("speed-a", "org", "repo"); len(free) != 0 {
+		t.Fatalf("persisted repository is still free: %+v", free)
+	}
+
+	// 对账标记为不完整的仓库重新参与持久化校验，并可按标记筛选
+	registered, err := repositories.ListByInstanceId("speed-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = repositories.UpdateIncomplete([]int64{registered[0].ID}, true); err != nil {
+		t.Fatal(err)
+	}
+	if free, _ = repositories.GetFreeRepository("speed-a", "org", "repo"); len(free) != 1 || free[0].ID != registered[0].ID || !free[0].Incomplete {
+		t.Fatalf("incomplete repository free = %+v", free)
+	}
+	for incomplete, want := range map[string]string{"true": "org/repo", "false": "org/repo-2"} {
+		list, total, err = repositories.ModelList(&query.ModelQuery{InstanceId: "speed-a", Incomplete: incomplete})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 1 || list[0].OrgRepo != want || list[0].Incomplete != (incomplete == "true") {
+			t.Fatalf("incomplete=%s got %d, first %+v", incomplete, total, list)
+		}
+	}
+}
+
+func TestSqliteCacheJob(t *testing.T) {
+	baseData := newSqliteData(t)
+	cacheJobs := NewCacheJobDao(baseData, nil)
+	for i := 0; i < 3; i++ {
+		if err := cacheJobs.Save(&model.CacheJob{InstanceId: "speed-a", Datatype: "models", Org: "org", Repo: "repo",
+			Status: consts.RunningStatusJobWait, CreatedAt: time.Now().Add(time.Duration(i) * time.Second)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	jobs, total, err := cacheJobs.ListCacheJob(&query.CacheJobQuery{InstanceId: "speed-a", Page: 2, PageSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(jobs) != 1 || jobs[0].ID != 1 {
+		t.Fatalf("got %d/%d, first %+v", len(jobs), total, jobs)
+	}
+	if err = cacheJobs.UpdateCacheStatus(&query.UpdateJobStatusReq{Id: 1, Status: consts.RunningStatusJobBreak, ErrorMsg: "it's down"}); err != nil {
+		t.Fatal(err)
+	}
+	job, err := cacheJobs.GetCacheJob(&query.CacheJobQuery{Id: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != consts.RunningStatusJobBreak || job.ErrorMsg != `{"msg":"it's down"}` {
+		t.Fatalf("job = %+v", job)
+	}
+}
+
+// TestSqliteFragmentedRanges 碎片化的区间超过上限时只保存起始位置最小的部分
+func TestSqliteFragmentedRanges(t *testing.T) {
+	baseData := newSqliteData(t)
+	records, processes := NewModelFileRecordDao(baseData), NewModelFileProcessDao(baseData)
+	processId, _, err := records.SaveSchedulerRecord(&pb.SchedulerFileRequest{DataType: "models", Org: "org", Repo: "repo",
+		Name: "model.bin", Etag: "etag", FileSize: 1 << 30}, &model.ModelFileProcess{InstanceID: "speed-a", Status: consts.StatusDownloading})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := common.RangeSet{}
+	for i := int64(0); i < common.MaxStoredRanges+200; i++ {
+		rs = rs.Add(i*100, i*100+50)
+	}
+	if err = processes.BatchReportFileProcess(map[int64]*dto.ProcessReport{
+		processId: {Ranges: rs, Status: consts.StatusDownloading},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	process, err := processes.GetById(processId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := common.ParseRangeSet(process.Ranges)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != common.MaxStoredRanges || process.OffsetNum != 50 || !stored.Contains(0, 50) {
+		t.Fatalf("stored %d ranges, offset %d", len(stored), process.OffsetNum)
+	}
+}
+
+func TestSqliteIntegrity(t *testing.T) {
+	baseData := newSqliteData(t)
+	records, processes, integrity := NewModelFileRecordDao(baseData), NewModelFileProcessDao(baseData), NewIntegrityDao(baseData)
+	processIds := make([]int64, 0, 2)
+	for _, instanceId := range []string{"speed-a", "speed-b"} {
+		processId, _, err := records.SaveSchedulerRecord(&pb.SchedulerFileRequest{DataType: "models", Org: "org", Repo: "repo",
+			Name: "model.bin", Etag: "etag", FileSize: 20}, &model.ModelFileProcess{InstanceID: instanceId})
+		if err != nil {
+			t.Fatal(err)
+		}
+		processIds = append(processIds, processId)
+	}
+	chunk := func(processId, start int64, digest string) *model.FileChecksum {
+		return &model.FileChecksum{ProcessID: processId, Etag: "etag", StartPos: start, EndPos: start + 10, Sha256: digest}
+	}
+	if err := integrity.SaveChecksums([]*model.FileChecksum{chunk(processIds[0], 0, "old"), chunk(processIds[1], 0, "b")}); err != nil {
+		t.Fatal(err)
+	}
+	// 相同区间覆盖，未校验通过的摘要不作为基准
+	if err := integrity.SaveChecksums([]*model.FileChecksum{chunk(processIds[0], 0, "a"), chunk(processIds[0], 10, "a")}); err != nil {
+		t.Fatal(err)
+	}
+	if refs, err := integrity.ReferenceChecksums("etag"); err != nil || len(refs) != 0 {
+		t.Fatalf("refs before verify = %+v, %v", refs, err)
+	}
+	if err := integrity.VerifyChecksums(processIds[0]); err != nil {
+		t.Fatal(err)
+	}
+	refs, err := integrity.ReferenceChecksums("etag")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refs) != 2 || refs[0].Sha256 != "a" || refs[1].StartPos != 10 {
+		t.Fatalf("refs = %+v", refs)
+	}
+
+	now := time.Now()
+	if err = integrity.Quarantine("etag", "speed-c", now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err = integrity.Quarantine("etag", "speed-d", now.Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	// 重复隔离时延长
+	if err = integrity.Quarantine("etag", "speed-d", now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		at   time.Time
+		want int
+	}{{now, 2}, {now.Add(2 * time.Minute), 1}, {now.Add(2 * time.Hour), 0}} {
+		instanceIds, err := integrity.QuarantinedInstances("etag", tt.at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(instanceIds) != tt.want {
+			t.Fatalf("quarantined at %s = %v, want %d", tt.at.Sub(now), instanceIds, tt.want)
+		}
+	}
+
+	// 进度删除后其区间摘要被清理，过期的隔离一并删除
+	if _, err = processes.DeleteByIds([]int64{processIds[0]}); err != nil {
+		t.Fatal(err)
+	}
+	purged, err := integrity.Purge(now.Add(2 * time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if purged != 3 {
+		t.Fatalf("purged = %d, want 3", purged)
+	}
+	if refs, _ = integrity.ReferenceChecksums("etag"); len(refs) != 0 {
+		t.Fatalf("refs of deleted process = %+v", refs)
+	}
+	if err = integrity.DeleteChecksums(processIds[1]); err != nil {
+		t.Fatal(err)
+	}
+	var left int64
+	if err = baseData.BizDB.Model(&model.FileChecksum{}).Count(&left).Error; err != nil || left != 0 {
+		t.Fatalf("checksums left = %d, %v", left, err)
+	}
+}
@@ -0,0 +1,156 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/model/dto"
+	"dingoscheduler/internal/model/query"
+	pb "dingoscheduler/pkg/proto/manager"
+)
+
+// 服务层依赖的存储接口，MySQL实现为各Dao，内存实现见dao/memory，用于测试及模拟回放
+
+type DingospeedStore interface {
+	Save(speed *model.Dingospeed) (int64, error)
+	RegisterUpdate(speed *model.Dingospeed) error
+	HeartbeatUpdate(speed *model.Dingospeed) error
+	GetEntity(instanceId string, online bool) (*model.Dingospeed, error)
+	List() ([]*model.Dingospeed, error)
+	ListExpired(deadline time.Time) ([]*model.Dingospeed, error)
+	// Expire 在一个事务中标记节点失效并清除以其为master的下载进度的master，event非空时根据受影响的进度生成事件一并写入。
+	// 期间已恢复心跳的节点不受影响，返回受影响的进度及是否标记成功
+	Expire(id int32, instanceId string, deadline time.Time, event func([]*model.ModelFileProcess) (*model.SchedulerEvent, error)) ([]*model.ModelFileProcess, bool, error)
+}
+
+type RecordStore interface {
+	SaveSchedulerRecord(req *pb.SchedulerFileRequest, process *model.ModelFileProcess) (int64, bool, error)
+	BatchSaveSchedulerRecords(reqs []*pb.SchedulerFileRequest, processes []*model.ModelFileProcess) ([]bool, error)
+	FirstModelFileRecord(condition *query.ModelFileRecordQuery) (*model.ModelFileRecord, error)
+	GetByIDs(ids []int64) ([]model.ModelFileRecord, error)
+	BatchQueryByEtags(etags []string) ([]model.ModelFileRecord, error)
+	GetIDsByEtagsOrFields(etag, datatype, org, repo, name string) ([]int64, error)
+	ScanRecords(afterId int64, limit int) ([]*model.ModelFileRecord, error)
+}
+
+type ProcessStore interface {
+	Save(process *model.ModelFileProcess) (int64, error)
+	ResetProcess(process *model.ModelFileProcess) error
+	GetById(id int64) (*model.ModelFileProcess, error)
+	UpdateIntegrity(id int64, integrity int32) error
+	MarkCorrupted(id int64) error
+	UpdateMaster(id int64, masterInstanceId string) error
+	BatchReportFileProcess(reports map[int64]*dto.ProcessReport) error
+	SyncFileProcess(batch *dto.ProcessSync) (map[int64]struct{}, error)
+	GetModelFileProcess(recordId int64) ([]*dto.ModelFileProcessDto, error)
+	ScanProcesses(afterId int64, limit int) ([]*dto.ModelFileProcessDto, error)
+	GetModelFileProcessByInstanceId(recordId int64, instanceId string) (*dto.ModelFileProcessDto, error)
+	ListInstanceFiles(instanceId string) ([]*dto.InstanceFileDto, error)
+	ResetOffsets(processes []*model.ModelFileProcess) error
+	DeleteByIds(ids []int64) (int64, error)
+	DeleteByRecordIDAndInstanceID(recordID []int64, instanceID string) (int64, error)
+}
+
+type RepositoryStore interface {
+	DeleteByInstanceIdAndDatatypeAndOrgAndRepo(instanceId string, datatype string, org string, repo string) (int64, error)
+	UpdateRepositoryMountStatus(statusReq *query.UpdateMountStatusReq) error
+	ListByInstanceId(instanceId string) ([]*model.Repository, error)
+	UpdateIncomplete(ids []int64, incomplete bool) error
+	Get(id int64) (*model.Repository, error)
+	GetUnmountRepository(instanceId string, ids []int, runningStatus []int32, limit int) ([]*model.Repository, error)
+	ModelList(query *query.ModelQuery) ([]*model.Repository, int64, error)
+	// PersistRepo 将节点上已下载完成、尚未登记的仓库写入仓库表
+	PersistRepo(persistRepoReq *query.PersistRepoReq) error
+}
+
+type CacheJobStore interface {
+	Save(preheatJob *model.CacheJob) error
+	GetCacheJob(condition *query.CacheJobQuery) (*model.CacheJob, error)
+	ListCacheJob(condition *query.CacheJobQuery) ([]*model.CacheJob, int64, error)
+	GetUnCacheJob(instanceId string, ids []int, runningStatus []int32, limit int) ([]*model.CacheJob, error)
+	UpdateCacheStatus(statusReq *query.UpdateJobStatusReq) error
+	// UpdateStatusAndRepo 更新任务状态，任务完成时登记仓库
+	UpdateStatusAndRepo(jobStatusReq *query.UpdateJobStatusReq) error
+	Delete(id int64) error
+}
+
+type TagStore interface {
+	GetTagByRepoId(repoId int64) ([]*model.Tag, error)
+	TagListByCondition(condition *query.TagQuery) ([]*model.Tag, error)
+	TagCountByCondition(condition *query.TagQuery) (int64, error)
+}
+
+type OrganizationStore interface {
+	// GetOrganization 返回组织的图标，组织不存在时返回空串
+	GetOrganization(orgName string) (string, error)
+}
+
+// LeaseStore 多副本部署时选主及分布式锁使用的租约
+type LeaseStore interface {
+	// Acquire 租约不存在、已过期或本就由holder持有时取得或续约
+	Acquire(name, holder string, ttl time.Duration) (bool, error)
+	// Release 释放holder持有的租约，已被其他持有者接管时不做处理
+	Release(name, holder string) error
+	// Holder 返回租约当前的持有者，无人持有或已过期时为空串
+	Holder(name string) (string, error)
+}
+
+// EventStore 多副本部署时广播给各副本的事件，由主副本顺序写入，各副本按id轮询
+type EventStore interface {
+	Append(topic string, payload []byte) error
+	// ListAfter 按id顺序返回id大于afterId的事件
+	ListAfter(afterId int64, limit int) ([]*model.SchedulerEvent, error)
+	// LastId 返回最新事件的id，没有事件时为0
+	LastId() (int64, error)
+	DeleteBefore(t time.Time) (int64, error)
+}
+
+// IntegrityStore 区间摘要及节点隔离，保存在数据库中由各副本共享
+type IntegrityStore interface {
+	// SaveChecksums 保存进度上报的区间摘要，同一进度的相同区间覆盖
+	SaveChecksums(checksums []*model.FileChecksum) error
+	// VerifyChecksums 进度整体校验通过，其区间摘要作为相同etag的比对基准
+	VerifyChecksums(processId int64) error
+	// ReferenceChecksums 返回相同etag已校验通过的区间摘要
+	ReferenceChecksums(etag string) ([]*model.FileChecksum, error)
+	DeleteChecksums(processId int64) error
+	// Quarantine 节点在expiresAt之前不作为etag的来源，重复隔离时延长
+	Quarantine(etag, instanceId string, expiresAt time.Time) error
+	// QuarantinedInstances 返回now时仍不能作为etag来源的节点
+	QuarantinedInstances(etag string, now time.Time) ([]string, error)
+	// Purge 删除所属进度已不存在的区间摘要及now之前已过期的隔离
+	Purge(now time.Time) (int64, error)
+}
+
+type HfTokenStore interface {
+	GetHeaders() map[string]string
+	RefreshToken() string
+}
+
+var (
+	_ DingospeedStore   = (*DingospeedDao)(nil)
+	_ RecordStore       = (*ModelFileRecordDao)(nil)
+	_ ProcessStore      = (*ModelFileProcessDao)(nil)
+	_ RepositoryStore   = (*RepositoryDao)(nil)
+	_ CacheJobStore     = (*CacheJobDao)(nil)
+	_ TagStore          = (*TagDao)(nil)
+	_ OrganizationStore = (*OrganizationDao)(nil)
+	_ HfTokenStore      = (*HfTokenDao)(nil)
+	_ LeaseStore        = (*LeaseDao)(nil)
+	_ EventStore        = (*EventDao)(nil)
+	_ IntegrityStore    = (*IntegrityDao)(nil)
+)
@@ -0,0 +1,102 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package data
+
+import (
+	"maps"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+)
+
+// Cache 节点信息、组织图标、仓库卡片等热点数据的缓存。内存实现只在本副本可见；
+// redis实现供多副本部署，数据及删除对所有副本可见，修改数据库后须删除对应的key。
+type Cache interface {
+	// Get 取出key的值写入value，value须为指向所存类型的指针，只判断是否存在时传nil
+	Get(key string, value interface{}) bool
+	// Set ttl为0时使用默认过期时间
+	Set(key string, value interface{}, ttl time.Duration)
+	Delete(keys ...string)
+	// HGetAll 取出哈希key的全部字段，不存在时返回空map
+	HGetAll(key string) map[string]string
+	// HSet 写入哈希key的字段，不影响其他字段，各副本并发写入不同字段时互不覆盖。ttl为0时使用默认过期时间
+	HSet(key string, fields map[string]string, ttl time.Duration)
+}
+
+// memoryCache 基于go-cache，取出的是存入的值本身，指针类型的值在本副本内共享
+type memoryCache struct {
+	c  *cache.Cache
+	mu sync.Mutex // 哈希字段的读写
+}
+
+func NewMemoryCache(defaultExpiration, cleanupInterval time.Duration) Cache {
+	return &memoryCache{c: cache.New(defaultExpiration, cleanupInterval)}
+}
+
+func (m *memoryCache) Get(key string, value interface{}) bool {
+	v, ok := m.c.Get(key)
+	if !ok {
+		return false
+	}
+	if value == nil {
+		return true
+	}
+	dst, src := reflect.ValueOf(value).Elem(), reflect.ValueOf(v)
+	if !src.Type().AssignableTo(dst.Type()) {
+		zap.S().Errorf("cache %s holds %s, want %s", key, src.Type(), dst.Type())
+		return false
+	}
+	dst.Set(src)
+	return true
+}
+
+func (m *memoryCache) Set(key string, value interface{}, ttl time.Duration) {
+	m.c.Set(key, value, ttl)
+}
+
+func (m *memoryCache) Delete(keys ...string) {
+	for _, key := range keys {
+		m.c.Delete(key)
+	}
+}
+
+func (m *memoryCache) HGetAll(key string) map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fields := make(map[string]string)
+	if v, ok := m.c.Get(key); ok {
+		if h, ok := v.(map[string]string); ok {
+			maps.Copy(fields, h)
+		}
+	}
+	return fields
+}
+
+// HSet 存入的map不再修改，每次写入复制一份，HGetAll返回的副本可由调用方修改
+func (m *memoryCache) HSet(key string, fields map[string]string, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h := make(map[string]string, len(fields))
+	if v, ok := m.c.Get(key); ok {
+		if old, ok := v.(map[string]string); ok {
+			maps.Copy(h, old)
+		}
+	}
+	maps.Copy(h, fields)
+	m.c.Set(key, h, ttl)
+}
@@ -0,0 +1,151 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package data
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"dingoscheduler/internal/model"
+	"dingoscheduler/pkg/common"
+	"dingoscheduler/pkg/config"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func newRedisCache(t *testing.T, server *miniredis.Miniredis) Cache {
+	t.Helper()
+	c, cleanup, err := NewRedisCache(&config.Redis{Addr: server.Addr()}, "test:", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cleanup)
+	return c
+}
+
+func TestCacheRoundTrip(t *testing.T) {
+	server := miniredis.RunT(t)
+	for name, c := range map[string]Cache{
+		"memory": NewMemoryCache(time.Hour, time.Hour),
+		"redis":  newRedisCache(t, server),
+	} {
+		t.Run(name, func(t *testing.T) {
+			c.Set("speed", &model.Dingospeed{ID: 1, InstanceID: "speed-a", Port: 8001, UpdatedAt: time.Now()}, 0)
+			var speed *model.Dingospeed
+			if !c.Get("speed", &speed) || speed.InstanceID != "speed-a" || speed.Port != 8001 {
+				t.Fatalf("speed = %+v", speed)
+			}
+
+			c.Set("org", "icon.png", 0)
+			var icon string
+			if !c.Get("org", &icon) || icon != "icon.png" {
+				t.Fatalf("icon = %q", icon)
+			}
+
+			c.Set("digests", map[common.Range]string{{Start: 0, End: 10}: "abc"}, 0)
+			var digests map[common.Range]string
+			if !c.Get("digests", &digests) || digests[common.Range{Start: 0, End: 10}] != "abc" {
+				t.Fatalf("digests = %v", digests)
+			}
+
+			c.Set("card", &common.Response{StatusCode: 200, Headers: map[string]interface{}{"Etag": []string{"v1"}},
+				Body: []byte("# readme")}, 0)
+			var card *common.Response
+			if !c.Get("card", &card) || card.GetKey("Etag") != "v1" || string(card.Body) != "# readme" {
+				t.Fatalf("card = %+v", card)
+			}
+
+			c.Set("quarantine", true, 0)
+			if !c.Get("quarantine", nil) {
+				t.Fatal("quarantine marker not found")
+			}
+			c.Delete("quarantine", "org")
+			if c.Get("quarantine", nil) || c.Get("org", &icon) {
+				t.Fatal("deleted keys still cached")
+			}
+			if c.Get("missing", &icon) {
+				t.Fatal("missing key found")
+			}
+		})
+	}
+}
+
+// TestCacheHashFields 两个副本并发写入同一哈希的不同字段，互不覆盖
+func TestCacheHashFields(t *testing.T) {
+	server := miniredis.RunT(t)
+	memory := NewMemoryCache(time.Hour, time.Hour)
+	for name, replicas := range map[string][2]Cache{
+		"memory": {memory, memory},
+		"redis":  {newRedisCache(t, server), newRedisCache(t, server)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if fields := replicas[0].HGetAll("checksum/etag"); len(fields) != 0 {
+				t.Fatalf("missing hash = %v", fields)
+			}
+			var wg sync.WaitGroup
+			for i, c := range replicas {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < 50; j++ {
+						c.HSet("checksum/etag", map[string]string{fmt.Sprintf("%d-%d", i, j): "abc"}, 0)
+					}
+				}()
+			}
+			wg.Wait()
+			fields := replicas[1].HGetAll("checksum/etag")
+			if len(fields) != 100 || fields["1-49"] != "abc" {
+				t.Fatalf("hash has %d fields, want 100", len(fields))
+			}
+			fields["0-0"] = "changed"
+			if replicas[0].HGetAll("checksum/etag")["0-0"] != "abc" {
+				t.Fatal("caller modified the cached hash")
+			}
+			replicas[0].Delete("checksum/etag")
+			if fields := replicas[1].HGetAll("checksum/etag"); len(fields) != 0 {
+				t.Fatalf("deleted hash = %v", fields)
+			}
+		})
+	}
+}
+
+// TestRedisCacheShared 两个副本共用一个redis，一个副本删除后另一个副本同时失效
+func TestRedisCacheShared(t *testing.T) {
+	server := miniredis.RunT(t)
+	first, second := newRedisCache(t, server), newRedisCache(t, server)
+
+	first.Set("org", "icon.png", time.Minute)
+	var icon string
+	if !second.Get("org", &icon) || icon != "icon.png" {
+		t.Fatalf("second replica got %q", icon)
+	}
+	second.Delete("org")
+	if first.Get("org", &icon) {
+		t.Fatal("first replica still sees the deleted key")
+	}
+
+	first.Set("speed", &model.Dingospeed{InstanceID: "speed-a"}, time.Minute)
+	server.FastForward(2 * time.Minute)
+	if second.Get("speed", nil) {
+		t.Fatal("expired key still cached")
+	}
+	// redis不可用时按未命中处理
+	server.Close()
+	if first.Get("org", &icon) {
+		t.Fatal("get should miss when redis is down")
+	}
+}
@@ -17,12 +17,12 @@ import (
 	"errors"
 	"fmt"
 
+	"dingoscheduler/internal/migrate"
 	"dingoscheduler/pkg/config"
 	"dingoscheduler/pkg/consts"
 	myorm "dingoscheduler/pkg/gorm"
 
 	"github.com/google/wire"
-	"github.com/patrickmn/go-cache"
 	"gorm.io/gorm"
 )
 
@@ -30,7 +30,7 @@ var BaseDataProvider = wire.NewSet(NewBaseData)
 
 type BaseData struct {
 	BizDB *gorm.DB
-	Cache *cache.Cache
+	Cache Cache
 }
 
 func initDB(dbConfig *config.DBConfig) (*gorm.DB, error) {
@@ -39,6 +39,10 @@ func initDB(dbConfig *config.DBConfig) (*gorm.DB, error) {
 	switch dbConfig.Type {
 	case consts.DB_MYSQL:
 		dbClient, err = myorm.NewMysqlClient(dbConfig)
+	case consts.DB_SQLITE:
+		dbClient, err = myorm.NewSqliteClient(dbConfig)
+	case consts.DB_POSTGRES:
+		dbClient, err = myorm.NewPostgresClient(dbConfig)
 	default:
 		err = errors.New(fmt.Sprintf("unknown db type: %s", dbConfig.Type))
 	}
@@ -51,10 +55,23 @@ func NewBaseData(conf *config.Config) (*BaseData, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	gCache := cache.New(config.SysConfig.GetDefaultExpiration(), config.SysConfig.GetCleanupInterval())
+	if conf.BizDBConfig.AutoMigrate {
+		err = migrate.Up(bizClient, conf.BizDBConfig.Type)
+	} else {
+		err = migrate.Check(bizClient, conf.BizDBConfig.Type)
+	}
+	if err != nil {
+		closeDB(bizClient)
+		return nil, nil, err
+	}
+	gCache, closeCache, err := newCache(conf)
+	if err != nil {
+		closeDB(bizClient)
+		return nil, nil, err
+	}
 	cleanup := func() {
-		bizDb, _ := bizClient.DB()
-		_ = bizDb.Close()
+		closeCache()
+		closeDB(bizClient)
 	}
 
 	var debug = conf.Server.Mode != "release"
@@ -66,3 +83,38 @@ func NewBaseData(conf *config.Config) (*BaseData, func(), error) {
 		Cache: gCache,
 	}, cleanup, nil
 }
+
+func newCache(conf *config.Config) (Cache, func(), error) {
+	switch conf.GetCacheType() {
+	case consts.CacheMemory:
+		return NewMemoryCache(conf.GetDefaultExpiration(), conf.GetCleanupInterval()), func() {}, nil
+	case consts.CacheRedis:
+		return NewRedisCache(&conf.Cache.Redis, conf.GetCachePrefix(), conf.GetDefaultExpiration())
+	default:
+		return nil, nil, fmt.Errorf("unknown cache type: %s", conf.Cache.Type)
+	}
+}
+
+// OpenBizDB 只打开业务库，不检查表结构版本，供migrate、dedupe等子命令使用
+func OpenBizDB(conf *config.Config) (*gorm.DB, func(), error) {
+	bizClient, err := initDB(&conf.BizDBConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bizClient, func() { closeDB(bizClient) }, nil
+}
+
+// Migrate 执行表结构迁移，供migrate子命令使用
+func Migrate(conf *config.Config) error {
+	bizClient, cleanup, err := OpenBizDB(conf)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return migrate.Up(bizClient, conf.BizDBConfig.Type)
+}
+
+func closeDB(db *gorm.DB) {
+	sqlDB, _ := db.DB()
+	_ = sqlDB.Close()
+}
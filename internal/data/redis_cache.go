@@ -0,0 +1,127 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"time"
+
+	"dingoscheduler/pkg/config"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+func init() {
+	// 仓库卡片的响应头以[]string存放在map[string]interface{}中
+	gob.Register([]string{})
+}
+
+// redisCache 基于redis协议的共享缓存，值以gob编码，chan、func类型的字段不会保存。
+// redis不可用时按未命中处理，调用方回源数据库。
+type redisCache struct {
+	client            *redis.Client
+	prefix            string
+	defaultExpiration time.Duration
+}
+
+func NewRedisCache(conf *config.Redis, prefix string, defaultExpiration time.Duration) (Cache, func(), error) {
+	client := redis.NewClient(&redis.Options{Addr: conf.Addr, Password: conf.Password, DB: conf.DB})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, nil, err
+	}
+	return &redisCache{client: client, prefix: prefix, defaultExpiration: defaultExpiration}, func() {
+		_ = client.Close()
+	}, nil
+}
+
+func (r *redisCache) Get(key string, value interface{}) bool {
+	b, err := r.client.Get(context.Background(), r.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return false
+	}
+	if err != nil {
+		zap.S().Errorf("cache get %s err.%v", key, err)
+		return false
+	}
+	if value == nil {
+		return true
+	}
+	if err = gob.NewDecoder(bytes.NewReader(b)).Decode(value); err != nil {
+		zap.S().Errorf("cache decode %s err.%v", key, err)
+		return false
+	}
+	return true
+}
+
+func (r *redisCache) Set(key string, value interface{}, ttl time.Duration) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		zap.S().Errorf("cache encode %s err.%v", key, err)
+		return
+	}
+	if ttl <= 0 {
+		ttl = r.defaultExpiration
+	}
+	if err := r.client.Set(context.Background(), r.prefix+key, buf.Bytes(), ttl).Err(); err != nil {
+		zap.S().Errorf("cache set %s err.%v", key, err)
+	}
+}
+
+func (r *redisCache) Delete(keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	prefixed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		prefixed = append(prefixed, r.prefix+key)
+	}
+	if err := r.client.Del(context.Background(), prefixed...).Err(); err != nil {
+		zap.S().Errorf("cache delete %v err.%v", keys, err)
+	}
+}
+
+func (r *redisCache) HGetAll(key string) map[string]string {
+	fields, err := r.client.HGetAll(context.Background(), r.prefix+key).Result()
+	if err != nil {
+		zap.S().Errorf("cache hgetall %s err.%v", key, err)
+		return make(map[string]string)
+	}
+	return fields
+}
+
+func (r *redisCache) HSet(key string, fields map[string]string, ttl time.Duration) {
+	if len(fields) == 0 {
+		return
+	}
+	if ttl <= 0 {
+		ttl = r.defaultExpiration
+	}
+	ctx := context.Background()
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, r.prefix+key, fields)
+		pipe.Expire(ctx, r.prefix+key, ttl)
+		return nil
+	})
+	if err != nil {
+		zap.S().Errorf("cache hset %s err.%v", key, err)
+	}
+}
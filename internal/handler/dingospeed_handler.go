@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"dingoscheduler/internal/service"
+	"dingoscheduler/pkg/util"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type DingospeedHandler struct {
+	dingospeedService *service.DingospeedService
+}
+
+func NewDingospeedHandler(dingospeedService *service.DingospeedService) *DingospeedHandler {
+	return &DingospeedHandler{
+		dingospeedService: dingospeedService,
+	}
+}
+
+func (handler *DingospeedHandler) ListDingospeedHandler(c echo.Context) error {
+	speeds, err := handler.dingospeedService.ListDingospeed()
+	if err != nil {
+		zap.S().Errorf("ListDingospeed err.%v", err)
+		return util.ResponseError(c, err)
+	}
+	return util.ResponseData(c, speeds)
+}
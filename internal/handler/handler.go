@@ -18,4 +18,5 @@ import (
 	"github.com/google/wire"
 )
 
-var HandlerProvider = wire.NewSet(NewSysHandler, NewManagerHandler, NewRepositoryHandler, NewCacheJobHandler, NewTagHandler)
+var HandlerProvider = wire.NewSet(NewSysHandler, NewManagerHandler, NewRepositoryHandler, NewCacheJobHandler, NewTagHandler,
+	NewDingospeedHandler)
@@ -60,6 +60,14 @@ func (handler *RepositoryHandler) RepositoriesHandler(c echo.Context) error {
 	other := c.QueryParam("other")
 	datatype := c.QueryParam("datatype")
 	status := c.QueryParam("status")
+	incomplete := c.QueryParam("incomplete")
+	if incomplete != "" {
+		b, err := strconv.ParseBool(incomplete)
+		if err != nil {
+			return util.ErrorRequestParamCN(c)
+		}
+		incomplete = strconv.FormatBool(b)
+	}
 	models, total, err := handler.repositoryService.RepositoryList(&query.ModelQuery{
 		InstanceId:        instanceId,
 		Name:              name,
@@ -76,6 +84,7 @@ func (handler *RepositoryHandler) RepositoriesHandler(c echo.Context) error {
 		Other:             other,
 		Datatype:          datatype,
 		Status:            status,
+		Incomplete:        incomplete,
 	})
 	if err != nil {
 		return util.ResponseError(c, err)
@@ -169,6 +178,22 @@ func (handler *RepositoryHandler) MountRepositoryHandler(c echo.Context) error {
 	return util.NormalResponseData(c, nil)
 }
 
+func (handler *RepositoryHandler) EvictRepositoryHandler(c echo.Context) error {
+	evictRepoReq := new(query.EvictRepoReq)
+	if err := c.Bind(evictRepoReq); err != nil {
+		return util.ErrorRequestParamCN(c)
+	}
+	instanceId, err := GetInstanceId(evictRepoReq.AidcCode)
+	if err != nil || evictRepoReq.Org == "" || evictRepoReq.Repo == "" {
+		return util.ErrorRequestParamCN(c)
+	}
+	if err = handler.repositoryService.EvictRepository(instanceId, evictRepoReq); err != nil {
+		zap.S().Errorf("EvictRepository err.%v", err)
+		return util.ResponseError(c, err)
+	}
+	return util.NormalResponseData(c, nil)
+}
+
 func GetInstanceId(aidcCode string) (string, error) {
 	if aidcCode == "" {
 		return "", fmt.Errorf("aidcCode is null")
@@ -0,0 +1,278 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package migrate 内置的数据库表结构迁移，每种数据库一套按版本号排序的sql文件，
+// 已执行的版本记录在schema_version表中。
+package migrate
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"dingoscheduler/pkg/consts"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+//go:embed sql
+var sqlFS embed.FS
+
+const versionTable = "schema_version"
+
+const (
+	// lockName mysql GET_LOCK的锁名，postgres取其hashtext作为advisory lock的键
+	lockName = "dingoscheduler_migrate"
+	// lockTimeout 等待其他副本完成迁移的最长时间，大表DDL可能耗时较长
+	lockTimeout       = 10 * time.Minute
+	lockRetryInterval = time.Second
+)
+
+// localLock sqlite的进程内迁移锁
+var localLock sync.Mutex
+
+// Migration 一个版本的迁移脚本，文件名格式为<版本号>_<说明>.sql
+type Migration struct {
+	Version    int
+	Name       string
+	Statements []string
+}
+
+// Load 读取dialect（mysql、sqlite、postgres）对应的迁移脚本，按版本号升序返回
+func Load(dialect string) ([]Migration, error) {
+	dir := path.Join("sql", dialect)
+	entries, err := fs.ReadDir(sqlFS, dir)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported migrate dialect %s: %w", dialect, err)
+	}
+	migrations := make([]Migration, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		base := strings.TrimSuffix(entry.Name(), ".sql")
+		prefix, name, _ := strings.Cut(base, "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration file name %s", entry.Name())
+		}
+		content, err := fs.ReadFile(sqlFS, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, Migration{Version: version, Name: name, Statements: splitStatements(string(content))})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			return nil, fmt.Errorf("duplicate migration version %d", migrations[i].Version)
+		}
+	}
+	return migrations, nil
+}
+
+// Up 执行所有未执行的迁移，数据库版本高于内置的最新版本时返回错误。
+// 多个副本同时启动时通过数据库锁串行执行，取得锁后重新读取版本，跳过其他副本已执行的迁移。
+func Up(db *gorm.DB, dialect string) error {
+	migrations, err := Load(dialect)
+	if err != nil {
+		return err
+	}
+	// 会话级的锁只对持有它的连接有效，锁定、迁移、释放须在同一个连接上完成
+	return db.Connection(func(conn *gorm.DB) error {
+		unlock, err := lock(conn, dialect)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		return up(conn, migrations)
+	})
+}
+
+func up(db *gorm.DB, migrations []Migration) error {
+	if err := ensureVersionTable(db); err != nil {
+		return err
+	}
+	current, err := currentVersion(db)
+	if err != nil {
+		return err
+	}
+	if err = checkVersion(current, migrations); err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		// mysql的DDL会隐式提交事务，失败时需人工处理后重新执行；sqlite、postgres可整体回滚
+		err = db.Transaction(func(tx *gorm.DB) error {
+			for _, stmt := range m.Statements {
+				if err := tx.Exec(stmt).Error; err != nil {
+					return fmt.Errorf("migration %d_%s: %w", m.Version, m.Name, err)
+				}
+			}
+			return tx.Exec(fmt.Sprintf("INSERT INTO %s (version, name) VALUES (?, ?)", versionTable), m.Version, m.Name).Error
+		})
+		if err != nil {
+			return err
+		}
+		zap.S().Infof("[Migrate] applied %d_%s.", m.Version, m.Name)
+	}
+	return nil
+}
+
+// lock 取得迁移锁，返回释放函数。mysql使用GET_LOCK，postgres使用advisory lock，
+// 连接断开时数据库自动释放；sqlite只用于单机部署，在进程内互斥。
+func lock(conn *gorm.DB, dialect string) (func(), error) {
+	switch dialect {
+	case consts.DB_MYSQL:
+		var got sql.NullInt64
+		if err := conn.Raw("SELECT GET_LOCK(?, ?)", lockName, int(lockTimeout.Seconds())).Scan(&got).Error; err != nil {
+			return nil, fmt.Errorf("acquire migrate lock: %w", err)
+		}
+		if !got.Valid || got.Int64 != 1 {
+			return nil, fmt.Errorf("acquire migrate lock: timeout after %s", lockTimeout)
+		}
+		return func() {
+			if err := conn.Exec("SELECT RELEASE_LOCK(?)", lockName).Error; err != nil {
+				zap.S().Warnf("[Migrate] release lock err.%v", err)
+			}
+		}, nil
+	case consts.DB_POSTGRES:
+		// pg_advisory_lock无法设置等待时间，轮询pg_try_advisory_lock直到超时
+		deadline := time.Now().Add(lockTimeout)
+		for {
+			var got bool
+			if err := conn.Raw("SELECT pg_try_advisory_lock(hashtext(?))", lockName).Scan(&got).Error; err != nil {
+				return nil, fmt.Errorf("acquire migrate lock: %w", err)
+			}
+			if got {
+				return func() {
+					if err := conn.Exec("SELECT pg_advisory_unlock(hashtext(?))", lockName).Error; err != nil {
+						zap.S().Warnf("[Migrate] release lock err.%v", err)
+					}
+				}, nil
+			}
+			if time.Now().After(deadline) {
+				return nil, fmt.Errorf("acquire migrate lock: timeout after %s", lockTimeout)
+			}
+			time.Sleep(lockRetryInterval)
+		}
+	default:
+		localLock.Lock()
+		return localLock.Unlock, nil
+	}
+}
+
+// Check 只检查不执行，数据库版本高于内置的最新版本时返回错误，存在未执行的迁移时打印告警
+func Check(db *gorm.DB, dialect string) error {
+	migrations, err := Load(dialect)
+	if err != nil {
+		return err
+	}
+	current, err := Version(db)
+	if err != nil {
+		return err
+	}
+	if err = checkVersion(current, migrations); err != nil {
+		return err
+	}
+	if latest := latestVersion(migrations); current < latest {
+		zap.S().Warnf("[Migrate] schema version %d is behind %d, run the migrate subcommand or enable bizDB.autoMigrate.", current, latest)
+	}
+	return nil
+}
+
+// Version 返回数据库当前的表结构版本，未执行过迁移时为0
+func Version(db *gorm.DB) (int, error) {
+	if !db.Migrator().HasTable(versionTable) {
+		return 0, nil
+	}
+	return currentVersion(db)
+}
+
+func ensureVersionTable(db *gorm.DB) error {
+	return db.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+    version    int          NOT NULL,
+    name       varchar(255) NOT NULL,
+    applied_at timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (version)
+)`, versionTable)).Error
+}
+
+func currentVersion(db *gorm.DB) (int, error) {
+	var version int
+	err := db.Raw(fmt.Sprintf("SELECT COALESCE(MAX(version), 0) FROM %s", versionTable)).Scan(&version).Error
+	return version, err
+}
+
+func latestVersion(migrations []Migration) int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// checkVersion 数据库由更新的版本迁移过时拒绝启动，避免旧程序写坏新表结构
+func checkVersion(current int, migrations []Migration) error {
+	if latest := latestVersion(migrations); current > latest {
+		return fmt.Errorf("schema version %d is newer than the latest known version %d, upgrade dingoscheduler", current, latest)
+	}
+	return nil
+}
+
+// splitStatements 按分号拆分脚本，忽略--注释行及引号内的分号
+func splitStatements(script string) []string {
+	var lines []string
+	for _, line := range strings.Split(script, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	script = strings.Join(lines, "\n")
+
+	var statements []string
+	var quote rune
+	start := 0
+	for i, c := range script {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == ';':
+			if stmt := strings.TrimSpace(script[start:i]); stmt != "" {
+				statements = append(statements, stmt)
+			}
+			start = i + 1
+		}
+	}
+	if stmt := strings.TrimSpace(script[start:]); stmt != "" {
+		statements = append(statements, stmt)
+	}
+	return statements
+}
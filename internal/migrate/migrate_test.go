@@ -0,0 +1,155 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package migrate
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/consts"
+	myorm "dingoscheduler/pkg/gorm"
+
+	"gorm.io/gorm"
+)
+
+var tables = []string{"dingospeed", "model_file_record", "model_file_process", "repository", "repository_tag", "tag",
+	"organization", "hf_token", "cache_job", "scheduler_lease", "scheduler_event", "file_checksum", "peer_quarantine"}
+
+func newSqliteDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := myorm.NewSqliteClient(&config.DBConfig{Type: consts.DB_SQLITE, Database: ":memory:"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+	return db
+}
+
+func TestLoadAllDialects(t *testing.T) {
+	var versions []int
+	for _, dialect := range []string{consts.DB_MYSQL, consts.DB_SQLITE, consts.DB_POSTGRES} {
+		migrations, err := Load(dialect)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(migrations) == 0 {
+			t.Fatalf("%s has no migrations", dialect)
+		}
+		if versions != nil && len(versions) != len(migrations) {
+			t.Fatalf("%s has %d migrations, want %d", dialect, len(migrations), len(versions))
+		}
+		for i, m := range migrations {
+			if versions != nil && versions[i] != m.Version {
+				t.Fatalf("%s migration %d has version %d, want %d", dialect, i, m.Version, versions[i])
+			}
+		}
+		if versions == nil {
+			for _, m := range migrations {
+				versions = append(versions, m.Version)
+			}
+		}
+	}
+	if _, err := Load("oracle"); err == nil {
+		t.Fatal("unknown dialect should fail")
+	}
+}
+
+func TestUp(t *testing.T) {
+	db := newSqliteDB(t)
+	if err := Up(db, consts.DB_SQLITE); err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range tables {
+		if !db.Migrator().HasTable(table) {
+			t.Fatalf("table %s not created", table)
+		}
+	}
+	migrations, _ := Load(consts.DB_SQLITE)
+	version, err := Version(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != latestVersion(migrations) {
+		t.Fatalf("version = %d, want %d", version, latestVersion(migrations))
+	}
+	// 重复执行不再应用已记录的版本
+	if err = Up(db, consts.DB_SQLITE); err != nil {
+		t.Fatal(err)
+	}
+	if err = Check(db, consts.DB_SQLITE); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// 多个副本同时启动时只有一个执行迁移，其余取得锁后重新读取版本并跳过
+func TestConcurrentUp(t *testing.T) {
+	database := filepath.Join(t.TempDir(), "migrate.db")
+	const replicas = 4
+	errs := make(chan error, replicas)
+	var wg sync.WaitGroup
+	for i := 0; i < replicas; i++ {
+		db, err := myorm.NewSqliteClient(&config.DBConfig{Type: consts.DB_SQLITE, Database: database, MaxConn: 2, MaxIdleConn: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			sqlDB, _ := db.DB()
+			_ = sqlDB.Close()
+		})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- Up(db, consts.DB_SQLITE)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRefuseFutureVersion(t *testing.T) {
+	db := newSqliteDB(t)
+	if err := Up(db, consts.DB_SQLITE); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec("INSERT INTO schema_version (version, name) VALUES (?, ?)", 9999, "future").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := Check(db, consts.DB_SQLITE); err == nil {
+		t.Fatal("Check should refuse a future schema version")
+	}
+	if err := Up(db, consts.DB_SQLITE); err == nil {
+		t.Fatal("Up should refuse a future schema version")
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	statements := splitStatements("-- comment; ignored\nCREATE TABLE a (c varchar(8) DEFAULT ';');\n\nCREATE INDEX i ON a (c);\n")
+	if len(statements) != 2 {
+		t.Fatalf("got %d statements: %q", len(statements), statements)
+	}
+	if statements[0] != "CREATE TABLE a (c varchar(8) DEFAULT ';')" {
+		t.Fatalf("statement = %q", statements[0])
+	}
+}
@@ -0,0 +1,130 @@
+-- 初始表结构，包含此前以ALTER TABLE发布的列
+
+CREATE TABLE IF NOT EXISTS dingospeed (
+    id                 int          NOT NULL AUTO_INCREMENT,
+    instance_id        varchar(128) NOT NULL,
+    host               varchar(255) NOT NULL,
+    port               int          NOT NULL,
+    online             tinyint(1)   NOT NULL COMMENT '是否在线',
+    active_uploads     int          NOT NULL DEFAULT 0 COMMENT '正在对外提供下载的连接数',
+    outbound_bandwidth bigint       NOT NULL DEFAULT 0 COMMENT '出口带宽（字节/秒）',
+    free_disk          bigint       NOT NULL DEFAULT 0 COMMENT '剩余磁盘空间（字节）',
+    queued_cache_jobs  int          NOT NULL DEFAULT 0 COMMENT '排队中的缓存任务数',
+    max_uploads        int          NOT NULL DEFAULT 0 COMMENT '对外下载连接数上限',
+    bandwidth_limit    bigint       NOT NULL DEFAULT 0 COMMENT '出口带宽上限（字节/秒）',
+    version            varchar(64)  NOT NULL DEFAULT '',
+    aidc               varchar(64)  NOT NULL DEFAULT '' COMMENT '所属aidc',
+    status             tinyint      NOT NULL DEFAULT 1 COMMENT '1存活，2心跳超时',
+    created_at         datetime     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at         datetime     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_dingospeed_instance (instance_id, online)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS model_file_record (
+    id         bigint        NOT NULL AUTO_INCREMENT,
+    datatype   varchar(32)   NOT NULL,
+    org        varchar(128)  NOT NULL,
+    repo       varchar(255)  NOT NULL,
+    name       varchar(1024) NOT NULL,
+    etag       varchar(128)  NOT NULL,
+    file_size  bigint        NOT NULL,
+    created_at datetime      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at datetime      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY idx_record_repo (datatype, org, repo),
+    KEY idx_record_etag (etag)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS model_file_process (
+    id                 bigint        NOT NULL AUTO_INCREMENT,
+    record_id          bigint        NOT NULL,
+    instance_id        varchar(128)  NOT NULL,
+    offset_num         bigint        NOT NULL,
+    status             tinyint       NOT NULL COMMENT '下载状态：1(正在下载)，2（下载中断），3（下载完成）',
+    master_instance_id varchar(128)  NOT NULL DEFAULT '',
+    ranges             varchar(2048) NOT NULL DEFAULT '' COMMENT '已完成的字节区间，非连续下载时记录',
+    integrity          tinyint       NOT NULL DEFAULT 0 COMMENT '完整性：0(未校验)，1（校验通过），2（数据损坏）',
+    created_at         datetime      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at         datetime      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY idx_process_record (record_id),
+    KEY idx_process_instance (instance_id),
+    KEY idx_process_master (master_instance_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS repository (
+    id              bigint        NOT NULL AUTO_INCREMENT,
+    instance_id     varchar(128)  NOT NULL,
+    datatype        varchar(32)   NOT NULL,
+    org             varchar(128)  NOT NULL,
+    repo            varchar(255)  NOT NULL,
+    org_repo        varchar(384)  NOT NULL,
+    like_num        int           NOT NULL DEFAULT 0,
+    download_num    int           NOT NULL DEFAULT 0,
+    pipeline_tag_id varchar(128)  NOT NULL DEFAULT '',
+    pipeline_tag    varchar(128)  NOT NULL DEFAULT '',
+    last_modified   varchar(64)   NOT NULL DEFAULT '',
+    used_storage    bigint                 DEFAULT 0,
+    sha             varchar(64)   NOT NULL DEFAULT '',
+    status          tinyint       NOT NULL DEFAULT 0,
+    incomplete      tinyint(1)    NOT NULL DEFAULT 0 COMMENT '节点磁盘上缺少文件',
+    error_msg       varchar(2048) NOT NULL DEFAULT '',
+    created_at      datetime      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      datetime      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_repository_instance (instance_id, datatype, org, repo),
+    KEY idx_repository_repo (repo)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS repository_tag (
+    id      bigint       NOT NULL AUTO_INCREMENT,
+    repo_id bigint       NOT NULL,
+    tag_id  varchar(255) NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_repository_tag (repo_id, tag_id),
+    KEY idx_repository_tag_tag (tag_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS tag (
+    id       varchar(255) NOT NULL,
+    label    varchar(255) NOT NULL,
+    type     varchar(64)  NOT NULL,
+    sub_type varchar(64)  NOT NULL DEFAULT '',
+    PRIMARY KEY (id),
+    KEY idx_tag_type (type)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS organization (
+    id   bigint       NOT NULL AUTO_INCREMENT,
+    name varchar(128) NOT NULL,
+    icon varchar(512) NOT NULL DEFAULT '',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_organization_name (name)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS hf_token (
+    id      bigint       NOT NULL AUTO_INCREMENT,
+    token   varchar(255) NOT NULL,
+    enabled tinyint(1)   NOT NULL DEFAULT 1 COMMENT '是否启用',
+    PRIMARY KEY (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS cache_job (
+    id           bigint        NOT NULL AUTO_INCREMENT,
+    type         tinyint       NOT NULL,
+    instance_id  varchar(128)  NOT NULL,
+    datatype     varchar(32)   NOT NULL,
+    org          varchar(128)  NOT NULL,
+    repo         varchar(255)  NOT NULL,
+    used_storage bigint        NOT NULL DEFAULT 0,
+    `commit`     varchar(64)   NOT NULL DEFAULT '',
+    status       tinyint       NOT NULL,
+    error_msg    varchar(2048) NOT NULL DEFAULT '',
+    process      float         NOT NULL DEFAULT 0,
+    created_at   datetime      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   datetime      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY idx_cache_job_instance (instance_id, status),
+    KEY idx_cache_job_repo (datatype, org, repo)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
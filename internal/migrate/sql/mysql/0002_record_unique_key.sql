@@ -0,0 +1,3 @@
+-- 文件记录的唯一键，库中已有重复数据时先执行 dingoscheduler dedupe 合并
+-- utf8mb4下完整的name超出索引长度限制，只取前200个字符
+ALTER TABLE model_file_record ADD UNIQUE KEY uk_record_file (datatype, org, repo, name(200), etag);
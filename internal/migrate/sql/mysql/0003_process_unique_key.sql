@@ -0,0 +1,2 @@
+-- 下载进度的唯一键，库中已有重复数据时先执行 dingoscheduler dedupe 合并
+ALTER TABLE model_file_process ADD UNIQUE KEY uk_process_instance (record_id, instance_id);
@@ -0,0 +1,7 @@
+-- 多副本部署时的选主租约和分布式锁，expires_at为unix毫秒时间戳
+CREATE TABLE IF NOT EXISTS scheduler_lease (
+    name       varchar(255) NOT NULL,
+    holder     varchar(255) NOT NULL,
+    expires_at bigint       NOT NULL,
+    PRIMARY KEY (name)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,9 @@
+-- 多副本部署时广播给各副本的事件，由主副本写入，各副本按id顺序轮询
+CREATE TABLE IF NOT EXISTS scheduler_event (
+    id         bigint      NOT NULL AUTO_INCREMENT,
+    topic      varchar(64) NOT NULL,
+    payload    text        NOT NULL,
+    created_at datetime    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY idx_event_created (created_at)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
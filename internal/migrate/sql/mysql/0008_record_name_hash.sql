@@ -0,0 +1,6 @@
+-- 0002的唯一键只取name的前200个字符，前缀相同的不同文件会互相冲突；改为对完整name的SHA-256建唯一键，
+-- 哈希按字节计算，大小写不同的文件名也视为不同文件。库中已有重复数据时先执行 dingoscheduler dedupe 合并
+ALTER TABLE model_file_record
+    ADD COLUMN name_hash char(64) AS (SHA2(name, 256)) STORED COMMENT '完整name的SHA-256，用于唯一键',
+    DROP INDEX uk_record_file,
+    ADD UNIQUE KEY uk_record_file (datatype, org, repo, name_hash, etag);
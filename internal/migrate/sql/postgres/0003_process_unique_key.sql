@@ -0,0 +1,2 @@
+-- 下载进度的唯一键，库中已有重复数据时先执行 dingoscheduler dedupe 合并
+CREATE UNIQUE INDEX IF NOT EXISTS uk_process_instance ON model_file_process (record_id, instance_id);
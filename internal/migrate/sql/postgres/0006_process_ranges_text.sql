@@ -0,0 +1,2 @@
+-- 下载碎片化时区间数可能很多，varchar(2048)约85个区间即溢出，改为text
+ALTER TABLE model_file_process ALTER COLUMN ranges TYPE text;
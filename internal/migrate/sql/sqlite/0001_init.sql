@@ -0,0 +1,122 @@
+-- 初始表结构，包含此前以ALTER TABLE发布的列
+
+CREATE TABLE IF NOT EXISTS dingospeed (
+    id                 INTEGER PRIMARY KEY AUTOINCREMENT,
+    instance_id        varchar(128) NOT NULL,
+    host               varchar(255) NOT NULL,
+    port               int          NOT NULL,
+    online             boolean      NOT NULL,
+    active_uploads     int          NOT NULL DEFAULT 0,
+    outbound_bandwidth bigint       NOT NULL DEFAULT 0,
+    free_disk          bigint       NOT NULL DEFAULT 0,
+    queued_cache_jobs  int          NOT NULL DEFAULT 0,
+    max_uploads        int          NOT NULL DEFAULT 0,
+    bandwidth_limit    bigint       NOT NULL DEFAULT 0,
+    version            varchar(64)  NOT NULL DEFAULT '',
+    aidc               varchar(64)  NOT NULL DEFAULT '',
+    status             integer      NOT NULL DEFAULT 1,
+    created_at         DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at         DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uk_dingospeed_instance ON dingospeed (instance_id, online);
+
+CREATE TABLE IF NOT EXISTS model_file_record (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    datatype   varchar(32)   NOT NULL,
+    org        varchar(128)  NOT NULL,
+    repo       varchar(255)  NOT NULL,
+    name       varchar(1024) NOT NULL,
+    etag       varchar(128)  NOT NULL,
+    file_size  bigint        NOT NULL,
+    created_at DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_record_repo ON model_file_record (datatype, org, repo);
+CREATE INDEX IF NOT EXISTS idx_record_etag ON model_file_record (etag);
+
+CREATE TABLE IF NOT EXISTS model_file_process (
+    id                 INTEGER PRIMARY KEY AUTOINCREMENT,
+    record_id          bigint        NOT NULL,
+    instance_id        varchar(128)  NOT NULL,
+    offset_num         bigint        NOT NULL,
+    status             integer       NOT NULL,
+    master_instance_id varchar(128)  NOT NULL DEFAULT '',
+    ranges             varchar(2048) NOT NULL DEFAULT '',
+    integrity          integer       NOT NULL DEFAULT 0,
+    created_at         DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at         DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_process_record ON model_file_process (record_id);
+CREATE INDEX IF NOT EXISTS idx_process_instance ON model_file_process (instance_id);
+CREATE INDEX IF NOT EXISTS idx_process_master ON model_file_process (master_instance_id);
+
+CREATE TABLE IF NOT EXISTS repository (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    instance_id     varchar(128)  NOT NULL,
+    datatype        varchar(32)   NOT NULL,
+    org             varchar(128)  NOT NULL,
+    repo            varchar(255)  NOT NULL,
+    org_repo        varchar(384)  NOT NULL,
+    like_num        int           NOT NULL DEFAULT 0,
+    download_num    int           NOT NULL DEFAULT 0,
+    pipeline_tag_id varchar(128)  NOT NULL DEFAULT '',
+    pipeline_tag    varchar(128)  NOT NULL DEFAULT '',
+    last_modified   varchar(64)   NOT NULL DEFAULT '',
+    used_storage    bigint                 DEFAULT 0,
+    sha             varchar(64)   NOT NULL DEFAULT '',
+    status          integer       NOT NULL DEFAULT 0,
+    incomplete      boolean       NOT NULL DEFAULT 0,
+    error_msg       varchar(2048) NOT NULL DEFAULT '',
+    created_at      DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uk_repository_instance ON repository (instance_id, datatype, org, repo);
+CREATE INDEX IF NOT EXISTS idx_repository_repo ON repository (repo);
+
+CREATE TABLE IF NOT EXISTS repository_tag (
+    id      INTEGER PRIMARY KEY AUTOINCREMENT,
+    repo_id bigint       NOT NULL,
+    tag_id  varchar(255) NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uk_repository_tag ON repository_tag (repo_id, tag_id);
+CREATE INDEX IF NOT EXISTS idx_repository_tag_tag ON repository_tag (tag_id);
+
+CREATE TABLE IF NOT EXISTS tag (
+    id       varchar(255) NOT NULL,
+    label    varchar(255) NOT NULL,
+    type     varchar(64)  NOT NULL,
+    sub_type varchar(64)  NOT NULL DEFAULT '',
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_tag_type ON tag (type);
+
+CREATE TABLE IF NOT EXISTS organization (
+    id   INTEGER PRIMARY KEY AUTOINCREMENT,
+    name varchar(128) NOT NULL,
+    icon varchar(512) NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uk_organization_name ON organization (name);
+
+CREATE TABLE IF NOT EXISTS hf_token (
+    id      INTEGER PRIMARY KEY AUTOINCREMENT,
+    token   varchar(255) NOT NULL,
+    enabled boolean      NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS cache_job (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    type         integer       NOT NULL,
+    instance_id  varchar(128)  NOT NULL,
+    datatype     varchar(32)   NOT NULL,
+    org          varchar(128)  NOT NULL,
+    repo         varchar(255)  NOT NULL,
+    used_storage bigint        NOT NULL DEFAULT 0,
+    "commit"     varchar(64)   NOT NULL DEFAULT '',
+    status       integer       NOT NULL,
+    error_msg    varchar(2048) NOT NULL DEFAULT '',
+    process      float         NOT NULL DEFAULT 0,
+    created_at   DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_cache_job_instance ON cache_job (instance_id, status);
+CREATE INDEX IF NOT EXISTS idx_cache_job_repo ON cache_job (datatype, org, repo);
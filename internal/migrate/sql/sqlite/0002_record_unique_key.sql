@@ -0,0 +1,2 @@
+-- 文件记录的唯一键，库中已有重复数据时先执行 dingoscheduler dedupe 合并
+CREATE UNIQUE INDEX IF NOT EXISTS uk_record_file ON model_file_record (datatype, org, repo, name, etag);
@@ -0,0 +1,8 @@
+-- 多副本部署时广播给各副本的事件，由主副本写入，各副本按id顺序轮询
+CREATE TABLE IF NOT EXISTS scheduler_event (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    topic      varchar(64) NOT NULL,
+    payload    text        NOT NULL,
+    created_at DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_event_created ON scheduler_event (created_at);
@@ -0,0 +1 @@
+-- sqlite不限制varchar的长度，只保持版本号与其他数据库一致
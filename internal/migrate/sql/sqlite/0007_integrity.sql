@@ -0,0 +1,21 @@
+-- 节点上报的区间摘要，随下载进度保存，进度整体校验通过后作为相同etag的比对基准，进度删除后由主副本清理
+CREATE TABLE IF NOT EXISTS file_checksum (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    process_id bigint       NOT NULL,
+    etag       varchar(128) NOT NULL,
+    start_pos  bigint       NOT NULL,
+    end_pos    bigint       NOT NULL,
+    sha256     char(64)     NOT NULL,
+    verified   boolean      NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uk_checksum_chunk ON file_checksum (process_id, start_pos, end_pos);
+CREATE INDEX IF NOT EXISTS idx_checksum_etag ON file_checksum (etag, verified);
+
+-- 提供过损坏数据的节点，expires_at（unix毫秒时间戳）之前不作为该etag的来源
+CREATE TABLE IF NOT EXISTS peer_quarantine (
+    etag        varchar(128) NOT NULL,
+    instance_id varchar(128) NOT NULL,
+    expires_at  bigint       NOT NULL,
+    PRIMARY KEY (etag, instance_id)
+);
+CREATE INDEX IF NOT EXISTS idx_quarantine_expires ON peer_quarantine (expires_at);
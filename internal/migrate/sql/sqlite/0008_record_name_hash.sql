@@ -0,0 +1 @@
+-- sqlite的唯一键已包含完整的name，只保持版本号与其他数据库一致
@@ -12,13 +12,22 @@ const TableNameDingospeed = "dingospeed"
 
 // Dingospeed mapped from table <dingospeed>
 type Dingospeed struct {
-	ID         int32     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
-	InstanceID string    `gorm:"column:instance_id;not null" json:"instance_id"`
-	Host       string    `gorm:"column:host;not null" json:"host"`
-	Port       int32     `gorm:"column:port;not null" json:"port"`
-	CreatedAt  time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt  time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
-	Online     bool      `gorm:"column:online;not null;comment:是否在线" json:"online"` // 是否在线
+	ID                int32     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	InstanceID        string    `gorm:"column:instance_id;not null" json:"instance_id"`
+	Host              string    `gorm:"column:host;not null" json:"host"`
+	Port              int32     `gorm:"column:port;not null" json:"port"`
+	CreatedAt         time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt         time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	Online            bool      `gorm:"column:online;not null;comment:是否在线" json:"online"`                                         // 是否在线
+	ActiveUploads     int32     `gorm:"column:active_uploads;not null;default:0;comment:正在对外提供下载的连接数" json:"active_uploads"`       // 正在对外提供下载的连接数
+	OutboundBandwidth int64     `gorm:"column:outbound_bandwidth;not null;default:0;comment:出口带宽（字节/秒）" json:"outbound_bandwidth"` // 出口带宽（字节/秒）
+	FreeDisk          int64     `gorm:"column:free_disk;not null;default:0;comment:剩余磁盘空间（字节）" json:"free_disk"`                   // 剩余磁盘空间（字节）
+	QueuedCacheJobs   int32     `gorm:"column:queued_cache_jobs;not null;default:0;comment:排队中的缓存任务数" json:"queued_cache_jobs"`    // 排队中的缓存任务数
+	MaxUploads        int32     `gorm:"column:max_uploads;not null;default:0;comment:对外下载连接数上限" json:"max_uploads"`                // 对外下载连接数上限
+	BandwidthLimit    int64     `gorm:"column:bandwidth_limit;not null;default:0;comment:出口带宽上限（字节/秒）" json:"bandwidth_limit"`     // 出口带宽上限（字节/秒）
+	Version           string    `gorm:"column:version;not null;default:''" json:"version"`
+	Aidc              string    `gorm:"column:aidc;not null;default:'';comment:所属aidc" json:"aidc"`       // 所属aidc
+	Status            int32     `gorm:"column:status;not null;default:1;comment:1存活，2心跳超时" json:"status"` // 1存活，2心跳超时
 }
 
 // TableName Dingospeed's table name
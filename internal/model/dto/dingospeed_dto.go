@@ -0,0 +1,21 @@
+package dto
+
+type Dingospeed struct {
+	ID                int32   `json:"id"`
+	InstanceID        string  `json:"instanceId"`
+	Host              string  `json:"host"`
+	Port              int32   `json:"port"`
+	Online            bool    `json:"online"`
+	Aidc              string  `json:"aidc"`
+	Status            int32   `json:"status"`
+	ActiveUploads     int32   `json:"activeUploads"`
+	OutboundBandwidth int64   `json:"outboundBandwidth"`
+	FreeDisk          int64   `json:"freeDisk"`
+	QueuedCacheJobs   int32   `json:"queuedCacheJobs"`
+	MaxUploads        int32   `json:"maxUploads"`
+	BandwidthLimit    int64   `json:"bandwidthLimit"`
+	Version           string  `json:"version"`
+	Load              float64 `json:"load"`
+	Saturated         bool    `json:"saturated"`
+	UpdatedAt         int64   `json:"updatedAt"`
+}
@@ -1,13 +1,78 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"dingoscheduler/internal/model"
+	"dingoscheduler/pkg/common"
+
+	"go.uber.org/zap"
+)
 
 type ModelFileProcessDto struct {
 	ID         int64     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
 	RecordID   int64     `gorm:"column:record_id;not null" json:"record_id"`
 	InstanceID string    `gorm:"column:instance_id;not null" json:"instance_id"`
 	OffsetNum  int64     `gorm:"column:offset_num;not null" json:"offset_num"`
+	Ranges     string    `gorm:"column:ranges" json:"ranges"`
 	Host       string    `gorm:"column:host;not null" json:"host"`
 	Port       int32     `gorm:"column:port;not null" json:"port"`
 	UpdatedAt  time.Time `gorm:"column:updated_at" json:"updated_at"`
+	Load       float64   `gorm:"-" json:"load"`
+}
+
+// RangeSet 节点已完成的区间，未记录非连续区间或无法解析时由offset_num推出[0, offset_num)
+func (p *ModelFileProcessDto) RangeSet() common.RangeSet {
+	rs, err := common.ParseRangeSet(p.Ranges)
+	if err != nil {
+		zap.S().Errorf("process %d ranges err.%v", p.ID, err)
+	}
+	if err != nil || len(rs) == 0 {
+		return common.RangeSet{}.Add(0, p.OffsetNum)
+	}
+	return rs
+}
+
+// HasRange 节点是否持有[start, end)的完整数据
+func (p *ModelFileProcessDto) HasRange(start, end int64) bool {
+	return p.RangeSet().Contains(start, end)
+}
+
+// AvailableFrom 节点从pos开始可连续提供数据的结束位置
+func (p *ModelFileProcessDto) AvailableFrom(pos int64) int64 {
+	return p.RangeSet().ContiguousFrom(pos)
+}
+
+// ProcessReport 合并后待写入数据库的进度上报
+type ProcessReport struct {
+	Ranges   common.RangeSet
+	Status   int32
+	Attempts int // 写入数据库失败的次数
+}
+
+// InstanceFileDto 节点的下载进度及所属文件
+type InstanceFileDto struct {
+	ProcessID int64     `gorm:"column:process_id"`
+	RecordID  int64     `gorm:"column:record_id"`
+	Datatype  string    `gorm:"column:datatype"`
+	Org       string    `gorm:"column:org"`
+	Repo      string    `gorm:"column:repo"`
+	Name      string    `gorm:"column:name"`
+	Etag      string    `gorm:"column:etag"`
+	FileSize  int64     `gorm:"column:file_size"`
+	OffsetNum int64     `gorm:"column:offset_num"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+// ProcessSync 一批离线同步的进度，Reports为已有进度的上报，Processes为需要新建的进度
+type ProcessSync struct {
+	Reports   map[int64]*ProcessReport
+	Processes []*NewProcess
+}
+
+// NewProcess 待新建的进度，Record不为空时同时新建文件记录，多个进度可共用同一个Record
+type NewProcess struct {
+	Record         *model.ModelFileRecord
+	Process        *model.ModelFileProcess
+	RecordInserted bool // Record由本批次新建，为false时记录已存在，可能已有其他节点的进度
 }
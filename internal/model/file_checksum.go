@@ -0,0 +1,32 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package model
+
+const TableNameFileChecksum = "file_checksum"
+
+// FileChecksum 节点上报的区间摘要，Verified为所属进度已整体校验通过，此时作为相同etag的比对基准
+type FileChecksum struct {
+	ID        int64  `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	ProcessID int64  `gorm:"column:process_id;not null" json:"processId"`
+	Etag      string `gorm:"column:etag;not null" json:"etag"`
+	StartPos  int64  `gorm:"column:start_pos;not null" json:"startPos"`
+	EndPos    int64  `gorm:"column:end_pos;not null" json:"endPos"`
+	Sha256    string `gorm:"column:sha256;not null" json:"sha256"`
+	Verified  bool   `gorm:"column:verified;not null;default:0" json:"verified"`
+}
+
+func (*FileChecksum) TableName() string {
+	return TableNameFileChecksum
+}
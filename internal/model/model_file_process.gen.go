@@ -18,6 +18,8 @@ type ModelFileProcess struct {
 	OffsetNum        int64     `gorm:"column:offset_num;not null" json:"offset_num"`
 	Status           int32     `gorm:"column:status;not null;comment:下载状态：1(正在下载)，2（下载中断），3（下载完成）" json:"status"` // 下载状态：1(正在下载)，2（下载中断），3（下载完成）
 	MasterInstanceID string    `gorm:"column:master_instance_id" json:"master_instance_id"`
+	Ranges           string    `gorm:"column:ranges;not null;comment:已完成的字节区间，非连续下载时记录" json:"ranges"`                          // 已完成的字节区间，非连续下载时记录
+	Integrity        int32     `gorm:"column:integrity;not null;default:0;comment:完整性：0(未校验)，1（校验通过），2（数据损坏）" json:"integrity"` // 完整性：0(未校验)，1（校验通过），2（数据损坏）
 	CreatedAt        time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt        time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
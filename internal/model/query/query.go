@@ -111,6 +111,7 @@ type ModelQuery struct {
 	Other             string
 	Datatype          string `json:"datatype"`
 	Status            string `json:"status"`
+	Incomplete        string `json:"incomplete"` // true只列出缺少文件的仓库，false只列出完整的仓库
 }
 
 type RepositoryReq struct {
@@ -118,6 +119,13 @@ type RepositoryReq struct {
 	Token string `json:"token"`
 }
 
+type EvictRepoReq struct {
+	AidcCode string `json:"aidcCode"`
+	Datatype string `json:"datatype"`
+	Org      string `json:"org"`
+	Repo     string `json:"repo"`
+}
+
 type WaitTaskReq struct {
 	InstanceId string `json:"instanceId"`
 	Ids        []int  `json:"ids"`
@@ -0,0 +1,31 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package model
+
+import "time"
+
+const TableNameSchedulerEvent = "scheduler_event"
+
+// SchedulerEvent 多副本部署时广播给各副本的事件，Payload为事件的JSON
+type SchedulerEvent struct {
+	ID        int64     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	Topic     string    `gorm:"column:topic;not null" json:"topic"`
+	Payload   string    `gorm:"column:payload;not null" json:"payload"`
+	CreatedAt time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (*SchedulerEvent) TableName() string {
+	return TableNameSchedulerEvent
+}
@@ -0,0 +1,28 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package model
+
+const TableNameSchedulerLease = "scheduler_lease"
+
+// SchedulerLease 选主及分布式锁的租约，ExpiresAt为unix毫秒时间戳
+type SchedulerLease struct {
+	Name      string `gorm:"column:name;primaryKey" json:"name"`
+	Holder    string `gorm:"column:holder;not null" json:"holder"`
+	ExpiresAt int64  `gorm:"column:expires_at;not null" json:"expiresAt"`
+}
+
+func (*SchedulerLease) TableName() string {
+	return TableNameSchedulerLease
+}
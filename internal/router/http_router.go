@@ -29,10 +29,12 @@ type HttpRouter struct {
 	repositoryHandler *handler.RepositoryHandler
 	tagHandler        *handler.TagHandler
 	cacheJobHandler   *handler.CacheJobHandler
+	dingospeedHandler *handler.DingospeedHandler
 }
 
 func NewHttpRouter(echo *echo.Echo, managerHandler *handler.ManagerHandler, sysHandler *handler.SysHandler,
-	repositoryHandler *handler.RepositoryHandler, tagHandler *handler.TagHandler, cacheJobHandler *handler.CacheJobHandler) *HttpRouter {
+	repositoryHandler *handler.RepositoryHandler, tagHandler *handler.TagHandler, cacheJobHandler *handler.CacheJobHandler,
+	dingospeedHandler *handler.DingospeedHandler) *HttpRouter {
 	r := &HttpRouter{
 		echo:              echo,
 		sysHandler:        sysHandler,
@@ -40,6 +42,7 @@ func NewHttpRouter(echo *echo.Echo, managerHandler *handler.ManagerHandler, sysH
 		repositoryHandler: repositoryHandler,
 		tagHandler:        tagHandler,
 		cacheJobHandler:   cacheJobHandler,
+		dingospeedHandler: dingospeedHandler,
 	}
 	r.initRouter()
 	return r
@@ -60,6 +63,7 @@ func (r *HttpRouter) initRouter() {
 	r.echo.POST("/api/execWaitTask", r.managerHandler.ExecWaitTaskHandler) // 执行等待中的缓存下载任务和挂载模型任务
 	r.repositoryRouter()                                                   // repository接口
 	r.cacheJobRouter()                                                     // 模型缓存
+	r.dingospeedRouter()                                                   // dingospeed节点
 }
 
 func (r *HttpRouter) repositoryRouter() {
@@ -69,6 +73,7 @@ func (r *HttpRouter) repositoryRouter() {
 	r.echo.GET("/api/v1/repository/files/:aidcCode/:id/", r.repositoryHandler.RepositoryFilesHandler)          // 仓库文件目录
 	r.echo.GET("/api/v1/repository/files/:aidcCode/:id/:filePath", r.repositoryHandler.RepositoryFilesHandler) // 仓库文件目录
 	r.echo.POST("/api/v1/repositories/mount", r.repositoryHandler.MountRepositoryHandler)                      // 挂载缓存（公共目录）
+	r.echo.POST("/api/v1/repositories/evict", r.repositoryHandler.EvictRepositoryHandler)                      // 清除dingospeed本地缓存
 
 	r.echo.GET("/api/v1/tags", r.tagHandler.TagHandler)
 	r.echo.GET("/api/v1/task_tags", r.tagHandler.TaskTagHandler)
@@ -82,3 +87,7 @@ func (r *HttpRouter) cacheJobRouter() {
 	r.echo.POST("/api/v1/cacheJob/resume", r.cacheJobHandler.ResumeCacheJobHandler)
 	r.echo.DELETE("/api/v1/cacheJob/:id", r.cacheJobHandler.DeleteCacheJobHandler)
 }
+
+func (r *HttpRouter) dingospeedRouter() {
+	r.echo.GET("/api/v1/dingospeeds", r.dingospeedHandler.ListDingospeedHandler) // 节点列表及负载
+}
@@ -0,0 +1,46 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package selector
+
+import (
+	"dingoscheduler/internal/model"
+	"dingoscheduler/pkg/config"
+)
+
+// Load 节点负载，取连接数与出口带宽两者占用比例的较大值，>=1表示已满载
+func Load(speed *model.Dingospeed) float64 {
+	maxUploads := speed.MaxUploads
+	if maxUploads <= 0 {
+		maxUploads = config.SysConfig.GetDefaultMaxUploads()
+	}
+	load := float64(speed.ActiveUploads) / float64(maxUploads)
+	if speed.BandwidthLimit > 0 {
+		load = max(load, float64(speed.OutboundBandwidth)/float64(speed.BandwidthLimit))
+	}
+	return load
+}
+
+// Saturated 负载达到饱和阈值后，不再作为新下载请求的master
+func Saturated(speed *model.Dingospeed) bool {
+	return Load(speed) >= config.SysConfig.GetSaturation()
+}
+
+// Aidc 节点所属的aidc，注册时未上报则按aidc配置反查
+func Aidc(speed *model.Dingospeed) string {
+	if speed.Aidc != "" {
+		return speed.Aidc
+	}
+	return config.SysConfig.GetInstanceAidc(speed.InstanceID)
+}
@@ -0,0 +1,60 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package selector
+
+import (
+	"testing"
+
+	"dingoscheduler/internal/model"
+	"dingoscheduler/pkg/config"
+)
+
+func newTestConfig() {
+	config.SysConfig = &config.Config{}
+	config.SysConfig.SetDefaults()
+}
+
+func TestLoad(t *testing.T) {
+	newTestConfig()
+	tests := []struct {
+		name      string
+		speed     *model.Dingospeed
+		load      float64
+		saturated bool
+	}{
+		{name: "idle", speed: &model.Dingospeed{MaxUploads: 10}, load: 0},
+		{name: "uploads", speed: &model.Dingospeed{ActiveUploads: 5, MaxUploads: 10}, load: 0.5},
+		{name: "default max uploads", speed: &model.Dingospeed{ActiveUploads: 32}, load: 0.5},
+		{name: "bandwidth dominates", speed: &model.Dingospeed{ActiveUploads: 1, MaxUploads: 10, OutboundBandwidth: 95,
+			BandwidthLimit: 100}, load: 0.95, saturated: true},
+		{name: "unlimited bandwidth ignored", speed: &model.Dingospeed{ActiveUploads: 1, MaxUploads: 10, OutboundBandwidth: 1 << 30},
+			load: 0.1},
+		{name: "at saturation", speed: &model.Dingospeed{ActiveUploads: 9, MaxUploads: 10}, load: 0.9, saturated: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if load := Load(tt.speed); load < tt.load-1e-9 || load > tt.load+1e-9 {
+				t.Fatalf("load = %v, want %v", load, tt.load)
+			}
+			if saturated := Saturated(tt.speed); saturated != tt.saturated {
+				t.Fatalf("saturated = %v, want %v", saturated, tt.saturated)
+			}
+			// 饱和的节点不会被分配新的下载
+			if usable := (&Peer{Fresh: true, Load: Load(tt.speed)}).Usable(); usable == tt.saturated {
+				t.Fatalf("usable = %v with load %v", usable, Load(tt.speed))
+			}
+		})
+	}
+}
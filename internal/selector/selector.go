@@ -0,0 +1,140 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package selector
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/model/dto"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/consts"
+)
+
+// Request 发起调度的节点及其下载位置
+type Request struct {
+	InstanceID string
+	Aidc       string
+	Etag       string
+	StartPos   int64
+	FileSize   int64
+}
+
+// Peer 持有当前文件数据、可作为下载来源的节点
+type Peer struct {
+	InstanceID  string
+	Host        string
+	Port        int32
+	Aidc        string
+	Available   int64 // 从StartPos起连续持有数据的结束位置
+	HeartbeatAt time.Time
+	Fresh       bool
+	Load        float64
+	Score       float64
+	Cost        int   // 与请求方之间的链路代价，0表示同一aidc
+	Bandwidth   int64 // 与请求方之间的链路带宽(MB/s)，0表示未知
+	Process     *dto.ModelFileProcessDto
+	Source      *model.ModelFileRecord // 非空时节点持有的是相同etag的其他文件
+}
+
+// Usable 心跳有效且负载未饱和的节点才会被分配新的下载
+func (p *Peer) Usable() bool {
+	return p.Fresh && p.Load < config.SysConfig.GetSaturation()
+}
+
+// HasRange 节点是否持有[start, end)的完整数据
+func (p *Peer) HasRange(start, end int64) bool {
+	return p.Process.HasRange(start, end)
+}
+
+// PeerSelector 节点选择策略，返回按优先级排序的候选节点，排在首位的可用节点作为master。
+type PeerSelector interface {
+	Name() string
+	Select(req *Request, peers []*Peer) []*Peer
+}
+
+var selectors = map[string]PeerSelector{
+	consts.SelectorTopology:       topologySelector{},
+	consts.SelectorScore:          scoreSelector{},
+	consts.SelectorFirstFit:       firstFitSelector{},
+	consts.SelectorLeastLoaded:    leastLoadedSelector{},
+	consts.SelectorSameAidcFirst:  sameAidcFirstSelector{},
+	consts.SelectorWeightedRandom: weightedRandomSelector{},
+}
+
+// New 根据策略名称获取节点选择策略
+func New(name string) (PeerSelector, error) {
+	if s, ok := selectors[name]; ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("unknown peer selector: %s", name)
+}
+
+// Names 已注册的全部策略名称
+func Names() []string {
+	names := make([]string, 0, len(selectors))
+	for name := range selectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ForAidc 获取发起方所在aidc配置的策略，未单独配置时使用全局策略
+func ForAidc(aidc string) PeerSelector {
+	name := config.SysConfig.GetSelectorStrategy(aidc)
+	if s, err := New(name); err == nil {
+		return s
+	}
+	return topologySelector{}
+}
+
+// Score 以可提供的数据占剩余待下载部分的比例为主，心跳越新、负载越低得分越高，取值[0,1]。
+func Score(req *Request, peer *Peer, lease time.Duration, now time.Time) float64 {
+	coverage := 1.0
+	if remain := req.FileSize - req.StartPos; remain > 0 {
+		coverage = math.Min(float64(peer.Available-req.StartPos)/float64(remain), 1)
+	}
+	age := math.Max(float64(now.Sub(peer.HeartbeatAt)), 0)
+	freshness := 1 - math.Min(age/float64(lease), 1)
+	idle := 1 - math.Min(peer.Load, 1)
+	return 0.6*coverage + 0.2*freshness + 0.2*idle
+}
+
+// sortPeers 可用节点总是排在前面，其余按less排序
+func sortPeers(peers []*Peer, less func(a, b *Peer) bool) []*Peer {
+	sorted := make([]*Peer, len(peers))
+	copy(sorted, peers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Usable() != sorted[j].Usable() {
+			return sorted[i].Usable()
+		}
+		if sorted[i].Fresh != sorted[j].Fresh {
+			return sorted[i].Fresh
+		}
+		return less(sorted[i], sorted[j])
+	})
+	return sorted
+}
+
+func byScore(a, b *Peer) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	return a.Available > b.Available
+}
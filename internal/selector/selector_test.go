@@ -0,0 +1,209 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package selector
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"dingoscheduler/internal/model"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/consts"
+)
+
+func peerIds(peers []*Peer) []string {
+	ids := make([]string, 0, len(peers))
+	for _, p := range peers {
+		ids = append(ids, p.InstanceID)
+	}
+	return ids
+}
+
+func TestSelect(t *testing.T) {
+	newTestConfig()
+	// a: 同aidc、代价0但负载较高；b: 跨aidc得分最高；c: 同区域、负载最低；d: 已饱和；e: 心跳过期
+	newPeers := func() []*Peer {
+		return []*Peer{
+			{InstanceID: "a", Aidc: "local", Fresh: true, Load: 0.6, Score: 0.5, Cost: 0, Available: 100},
+			{InstanceID: "b", Aidc: "remote", Fresh: true, Load: 0.3, Score: 0.9, Cost: 100, Bandwidth: 10, Available: 300},
+			{InstanceID: "c", Aidc: "near", Fresh: true, Load: 0.1, Score: 0.7, Cost: 10, Bandwidth: 20, Available: 200},
+			{InstanceID: "d", Aidc: "local", Fresh: true, Load: 0.95, Score: 0.95, Cost: 0, Available: 400},
+			{InstanceID: "e", Aidc: "local", Fresh: false, Load: 0, Score: 1, Cost: 0, Available: 500},
+		}
+	}
+	req := &Request{InstanceID: "req", Aidc: "local"}
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{name: consts.SelectorTopology, want: []string{"a", "c", "b", "d", "e"}},
+		{name: consts.SelectorScore, want: []string{"b", "c", "a", "d", "e"}},
+		{name: consts.SelectorFirstFit, want: []string{"a", "b", "c", "d", "e"}},
+		{name: consts.SelectorLeastLoaded, want: []string{"c", "b", "a", "d", "e"}},
+		{name: consts.SelectorSameAidcFirst, want: []string{"a", "b", "c", "d", "e"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(tt.name)
+			if err != nil {
+				t.Fatalf("new selector: %v", err)
+			}
+			if s.Name() != tt.name {
+				t.Fatalf("name = %s, want %s", s.Name(), tt.name)
+			}
+			peers := newPeers()
+			if got := peerIds(s.Select(req, peers)); !slices.Equal(got, tt.want) {
+				t.Fatalf("order = %v, want %v", got, tt.want)
+			}
+			// 排序不修改传入的切片
+			if got := peerIds(peers); !slices.Equal(got, []string{"a", "b", "c", "d", "e"}) {
+				t.Fatalf("input reordered: %v", got)
+			}
+		})
+	}
+}
+
+func TestSelectSameAidcFirstWithoutAidc(t *testing.T) {
+	newTestConfig()
+	peers := []*Peer{
+		{InstanceID: "a", Fresh: true, Score: 0.2},
+		{InstanceID: "b", Fresh: true, Score: 0.8},
+	}
+	// 发起方aidc未知时退化为按得分排序
+	got := peerIds(sameAidcFirstSelector{}.Select(&Request{}, peers))
+	if !slices.Equal(got, []string{"b", "a"}) {
+		t.Fatalf("order = %v", got)
+	}
+}
+
+func TestSelectWeightedRandom(t *testing.T) {
+	newTestConfig()
+	peers := []*Peer{
+		{InstanceID: "low", Fresh: true, Score: 0.1},
+		{InstanceID: "high", Fresh: true, Score: 0.9},
+		{InstanceID: "stale", Fresh: false, Score: 1},
+	}
+	first := make(map[string]int)
+	for i := 0; i < 2000; i++ {
+		ranked := weightedRandomSelector{}.Select(&Request{}, peers)
+		if len(ranked) != len(peers) || ranked[len(ranked)-1].InstanceID != "stale" {
+			t.Fatalf("stale peer must rank last: %v", peerIds(ranked))
+		}
+		first[ranked[0].InstanceID]++
+	}
+	// 得分高的节点更常被排在首位，得分低的节点也有机会
+	if first["high"] <= first["low"] || first["low"] == 0 {
+		t.Fatalf("unexpected distribution: %v", first)
+	}
+}
+
+func TestNew(t *testing.T) {
+	newTestConfig()
+	names := Names()
+	want := []string{consts.SelectorFirstFit, consts.SelectorLeastLoaded, consts.SelectorSameAidcFirst,
+		consts.SelectorScore, consts.SelectorTopology, consts.SelectorWeightedRandom}
+	if !slices.Equal(names, want) {
+		t.Fatalf("names = %v, want %v", names, want)
+	}
+	for _, name := range names {
+		if s, err := New(name); err != nil || s.Name() != name {
+			t.Fatalf("new %s: %v", name, err)
+		}
+	}
+	if _, err := New("unknown"); err == nil {
+		t.Fatal("expected error for unknown selector")
+	}
+}
+
+func TestForAidc(t *testing.T) {
+	newTestConfig()
+	config.SysConfig.Scheduler.Selector.Strategy = consts.SelectorScore
+	config.SysConfig.Scheduler.Selector.AidcStrategies = map[string]string{
+		"local": consts.SelectorSameAidcFirst,
+		"bad":   "unknown",
+	}
+	tests := []struct {
+		aidc string
+		want string
+	}{
+		{aidc: "local", want: consts.SelectorSameAidcFirst},
+		{aidc: "other", want: consts.SelectorScore},
+		{aidc: "", want: consts.SelectorScore},
+		{aidc: "bad", want: consts.SelectorTopology},
+	}
+	for _, tt := range tests {
+		if got := ForAidc(tt.aidc).Name(); got != tt.want {
+			t.Fatalf("ForAidc(%q) = %s, want %s", tt.aidc, got, tt.want)
+		}
+	}
+	config.SysConfig.Scheduler.Selector.Strategy = ""
+	if got := ForAidc("other").Name(); got != consts.SelectorTopology {
+		t.Fatalf("default strategy = %s", got)
+	}
+}
+
+func TestScore(t *testing.T) {
+	now := time.Now()
+	lease := time.Minute
+	req := &Request{StartPos: 100, FileSize: 1100}
+	tests := []struct {
+		name string
+		peer *Peer
+		want float64
+	}{
+		{name: "full coverage idle fresh", peer: &Peer{Available: 1100, HeartbeatAt: now}, want: 1},
+		{name: "half coverage", peer: &Peer{Available: 600, HeartbeatAt: now}, want: 0.3 + 0.2 + 0.2},
+		{name: "coverage capped", peer: &Peer{Available: 5000, HeartbeatAt: now}, want: 1},
+		{name: "half lease old", peer: &Peer{Available: 1100, HeartbeatAt: now.Add(-lease / 2)}, want: 0.6 + 0.1 + 0.2},
+		{name: "expired heartbeat", peer: &Peer{Available: 1100, HeartbeatAt: now.Add(-2 * lease)}, want: 0.6 + 0.2},
+		{name: "future heartbeat", peer: &Peer{Available: 1100, HeartbeatAt: now.Add(lease)}, want: 1},
+		{name: "half load", peer: &Peer{Available: 1100, HeartbeatAt: now, Load: 0.5}, want: 0.6 + 0.2 + 0.1},
+		{name: "overloaded", peer: &Peer{Available: 1100, HeartbeatAt: now, Load: 2}, want: 0.6 + 0.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Score(req, tt.peer, lease, now); math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("score = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	// 文件大小未知时视为完全覆盖
+	if got := Score(&Request{}, &Peer{HeartbeatAt: now}, lease, now); math.Abs(got-1) > 1e-9 {
+		t.Fatalf("score without file size = %v", got)
+	}
+}
+
+func TestAidc(t *testing.T) {
+	newTestConfig()
+	config.SysConfig.Aidc = map[string]string{"beijing": "speed-1"}
+	tests := []struct {
+		name  string
+		speed *model.Dingospeed
+		want  string
+	}{
+		{name: "reported", speed: &model.Dingospeed{InstanceID: "speed-1", Aidc: "shanghai"}, want: "shanghai"},
+		{name: "configured", speed: &model.Dingospeed{InstanceID: "speed-1"}, want: "beijing"},
+		{name: "unknown", speed: &model.Dingospeed{InstanceID: "speed-2"}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Aidc(tt.speed); got != tt.want {
+				t.Fatalf("aidc = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package selector
+
+import (
+	"math"
+	"math/rand"
+
+	"dingoscheduler/pkg/consts"
+)
+
+// topologySelector 优先选同一aidc的节点，其次是链路代价低的跨aidc节点，代价相同时按带宽、得分排序
+type topologySelector struct{}
+
+func (topologySelector) Name() string { return consts.SelectorTopology }
+
+func (topologySelector) Select(req *Request, peers []*Peer) []*Peer {
+	return sortPeers(peers, func(a, b *Peer) bool {
+		if a.Cost != b.Cost {
+			return a.Cost < b.Cost
+		}
+		if a.Bandwidth != b.Bandwidth {
+			return a.Bandwidth > b.Bandwidth
+		}
+		return byScore(a, b)
+	})
+}
+
+// scoreSelector 按综合得分排序
+type scoreSelector struct{}
+
+func (scoreSelector) Name() string { return consts.SelectorScore }
+
+func (scoreSelector) Select(req *Request, peers []*Peer) []*Peer {
+	return sortPeers(peers, byScore)
+}
+
+// firstFitSelector 保持进度从大到小的原始顺序，选第一个可用节点
+type firstFitSelector struct{}
+
+func (firstFitSelector) Name() string { return consts.SelectorFirstFit }
+
+func (firstFitSelector) Select(req *Request, peers []*Peer) []*Peer {
+	return sortPeers(peers, func(a, b *Peer) bool { return false })
+}
+
+// leastLoadedSelector 优先选负载最低的节点
+type leastLoadedSelector struct{}
+
+func (leastLoadedSelector) Name() string { return consts.SelectorLeastLoaded }
+
+func (leastLoadedSelector) Select(req *Request, peers []*Peer) []*Peer {
+	return sortPeers(peers, func(a, b *Peer) bool {
+		if a.Load != b.Load {
+			return a.Load < b.Load
+		}
+		return byScore(a, b)
+	})
+}
+
+// sameAidcFirstSelector 优先选与发起方同一aidc的节点
+type sameAidcFirstSelector struct{}
+
+func (sameAidcFirstSelector) Name() string { return consts.SelectorSameAidcFirst }
+
+func (sameAidcFirstSelector) Select(req *Request, peers []*Peer) []*Peer {
+	return sortPeers(peers, func(a, b *Peer) bool {
+		aLocal, bLocal := req.Aidc != "" && a.Aidc == req.Aidc, req.Aidc != "" && b.Aidc == req.Aidc
+		if aLocal != bLocal {
+			return aLocal
+		}
+		return byScore(a, b)
+	})
+}
+
+// weightedRandomSelector 按得分加权随机排序，避免所有请求集中到同一个得分最高的节点
+type weightedRandomSelector struct{}
+
+func (weightedRandomSelector) Name() string { return consts.SelectorWeightedRandom }
+
+func (weightedRandomSelector) Select(req *Request, peers []*Peer) []*Peer {
+	// Efraimidis-Spirakis加权无放回抽样：key = u^(1/w)，按key从大到小即为抽样顺序
+	keys := make(map[*Peer]float64, len(peers))
+	for _, p := range peers {
+		keys[p] = math.Pow(rand.Float64(), 1/(p.Score+1e-6))
+	}
+	return sortPeers(peers, func(a, b *Peer) bool { return keys[a] > keys[b] })
+}
@@ -0,0 +1,103 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package selector
+
+import (
+	"sync"
+
+	"dingoscheduler/pkg/config"
+)
+
+type linkKey struct {
+	from string
+	to   string
+}
+
+// Topology aidc所属区域及链路代价的索引
+type Topology struct {
+	aidcRegion map[string]string
+	links      map[linkKey]config.Link
+}
+
+var (
+	topology     *Topology
+	topologyOnce sync.Once
+)
+
+// GetTopology 获取根据系统配置构建的拓扑
+func GetTopology() *Topology {
+	topologyOnce.Do(func() {
+		topology = NewTopology(&config.SysConfig.Topology)
+	})
+	return topology
+}
+
+func NewTopology(conf *config.Topology) *Topology {
+	t := &Topology{
+		aidcRegion: make(map[string]string),
+		links:      make(map[linkKey]config.Link, len(conf.Links)*2),
+	}
+	for _, region := range conf.Regions {
+		for _, aidc := range region.Aidcs {
+			t.aidcRegion[aidc.Name] = region.Name
+		}
+	}
+	for _, link := range conf.Links {
+		t.links[linkKey{from: link.From, to: link.To}] = link
+		t.links[linkKey{from: link.To, to: link.From}] = link
+	}
+	return t
+}
+
+// Link 返回两个aidc之间的链路代价和带宽(MB/s)。同一aidc代价为0；
+// 优先使用aidc间的链路配置，其次是区域间的，都未配置时按是否同区域取默认代价。
+func (t *Topology) Link(from, to string) (int, int64) {
+	if from != "" && from == to {
+		return 0, 0
+	}
+	if link, ok := t.links[linkKey{from: from, to: to}]; ok {
+		return link.Cost, link.Bandwidth
+	}
+	fromRegion, toRegion := t.aidcRegion[from], t.aidcRegion[to]
+	if fromRegion == "" || toRegion == "" {
+		return config.SysConfig.GetCrossRegionCost(), 0
+	}
+	if link, ok := t.links[linkKey{from: fromRegion, to: toRegion}]; ok {
+		return link.Cost, link.Bandwidth
+	}
+	if fromRegion == toRegion {
+		return config.SysConfig.GetSameRegionCost(), 0
+	}
+	return config.SysConfig.GetCrossRegionCost(), 0
+}
+
+// ApplyTopology 按系统配置的拓扑计算各节点与请求方之间的链路代价，剔除代价高于回源的节点
+func ApplyTopology(req *Request, peers []*Peer) []*Peer {
+	return GetTopology().Apply(req, peers)
+}
+
+// Apply 计算各节点与请求方之间的链路代价，剔除代价高于回源的节点
+func (t *Topology) Apply(req *Request, peers []*Peer) []*Peer {
+	originCost := config.SysConfig.Topology.OriginCost
+	out := peers[:0]
+	for _, p := range peers {
+		p.Cost, p.Bandwidth = t.Link(req.Aidc, p.Aidc)
+		if originCost > 0 && p.Cost > originCost {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
@@ -0,0 +1,120 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package selector
+
+import (
+	"slices"
+	"testing"
+
+	"dingoscheduler/pkg/config"
+)
+
+func newTestTopology() *Topology {
+	return NewTopology(&config.Topology{
+		Regions: []config.Region{
+			{Name: "east", Aidcs: []config.TopologyAidc{{Name: "e1"}, {Name: "e2"}, {Name: "e3"}}},
+			{Name: "west", Aidcs: []config.TopologyAidc{{Name: "w1"}}},
+			{Name: "north", Aidcs: []config.TopologyAidc{{Name: "n1"}}},
+		},
+		Links: []config.Link{
+			{From: "e1", To: "e2", Cost: 5, Bandwidth: 1000},
+			{From: "east", To: "west", Cost: 200, Bandwidth: 100},
+		},
+	})
+}
+
+func TestTopologyLink(t *testing.T) {
+	newTestConfig()
+	topo := newTestTopology()
+	tests := []struct {
+		name      string
+		from, to  string
+		cost      int
+		bandwidth int64
+	}{
+		{name: "same aidc", from: "e1", to: "e1", cost: 0},
+		{name: "aidc link", from: "e1", to: "e2", cost: 5, bandwidth: 1000},
+		{name: "aidc link reversed", from: "e2", to: "e1", cost: 5, bandwidth: 1000},
+		{name: "same region default", from: "e1", to: "e3", cost: 10},
+		{name: "region link", from: "e3", to: "w1", cost: 200, bandwidth: 100},
+		{name: "region link reversed", from: "w1", to: "e2", cost: 200, bandwidth: 100},
+		{name: "cross region default", from: "e1", to: "n1", cost: 100},
+		{name: "unknown aidc", from: "e1", to: "x1", cost: 100},
+		{name: "empty aidc", from: "", to: "", cost: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cost, bandwidth := topo.Link(tt.from, tt.to)
+			if cost != tt.cost || bandwidth != tt.bandwidth {
+				t.Fatalf("link = (%d, %d), want (%d, %d)", cost, bandwidth, tt.cost, tt.bandwidth)
+			}
+		})
+	}
+}
+
+func TestTopologyApply(t *testing.T) {
+	newTestConfig()
+	topo := newTestTopology()
+	newPeers := func() []*Peer {
+		return []*Peer{
+			{InstanceID: "p-e1", Aidc: "e1"},
+			{InstanceID: "p-e2", Aidc: "e2"},
+			{InstanceID: "p-e3", Aidc: "e3"},
+			{InstanceID: "p-w1", Aidc: "w1"},
+			{InstanceID: "p-n1", Aidc: "n1"},
+		}
+	}
+	tests := []struct {
+		name       string
+		originCost int
+		want       []string
+		costs      []int
+	}{
+		{name: "unlimited", want: []string{"p-e1", "p-e2", "p-e3", "p-w1", "p-n1"}, costs: []int{0, 5, 10, 200, 100}},
+		{name: "drop costlier than origin", originCost: 100, want: []string{"p-e1", "p-e2", "p-e3", "p-n1"},
+			costs: []int{0, 5, 10, 100}},
+		{name: "only nearby", originCost: 5, want: []string{"p-e1", "p-e2"}, costs: []int{0, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.SysConfig.Topology.OriginCost = tt.originCost
+			peers := topo.Apply(&Request{Aidc: "e1"}, newPeers())
+			if got := peerIds(peers); !slices.Equal(got, tt.want) {
+				t.Fatalf("peers = %v, want %v", got, tt.want)
+			}
+			costs := make([]int, 0, len(peers))
+			for _, p := range peers {
+				costs = append(costs, p.Cost)
+			}
+			if !slices.Equal(costs, tt.costs) {
+				t.Fatalf("costs = %v, want %v", costs, tt.costs)
+			}
+		})
+	}
+}
+
+func TestTopologySelectPrefersCheapLink(t *testing.T) {
+	newTestConfig()
+	peers := newTestTopology().Apply(&Request{Aidc: "e1"}, []*Peer{
+		{InstanceID: "far", Aidc: "w1", Fresh: true, Score: 0.9},
+		{InstanceID: "near", Aidc: "e3", Fresh: true, Score: 0.5},
+		{InstanceID: "linked", Aidc: "e2", Fresh: true, Score: 0.1},
+		{InstanceID: "local", Aidc: "e1", Fresh: true, Score: 0.2},
+	})
+	got := peerIds(topologySelector{}.Select(&Request{Aidc: "e1"}, peers))
+	if want := []string{"local", "linked", "near", "far"}; !slices.Equal(got, want) {
+		t.Fatalf("order = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"dingoscheduler/internal/service"
+	"dingoscheduler/pkg/event"
+
+	"go.uber.org/zap"
+)
+
+// QuarantineAlerter 订阅节点隔离事件并发送告警。事件在执行隔离的副本上发布，每次隔离只告警一次。
+type QuarantineAlerter struct {
+	integrityService *service.IntegrityService
+	bus              *event.Bus
+	stop             chan struct{}
+	done             chan struct{}
+}
+
+func NewQuarantineAlerter(integrityService *service.IntegrityService, bus *event.Bus) *QuarantineAlerter {
+	return &QuarantineAlerter{
+		integrityService: integrityService,
+		bus:              bus,
+		stop:             make(chan struct{}),
+		done:             make(chan struct{}),
+	}
+}
+
+func (a *QuarantineAlerter) Start(ctx context.Context) error {
+	zap.S().Infof("[Alerter] quarantine alerter start.")
+	defer close(a.done)
+	quarantined, unsubscribe := a.bus.Subscribe(event.TopicPeerQuarantined, 64)
+	defer unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-a.stop:
+			return nil
+		case ev := <-quarantined:
+			if err := a.integrityService.Alert(ev.(*event.PeerQuarantined)); err != nil {
+				zap.S().Errorf("send quarantine alert err.%v", err)
+			}
+		}
+	}
+}
+
+func (a *QuarantineAlerter) Stop(ctx context.Context) error {
+	close(a.stop)
+	select {
+	case <-a.done:
+	case <-ctx.Done():
+	}
+	zap.S().Infof("[Alerter] quarantine alerter shutdown.")
+	return nil
+}
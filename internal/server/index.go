@@ -0,0 +1,65 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"dingoscheduler/internal/service"
+	"dingoscheduler/pkg/config"
+
+	"go.uber.org/zap"
+)
+
+// FileIndexLoader 启动时加载文件索引，之后定期全量刷新
+type FileIndexLoader struct {
+	fileIndex *service.FileIndex
+	stop      chan struct{}
+}
+
+func NewFileIndexLoader(fileIndex *service.FileIndex) *FileIndexLoader {
+	return &FileIndexLoader{
+		fileIndex: fileIndex,
+		stop:      make(chan struct{}),
+	}
+}
+
+func (l *FileIndexLoader) Start(ctx context.Context) error {
+	zap.S().Infof("[Index] loader start.")
+	if err := l.fileIndex.Load(); err != nil {
+		zap.S().Errorf("load file index err.%v", err)
+	}
+	ticker := time.NewTicker(config.SysConfig.GetIndexRefreshInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-l.stop:
+			return nil
+		case <-ticker.C:
+			if err := l.fileIndex.Load(); err != nil {
+				zap.S().Errorf("refresh file index err.%v", err)
+			}
+		}
+	}
+}
+
+func (l *FileIndexLoader) Stop(ctx context.Context) error {
+	zap.S().Infof("[Index] loader shutdown.")
+	close(l.stop)
+	return nil
+}
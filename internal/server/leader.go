@@ -0,0 +1,81 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"dingoscheduler/internal/service"
+	"dingoscheduler/pkg/config"
+
+	"go.uber.org/zap"
+)
+
+// LeaderElector 多副本部署时定期竞选或续约主副本
+type LeaderElector struct {
+	leaderService *service.LeaderService
+	stop          chan struct{}
+	done          chan struct{}
+}
+
+func NewLeaderElector(leaderService *service.LeaderService) *LeaderElector {
+	return &LeaderElector{
+		leaderService: leaderService,
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+}
+
+func (e *LeaderElector) Start(ctx context.Context) error {
+	defer close(e.done)
+	if !config.SysConfig.GetHAEnabled() {
+		return nil
+	}
+	zap.S().Infof("[Leader] elector start, lease %s.", config.SysConfig.GetLeaderLease())
+	e.campaign()
+	ticker := time.NewTicker(config.SysConfig.GetRenewInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-e.stop:
+			return nil
+		case <-ticker.C:
+			e.campaign()
+		}
+	}
+}
+
+func (e *LeaderElector) Stop(ctx context.Context) error {
+	zap.S().Infof("[Leader] elector shutdown.")
+	close(e.stop)
+	// 等待竞选循环退出后再让出，避免让出后又被续约
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+	}
+	if err := e.leaderService.Resign(); err != nil {
+		zap.S().Errorf("resign leader err.%v", err)
+	}
+	return nil
+}
+
+func (e *LeaderElector) campaign() {
+	if _, err := e.leaderService.Campaign(); err != nil {
+		zap.S().Errorf("leader campaign err.%v", err)
+	}
+}
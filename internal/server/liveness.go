@@ -0,0 +1,83 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"dingoscheduler/internal/service"
+	"dingoscheduler/pkg/config"
+
+	"go.uber.org/zap"
+)
+
+// integrityPurgeInterval 清理已删除进度的区间摘要及过期隔离的间隔
+const integrityPurgeInterval = time.Hour
+
+// LivenessSweeper 定期检查节点心跳，回收失效节点，并清理不再需要的区间摘要和隔离
+type LivenessSweeper struct {
+	livenessService  *service.LivenessService
+	integrityService *service.IntegrityService
+	leaderService    *service.LeaderService
+	stop             chan struct{}
+}
+
+func NewLivenessSweeper(livenessService *service.LivenessService, integrityService *service.IntegrityService,
+	leaderService *service.LeaderService) *LivenessSweeper {
+	return &LivenessSweeper{
+		livenessService:  livenessService,
+		integrityService: integrityService,
+		leaderService:    leaderService,
+		stop:             make(chan struct{}),
+	}
+}
+
+func (s *LivenessSweeper) Start(ctx context.Context) error {
+	zap.S().Infof("[Liveness] sweeper start, lease %s.", config.SysConfig.GetHeartbeatLease())
+	ticker := time.NewTicker(config.SysConfig.GetSweepInterval())
+	defer ticker.Stop()
+	purge := time.NewTicker(integrityPurgeInterval)
+	defer purge.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.stop:
+			return nil
+		case <-ticker.C:
+			// 多副本部署时只由主副本回收，避免重复通知
+			if !s.leaderService.IsLeader() {
+				continue
+			}
+			if err := s.livenessService.Sweep(); err != nil {
+				zap.S().Errorf("liveness sweep err.%v", err)
+			}
+		case <-purge.C:
+			if !s.leaderService.IsLeader() {
+				continue
+			}
+			if err := s.integrityService.Purge(); err != nil {
+				zap.S().Errorf("integrity purge err.%v", err)
+			}
+		}
+	}
+}
+
+func (s *LivenessSweeper) Stop(ctx context.Context) error {
+	zap.S().Infof("[Liveness] sweeper shutdown.")
+	close(s.stop)
+	return nil
+}
@@ -0,0 +1,106 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"dingoscheduler/internal/service"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/event"
+
+	"go.uber.org/zap"
+)
+
+// 多副本部署时轮询节点失效事件的间隔
+const eventPollInterval = time.Second
+
+// ExpiredNotifier 订阅节点失效事件，通过长连接通知下载方重新调度。
+// 多副本部署时事件由主副本写入数据库，各副本轮询后通知连接在本副本上的下载方。
+type ExpiredNotifier struct {
+	schedulerService *service.SchedulerService
+	livenessService  *service.LivenessService
+	bus              *event.Bus
+	stop             chan struct{}
+	done             chan struct{}
+}
+
+func NewExpiredNotifier(schedulerService *service.SchedulerService, livenessService *service.LivenessService, bus *event.Bus) *ExpiredNotifier {
+	return &ExpiredNotifier{
+		schedulerService: schedulerService,
+		livenessService:  livenessService,
+		bus:              bus,
+		stop:             make(chan struct{}),
+		done:             make(chan struct{}),
+	}
+}
+
+func (n *ExpiredNotifier) Start(ctx context.Context) error {
+	zap.S().Infof("[Notifier] expired notifier start.")
+	defer close(n.done)
+	expired, unsubscribe := n.bus.Subscribe(event.TopicInstanceExpired, 64)
+	defer unsubscribe()
+	var poll <-chan time.Time
+	lastId := int64(-1)
+	if config.SysConfig.GetHAEnabled() {
+		ticker := time.NewTicker(eventPollInterval)
+		defer ticker.Stop()
+		poll = ticker.C
+		lastId = n.pollExpired(lastId)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-n.stop:
+			return nil
+		case ev := <-expired:
+			n.schedulerService.NotifyMasterExpired(ev.(*event.InstanceExpired))
+		case <-poll:
+			lastId = n.pollExpired(lastId)
+		}
+	}
+}
+
+// pollExpired 通知lastId之后的节点失效事件，返回已处理的最后一个事件的id，首次轮询从最新的事件开始
+func (n *ExpiredNotifier) pollExpired(lastId int64) int64 {
+	if lastId < 0 {
+		id, err := n.livenessService.LastEventId()
+		if err != nil {
+			zap.S().Errorf("last event id err.%v", err)
+			return lastId
+		}
+		return id
+	}
+	events, lastId, err := n.livenessService.ExpiredEventsAfter(lastId)
+	if err != nil {
+		zap.S().Errorf("poll expired events err.%v", err)
+	}
+	for _, ev := range events {
+		n.schedulerService.NotifyMasterExpired(ev)
+	}
+	return lastId
+}
+
+func (n *ExpiredNotifier) Stop(ctx context.Context) error {
+	close(n.stop)
+	select {
+	case <-n.done:
+	case <-ctx.Done():
+	}
+	zap.S().Infof("[Notifier] expired notifier shutdown.")
+	return nil
+}
@@ -0,0 +1,68 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"dingoscheduler/internal/service"
+	"dingoscheduler/pkg/config"
+
+	"go.uber.org/zap"
+)
+
+// ProgressFlusher 定期将内存中合并的进度上报写入数据库，退出前写入剩余的上报
+type ProgressFlusher struct {
+	progressService *service.ProgressService
+	stop            chan struct{}
+	done            chan struct{}
+}
+
+func NewProgressFlusher(progressService *service.ProgressService) *ProgressFlusher {
+	return &ProgressFlusher{
+		progressService: progressService,
+		stop:            make(chan struct{}),
+		done:            make(chan struct{}),
+	}
+}
+
+func (f *ProgressFlusher) Start(ctx context.Context) error {
+	zap.S().Infof("[Progress] flusher start, interval %s.", config.SysConfig.GetProgressFlushInterval())
+	defer close(f.done)
+	ticker := time.NewTicker(config.SysConfig.GetProgressFlushInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-f.stop:
+			return nil
+		case <-ticker.C:
+			f.progressService.Flush()
+		}
+	}
+}
+
+func (f *ProgressFlusher) Stop(ctx context.Context) error {
+	close(f.stop)
+	select {
+	case <-f.done:
+	case <-ctx.Done():
+	}
+	f.progressService.Flush()
+	zap.S().Infof("[Progress] flusher shutdown.")
+	return nil
+}
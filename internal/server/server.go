@@ -2,4 +2,4 @@ package server
 
 import "github.com/google/wire"
 
-var ServerProvider = wire.NewSet(NewHTTPServer, NewSchedulerServer, NewEngine)
+var ServerProvider = wire.NewSet(NewHTTPServer, NewSchedulerServer, NewLivenessSweeper, NewExpiredNotifier, NewLeaderElector, NewProgressFlusher, NewFileIndexLoader, NewSessionRelayPoller, NewQuarantineAlerter, NewEngine)
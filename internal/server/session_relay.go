@@ -0,0 +1,89 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"dingoscheduler/internal/service"
+	"dingoscheduler/pkg/config"
+
+	"go.uber.org/zap"
+)
+
+// SessionRelayPoller 多副本部署时登记本副本上长连接的归属，轮询并执行其他副本转发过来的命令
+type SessionRelayPoller struct {
+	sessionRelay *service.SessionRelay
+	stop         chan struct{}
+	done         chan struct{}
+}
+
+func NewSessionRelayPoller(sessionRelay *service.SessionRelay) *SessionRelayPoller {
+	return &SessionRelayPoller{
+		sessionRelay: sessionRelay,
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+}
+
+func (p *SessionRelayPoller) Start(ctx context.Context) error {
+	defer close(p.done)
+	if !config.SysConfig.GetHAEnabled() {
+		return nil
+	}
+	zap.S().Infof("[Relay] session relay start.")
+	poll := time.NewTicker(service.RelayPollInterval)
+	defer poll.Stop()
+	renew := time.NewTicker(config.SysConfig.GetRenewInterval())
+	defer renew.Stop()
+	lastId, err := p.sessionRelay.LastEventId()
+	if err != nil {
+		zap.S().Errorf("last event id err.%v", err)
+		lastId = -1
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-p.stop:
+			return nil
+		case <-renew.C:
+			p.sessionRelay.Renew()
+		case <-poll.C:
+			if lastId < 0 {
+				if lastId, err = p.sessionRelay.LastEventId(); err != nil {
+					zap.S().Errorf("last event id err.%v", err)
+					lastId = -1
+				}
+				continue
+			}
+			if lastId, err = p.sessionRelay.Poll(lastId); err != nil {
+				zap.S().Errorf("poll relayed commands err.%v", err)
+			}
+		}
+	}
+}
+
+func (p *SessionRelayPoller) Stop(ctx context.Context) error {
+	close(p.stop)
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+	}
+	p.sessionRelay.ReleaseAll()
+	zap.S().Infof("[Relay] session relay shutdown.")
+	return nil
+}
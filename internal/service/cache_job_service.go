@@ -15,14 +15,18 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"dingoscheduler/internal/dao"
 	"dingoscheduler/internal/model/dto"
 	"dingoscheduler/internal/model/query"
+	"dingoscheduler/internal/session"
 	"dingoscheduler/pkg/common"
 	"dingoscheduler/pkg/consts"
 	myerr "dingoscheduler/pkg/error"
+	pb "dingoscheduler/pkg/proto/manager"
 	"dingoscheduler/pkg/util"
 
 	"github.com/bytedance/sonic"
@@ -31,21 +35,25 @@ import (
 )
 
 type CacheJobService struct {
-	dingospeedDao       *dao.DingospeedDao
-	modelFileProcessDao *dao.ModelFileProcessDao
-	cacheJobDao         *dao.CacheJobDao
-	hfTokenDao          *dao.HfTokenDao
+	dingospeedDao       dao.DingospeedStore
+	modelFileProcessDao dao.ProcessStore
+	cacheJobDao         dao.CacheJobStore
+	hfTokenDao          dao.HfTokenStore
 	lockDao             *dao.LockDao
+	leaseLocker         *dao.LeaseLocker
+	sessionRelay        *SessionRelay
 }
 
-func NewCacheJobService(dingospeedDao *dao.DingospeedDao, modelFileProcessDao *dao.ModelFileProcessDao,
-	cacheJobDao *dao.CacheJobDao, hfTokenDao *dao.HfTokenDao, lockDao *dao.LockDao) *CacheJobService {
+func NewCacheJobService(dingospeedDao dao.DingospeedStore, modelFileProcessDao dao.ProcessStore,
+	cacheJobDao dao.CacheJobStore, hfTokenDao dao.HfTokenStore, lockDao *dao.LockDao, leaseLocker *dao.LeaseLocker, sessionRelay *SessionRelay) *CacheJobService {
 	return &CacheJobService{
 		dingospeedDao:       dingospeedDao,
 		cacheJobDao:         cacheJobDao,
 		modelFileProcessDao: modelFileProcessDao,
 		hfTokenDao:          hfTokenDao,
 		lockDao:             lockDao,
+		leaseLocker:         leaseLocker,
+		sessionRelay:        sessionRelay,
 	}
 }
 
@@ -117,11 +125,31 @@ func (c *CacheJobService) getJobRealtimeStatus(jobIds []int64, instanceId string
 	return m, nil
 }
 
+// lock 获取缓存任务的锁，多副本部署时还需取得分布式锁，返回租约丢失时被取消的ctx及释放函数
+func (c *CacheJobService) lock(key string) (context.Context, func(), error) {
+	ctx := context.Background()
+	unlock, err := c.lockDao.LockCacheJob(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	leaseCtx, unlockLease, err := c.leaseLocker.Lock(ctx, dao.GetCacheJobOrgRepoKey(key))
+	if err != nil {
+		unlock()
+		return nil, nil, err
+	}
+	return leaseCtx, func() {
+		unlockLease()
+		unlock()
+	}, nil
+}
+
 func (c *CacheJobService) CreateCacheJob(createCacheJobReq *query.CreateCacheJobReq) (*common.Response, error) {
 	zap.S().Debugf("Cache instanceId:%s, %s/%s", createCacheJobReq.InstanceId, createCacheJobReq.Org, createCacheJobReq.Repo)
-	lock := c.lockDao.GetCacheJobReqLock(createCacheJobReq.OrgRepo)
-	lock.Lock()
-	defer lock.Unlock()
+	ctx, unlock, err := c.lock(createCacheJobReq.OrgRepo)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 	cacheJob, err := c.cacheJobDao.GetCacheJob(&query.CacheJobQuery{InstanceId: createCacheJobReq.InstanceId, Type: createCacheJobReq.Type,
 		Org: createCacheJobReq.Org, Repo: createCacheJobReq.Repo, Datatype: createCacheJobReq.Datatype})
 	if err != nil {
@@ -142,13 +170,18 @@ func (c *CacheJobService) CreateCacheJob(createCacheJobReq *query.CreateCacheJob
 	if err != nil {
 		return nil, err
 	}
+	if err = context.Cause(ctx); err != nil {
+		return nil, err
+	}
 	return util.PostForDomain(speedDomain, "/api/cacheJob/create", "application/json", b, c.hfTokenDao.GetHeaders())
 }
 
 func (c *CacheJobService) StopCacheJob(jobStatusReq *query.JobStatusReq) error {
-	lock := c.lockDao.GetCacheJobReqLock(util.Itoa(jobStatusReq.Id))
-	lock.Lock()
-	defer lock.Unlock()
+	ctx, unlock, err := c.lock(util.Itoa(jobStatusReq.Id))
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	cacheJob, err := c.cacheJobDao.GetCacheJob(&query.CacheJobQuery{Id: jobStatusReq.Id})
 	if err != nil {
 		return err
@@ -166,10 +199,23 @@ func (c *CacheJobService) StopCacheJob(jobStatusReq *query.JobStatusReq) error {
 	if entity == nil {
 		return myerr.New("该区域dingspeed未注册。")
 	}
+	if err = context.Cause(ctx); err != nil {
+		return err
+	}
 	err = c.cacheJobDao.UpdateCacheStatus(&query.UpdateJobStatusReq{Id: jobStatusReq.Id, Status: consts.RunningStatusJobStopping})
 	if err != nil {
 		return err
 	}
+	// 优先通过长连接下发，dingospeed未建立连接时使用http
+	err = c.sessionRelay.Call(jobStatusReq.InstanceId, &pb.SessionCommand{
+		Body: &pb.SessionCommand_StopCacheJob{StopCacheJob: &pb.CacheJobCommand{
+			Id:         jobStatusReq.Id,
+			InstanceId: jobStatusReq.InstanceId,
+		}},
+	})
+	if !errors.Is(err, session.ErrNoSession) {
+		return err
+	}
 	speedDomain := fmt.Sprintf("http://%s:%d", entity.Host, entity.Port)
 	b, err := sonic.Marshal(jobStatusReq)
 	if err != nil {
@@ -183,9 +229,11 @@ func (c *CacheJobService) StopCacheJob(jobStatusReq *query.JobStatusReq) error {
 }
 
 func (c *CacheJobService) ResumeCacheJob(resumeCacheJobReq *query.ResumeCacheJobReq) error {
-	lock := c.lockDao.GetCacheJobReqLock(util.Itoa(resumeCacheJobReq.Id))
-	lock.Lock()
-	defer lock.Unlock()
+	ctx, unlock, err := c.lock(util.Itoa(resumeCacheJobReq.Id))
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	cacheJob, err := c.cacheJobDao.GetCacheJob(&query.CacheJobQuery{Id: resumeCacheJobReq.Id})
 	if err != nil {
 		return err
@@ -205,6 +253,23 @@ func (c *CacheJobService) ResumeCacheJob(resumeCacheJobReq *query.ResumeCacheJob
 	if entity == nil {
 		return myerr.New("该区域dingspeed未注册。")
 	}
+	if err = context.Cause(ctx); err != nil {
+		return err
+	}
+	err = c.sessionRelay.Call(resumeCacheJobReq.InstanceId, &pb.SessionCommand{
+		Body: &pb.SessionCommand_ResumeCacheJob{ResumeCacheJob: &pb.CacheJobCommand{
+			Id:          resumeCacheJobReq.Id,
+			Type:        cacheJob.Type,
+			InstanceId:  cacheJob.InstanceId,
+			Datatype:    cacheJob.Datatype,
+			Org:         cacheJob.Org,
+			Repo:        cacheJob.Repo,
+			UsedStorage: cacheJob.UsedStorage,
+		}},
+	})
+	if !errors.Is(err, session.ErrNoSession) {
+		return err
+	}
 	speedDomain := fmt.Sprintf("http://%s:%d", entity.Host, entity.Port)
 	resumeReq := &query.ResumeCacheJobReq{
 		Id:          resumeCacheJobReq.Id,
@@ -227,9 +292,11 @@ func (c *CacheJobService) ResumeCacheJob(resumeCacheJobReq *query.ResumeCacheJob
 }
 
 func (c *CacheJobService) DeleteCacheJob(id int64) error {
-	lock := c.lockDao.GetCacheJobReqLock(util.Itoa(id))
-	lock.Lock()
-	defer lock.Unlock()
+	ctx, unlock, err := c.lock(util.Itoa(id))
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	cacheJob, err := c.cacheJobDao.GetCacheJob(&query.CacheJobQuery{Id: id})
 	if err != nil {
 		return err
@@ -240,5 +307,8 @@ func (c *CacheJobService) DeleteCacheJob(id int64) error {
 	if cacheJob.Status == consts.RunningStatusJobIng || cacheJob.Status == consts.RunningStatusJobComplete {
 		return myerr.New(fmt.Sprintf("当前缓存任务不能删除。"))
 	}
+	if err = context.Cause(ctx); err != nil {
+		return err
+	}
 	return c.cacheJobDao.Delete(id)
 }
@@ -0,0 +1,68 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package service
+
+import (
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/model/dto"
+	"dingoscheduler/internal/selector"
+	"dingoscheduler/pkg/util"
+)
+
+type DingospeedService struct {
+	dingospeedDao dao.DingospeedStore
+}
+
+func NewDingospeedService(dingospeedDao dao.DingospeedStore) *DingospeedService {
+	return &DingospeedService{
+		dingospeedDao: dingospeedDao,
+	}
+}
+
+// ListDingospeed 已注册节点及其最近一次心跳上报的负载
+func (s *DingospeedService) ListDingospeed() ([]*dto.Dingospeed, error) {
+	speeds, err := s.dingospeedDao.List()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*dto.Dingospeed, 0, len(speeds))
+	for _, speed := range speeds {
+		result = append(result, toDingospeedDto(speed))
+	}
+	return result, nil
+}
+
+func toDingospeedDto(speed *model.Dingospeed) *dto.Dingospeed {
+	return &dto.Dingospeed{
+		ID:                speed.ID,
+		InstanceID:        speed.InstanceID,
+		Host:              speed.Host,
+		Port:              speed.Port,
+		Online:            speed.Online,
+		Aidc:              selector.Aidc(speed),
+		Status:            speed.Status,
+		ActiveUploads:     speed.ActiveUploads,
+		OutboundBandwidth: speed.OutboundBandwidth,
+		FreeDisk:          speed.FreeDisk,
+		QueuedCacheJobs:   speed.QueuedCacheJobs,
+		MaxUploads:        speed.MaxUploads,
+		BandwidthLimit:    speed.BandwidthLimit,
+		Version:           speed.Version,
+		Load:              selector.Load(speed),
+		Saturated:         selector.Saturated(speed),
+		UpdatedAt:         util.TimeToUnix(speed.UpdatedAt),
+	}
+}
@@ -0,0 +1,296 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package service
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/model/dto"
+	"dingoscheduler/internal/model/query"
+
+	"go.uber.org/zap"
+)
+
+const indexScanSize = 10000
+
+type fileKey struct {
+	datatype string
+	org      string
+	repo     string
+	name     string
+	etag     string
+}
+
+type indexData struct {
+	records       map[fileKey]*model.ModelFileRecord
+	etags         map[string]map[int64]*model.ModelFileRecord  // etag -> recordId -> record
+	processes     map[int64]map[int64]*dto.ModelFileProcessDto // recordId -> processId -> process
+	processRecord map[int64]int64                              // processId -> recordId
+}
+
+func newIndexData() *indexData {
+	return &indexData{
+		records:       make(map[fileKey]*model.ModelFileRecord),
+		etags:         make(map[string]map[int64]*model.ModelFileRecord),
+		processes:     make(map[int64]map[int64]*dto.ModelFileProcessDto),
+		processRecord: make(map[int64]int64),
+	}
+}
+
+func (d *indexData) putRecord(record *model.ModelFileRecord) {
+	d.records[fileKey{record.Datatype, record.Org, record.Repo, record.Name, record.Etag}] = record
+	if record.Etag != "" {
+		if _, ok := d.etags[record.Etag]; !ok {
+			d.etags[record.Etag] = make(map[int64]*model.ModelFileRecord)
+		}
+		d.etags[record.Etag][record.ID] = record
+	}
+	if _, ok := d.processes[record.ID]; !ok {
+		d.processes[record.ID] = make(map[int64]*dto.ModelFileProcessDto)
+	}
+}
+
+func (d *indexData) putProcess(process *dto.ModelFileProcessDto) {
+	processes, ok := d.processes[process.RecordID]
+	if !ok {
+		// 所属记录不在索引中，查询时会回源数据库
+		return
+	}
+	processes[process.ID] = process
+	d.processRecord[process.ID] = process.RecordID
+}
+
+func (d *indexData) updateProcess(processId int64, fn func(p *dto.ModelFileProcessDto)) {
+	if recordId, ok := d.processRecord[processId]; ok {
+		if p, ok := d.processes[recordId][processId]; ok {
+			fn(p)
+		}
+	}
+}
+
+// FileIndex 文件记录及其下载进度的内存索引，启动时全量加载，调度过程中的写操作同步更新，
+// 定期全量刷新以纳入其他程序直接写入数据库的数据。未加载完成或未命中时由调用方查询数据库。
+type FileIndex struct {
+	modelFileRecordDao  dao.RecordStore
+	modelFileProcessDao dao.ProcessStore
+	mu                  sync.RWMutex
+	data                *indexData
+	replay              []func(d *indexData) // 加载期间的写操作，加载完成后在新数据上重放
+	loading             bool
+	ready               atomic.Bool
+	loadMu              sync.Mutex
+}
+
+func NewFileIndex(modelFileRecordDao dao.RecordStore, modelFileProcessDao dao.ProcessStore) *FileIndex {
+	return &FileIndex{
+		modelFileRecordDao:  modelFileRecordDao,
+		modelFileProcessDao: modelFileProcessDao,
+		data:                newIndexData(),
+	}
+}
+
+// Load 从数据库全量加载索引
+func (idx *FileIndex) Load() error {
+	idx.loadMu.Lock()
+	defer idx.loadMu.Unlock()
+	start := time.Now()
+	idx.mu.Lock()
+	idx.loading = true
+	idx.replay = nil
+	idx.mu.Unlock()
+	data, err := idx.scan()
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.loading = false
+	if err != nil {
+		idx.replay = nil
+		return err
+	}
+	for _, fn := range idx.replay {
+		fn(data)
+	}
+	idx.replay = nil
+	idx.data = data
+	idx.ready.Store(true)
+	zap.S().Infof("file index loaded, %d records, %d processes, cost %s", len(data.records), len(data.processRecord), time.Since(start))
+	return nil
+}
+
+func (idx *FileIndex) scan() (*indexData, error) {
+	data := newIndexData()
+	var afterId int64
+	for {
+		records, err := idx.modelFileRecordDao.ScanRecords(afterId, indexScanSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			data.putRecord(record)
+			afterId = record.ID
+		}
+		if len(records) < indexScanSize {
+			break
+		}
+	}
+	afterId = 0
+	for {
+		processes, err := idx.modelFileProcessDao.ScanProcesses(afterId, indexScanSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, process := range processes {
+			data.putProcess(process)
+			afterId = process.ID
+		}
+		if len(processes) < indexScanSize {
+			break
+		}
+	}
+	return data, nil
+}
+
+func (idx *FileIndex) apply(fn func(d *indexData)) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	fn(idx.data)
+	if idx.loading {
+		idx.replay = append(idx.replay, fn)
+	}
+}
+
+// GetRecord 查找文件记录，第二个返回值为false时需查询数据库
+func (idx *FileIndex) GetRecord(q *query.ModelFileRecordQuery) (*model.ModelFileRecord, bool) {
+	if !idx.ready.Load() || q.Datatype == "" || q.Org == "" || q.Repo == "" || q.FileName == "" || q.Etag == "" {
+		return nil, false
+	}
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	record, ok := idx.data.records[fileKey{q.Datatype, q.Org, q.Repo, q.FileName, q.Etag}]
+	if !ok {
+		return nil, false
+	}
+	r := *record
+	return &r, true
+}
+
+// GetRecordsByEtag 查找相同etag的所有文件记录，第二个返回值为false时需查询数据库
+func (idx *FileIndex) GetRecordsByEtag(etag string) ([]*model.ModelFileRecord, bool) {
+	if !idx.ready.Load() {
+		return nil, false
+	}
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	records := make([]*model.ModelFileRecord, 0, len(idx.data.etags[etag]))
+	for _, record := range idx.data.etags[etag] {
+		r := *record
+		records = append(records, &r)
+	}
+	return records, true
+}
+
+// GetProcesses 返回记录的下载进度副本，按offset_num从大到小排列，第二个返回值为false时需查询数据库
+func (idx *FileIndex) GetProcesses(recordId int64) ([]*dto.ModelFileProcessDto, bool) {
+	if !idx.ready.Load() {
+		return nil, false
+	}
+	idx.mu.RLock()
+	processes, ok := idx.data.processes[recordId]
+	if !ok {
+		idx.mu.RUnlock()
+		return nil, false
+	}
+	result := make([]*dto.ModelFileProcessDto, 0, len(processes))
+	for _, p := range processes {
+		c := *p
+		result = append(result, &c)
+	}
+	idx.mu.RUnlock()
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].OffsetNum != result[j].OffsetNum {
+			return result[i].OffsetNum > result[j].OffsetNum
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result, true
+}
+
+// PutRecord 加入文件记录及其全部下载进度
+func (idx *FileIndex) PutRecord(record *model.ModelFileRecord, processes []*dto.ModelFileProcessDto) {
+	r := *record
+	ps := make([]*dto.ModelFileProcessDto, 0, len(processes))
+	for _, p := range processes {
+		c := dto.ModelFileProcessDto{ID: p.ID, RecordID: record.ID, InstanceID: p.InstanceID, OffsetNum: p.OffsetNum, Ranges: p.Ranges}
+		ps = append(ps, &c)
+	}
+	idx.apply(func(d *indexData) {
+		d.putRecord(&r)
+		for _, p := range ps {
+			d.putProcess(p)
+		}
+	})
+}
+
+func (idx *FileIndex) PutProcess(process *model.ModelFileProcess) {
+	p := &dto.ModelFileProcessDto{ID: process.ID, RecordID: process.RecordID, InstanceID: process.InstanceID, OffsetNum: process.OffsetNum, Ranges: process.Ranges}
+	idx.apply(func(d *indexData) {
+		d.putProcess(p)
+	})
+}
+
+func (idx *FileIndex) ResetProcess(processId, offsetNum int64) {
+	idx.apply(func(d *indexData) {
+		d.updateProcess(processId, func(p *dto.ModelFileProcessDto) {
+			p.OffsetNum = offsetNum
+			p.Ranges = ""
+		})
+	})
+}
+
+// ApplyReports 进度上报写入数据库后同步到索引
+func (idx *FileIndex) ApplyReports(reports map[int64]*dto.ProcessReport) {
+	idx.apply(func(d *indexData) {
+		for id, report := range reports {
+			if len(report.Ranges) == 0 {
+				continue
+			}
+			d.updateProcess(id, func(p *dto.ModelFileProcessDto) {
+				rs := p.RangeSet()
+				for _, r := range report.Ranges {
+					rs = rs.Add(r.Start, r.End)
+				}
+				p.OffsetNum = rs.Prefix()
+				p.Ranges = rs.String()
+			})
+		}
+	})
+}
+
+func (idx *FileIndex) RemoveProcesses(recordIds []int64, instanceId string) {
+	idx.apply(func(d *indexData) {
+		for _, recordId := range recordIds {
+			for id, p := range d.processes[recordId] {
+				if p.InstanceID == instanceId {
+					delete(d.processes[recordId], id)
+					delete(d.processRecord, id)
+				}
+			}
+		}
+	})
+}
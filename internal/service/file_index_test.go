@@ -0,0 +1,141 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package service
+
+import (
+	"testing"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/dao/memory"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/model/dto"
+	"dingoscheduler/internal/model/query"
+	"dingoscheduler/pkg/common"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/consts"
+	pb "dingoscheduler/pkg/proto/manager"
+)
+
+// snapshotStores 扫描记录时取记录和进度的快照并阻塞，模拟加载期间数据库又发生了写入
+type snapshotStores struct {
+	dao.RecordStore
+	dao.ProcessStore
+	started   chan struct{}
+	release   chan struct{}
+	processes []*dto.ModelFileProcessDto
+}
+
+func (s *snapshotStores) ScanRecords(afterId int64, limit int) ([]*model.ModelFileRecord, error) {
+	records, err := s.RecordStore.ScanRecords(afterId, limit)
+	if err != nil {
+		return nil, err
+	}
+	if s.processes, err = s.ProcessStore.ScanProcesses(0, limit); err != nil {
+		return nil, err
+	}
+	close(s.started)
+	<-s.release
+	return records, nil
+}
+
+func (s *snapshotStores) ScanProcesses(afterId int64, limit int) ([]*dto.ModelFileProcessDto, error) {
+	return s.processes, nil
+}
+
+func saveProcess(t *testing.T, records dao.RecordStore, name, instanceId string) (int64, int64) {
+	t.Helper()
+	process := &model.ModelFileProcess{InstanceID: instanceId, Status: consts.StatusDownloading}
+	id, _, err := records.SaveSchedulerRecord(&pb.SchedulerFileRequest{DataType: "models", Org: "org", Repo: "repo", Name: name,
+		Etag: lfsEtag, FileSize: 100}, process)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return process.RecordID, id
+}
+
+// TestFileIndexReplaysWritesDuringLoad 加载期间的写操作在加载完成后重放到新数据上，不会被旧快照覆盖
+func TestFileIndexReplaysWritesDuringLoad(t *testing.T) {
+	config.SysConfig = &config.Config{}
+	config.SysConfig.SetDefaults()
+	db := memory.NewDB()
+	records, processes := memory.NewRecordStore(db), memory.NewProcessStore(db)
+	recordId, reported := saveProcess(t, records, "model.bin", "speed-a")
+	_, removed := saveProcess(t, records, "model.bin", "speed-b")
+	_, reset := saveProcess(t, records, "model.bin", "speed-c")
+	if err := processes.BatchReportFileProcess(map[int64]*dto.ProcessReport{reset: {Ranges: common.RangeSet{}.Add(0, 50)}}); err != nil {
+		t.Fatal(err)
+	}
+	stores := &snapshotStores{RecordStore: records, ProcessStore: processes, started: make(chan struct{}), release: make(chan struct{})}
+	idx := NewFileIndex(stores, stores)
+
+	loaded := make(chan error, 1)
+	go func() { loaded <- idx.Load() }()
+	<-stores.started
+	// 快照之后的写入
+	newRecordId, added := saveProcess(t, records, "config.json", "speed-a")
+	newRecords, err := records.GetByIDs([]int64{newRecordId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	newProcesses, err := processes.GetModelFileProcess(newRecordId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx.PutRecord(&newRecords[0], newProcesses)
+	idx.ApplyReports(map[int64]*dto.ProcessReport{reported: {Ranges: common.RangeSet{}.Add(0, 100)}})
+	idx.RemoveProcesses([]int64{recordId}, "speed-b")
+	idx.ResetProcess(reset, 0)
+	close(stores.release)
+	if err = <-loaded; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		recordId  int64
+		processId int64
+		present   bool
+		offset    int64
+	}{
+		{name: "report", recordId: recordId, processId: reported, present: true, offset: 100},
+		{name: "remove", recordId: recordId, processId: removed},
+		{name: "reset", recordId: recordId, processId: reset, present: true, offset: 0},
+		{name: "new record", recordId: newRecordId, processId: added, present: true, offset: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, ok := idx.GetProcesses(tt.recordId)
+			if !ok {
+				t.Fatalf("record %d not indexed", tt.recordId)
+			}
+			var found *dto.ModelFileProcessDto
+			for _, p := range list {
+				if p.ID == tt.processId {
+					found = p
+				}
+			}
+			if (found != nil) != tt.present {
+				t.Fatalf("process %d present = %v, want %v", tt.processId, found != nil, tt.present)
+			}
+			if found != nil && found.OffsetNum != tt.offset {
+				t.Fatalf("process %d offset = %d, want %d", tt.processId, found.OffsetNum, tt.offset)
+			}
+		})
+	}
+	q := &query.ModelFileRecordQuery{Datatype: "models", Org: "org", Repo: "repo", FileName: "config.json", Etag: lfsEtag}
+	if record, ok := idx.GetRecord(q); !ok || record.ID != newRecordId {
+		t.Fatalf("record created during load = %+v, %v", record, ok)
+	}
+}
@@ -5,10 +5,10 @@ import (
 )
 
 type HfTokenService struct {
-	hfTokenDao *dao.HfTokenDao
+	hfTokenDao dao.HfTokenStore
 }
 
-func NewHfTokenService(hfTokenDao *dao.HfTokenDao) *HfTokenService {
+func NewHfTokenService(hfTokenDao dao.HfTokenStore) *HfTokenService {
 	return &HfTokenService{
 		hfTokenDao: hfTokenDao,
 	}
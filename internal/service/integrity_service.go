@@ -0,0 +1,300 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/consts"
+	myerr "dingoscheduler/pkg/error"
+	"dingoscheduler/pkg/event"
+	"dingoscheduler/pkg/prom"
+	pb "dingoscheduler/pkg/proto/manager"
+	"dingoscheduler/pkg/util"
+
+	"github.com/bytedance/sonic"
+	"go.uber.org/zap"
+)
+
+// IntegrityService 校验节点上报的SHA-256。整个文件的摘要与etag（LFS oid）比对，区间摘要与相同etag
+// 已校验通过的节点上报的同一区间比对。校验失败时重置进度，并隔离提供数据的节点。
+// 区间摘要和隔离保存在数据库中，不随缓存过期，各副本共享。
+type IntegrityService struct {
+	integrityDao        dao.IntegrityStore
+	modelFileRecordDao  dao.RecordStore
+	modelFileProcessDao dao.ProcessStore
+	progressService     *ProgressService
+	fileIndex           *FileIndex
+	bus                 *event.Bus
+}
+
+func NewIntegrityService(integrityDao dao.IntegrityStore, modelFileRecordDao dao.RecordStore, modelFileProcessDao dao.ProcessStore,
+	progressService *ProgressService, fileIndex *FileIndex, bus *event.Bus) *IntegrityService {
+	return &IntegrityService{
+		integrityDao:        integrityDao,
+		modelFileRecordDao:  modelFileRecordDao,
+		modelFileProcessDao: modelFileProcessDao,
+		progressService:     progressService,
+		fileIndex:           fileIndex,
+		bus:                 bus,
+	}
+}
+
+func (s *IntegrityService) Report(req *pb.ChecksumRequest) (*pb.ChecksumResponse, error) {
+	process, err := s.modelFileProcessDao.GetById(req.ProcessId)
+	if err != nil {
+		return nil, err
+	}
+	if process == nil {
+		return nil, myerr.New(fmt.Sprintf("process %d not found", req.ProcessId))
+	}
+	// 只能上报本节点的进度，避免其他节点重置或隔离不属于它的数据
+	if req.InstanceId != process.InstanceID {
+		return nil, myerr.New(fmt.Sprintf("process %d does not belong to instance %s", req.ProcessId, req.InstanceId))
+	}
+	records, err := s.modelFileRecordDao.GetByIDs([]int64{process.RecordID})
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, myerr.New(fmt.Sprintf("record %d not found", process.RecordID))
+	}
+	record := records[0]
+
+	refChecksums, err := s.integrityDao.ReferenceChecksums(record.Etag)
+	if err != nil {
+		return nil, err
+	}
+	refs := make(map[string]string, len(refChecksums))
+	for _, c := range refChecksums {
+		refs[chunkField(c.StartPos, c.EndPos)] = c.Sha256
+	}
+	pending := make([]*model.FileChecksum, 0, len(req.Chunks))
+	corrupted := false
+	for _, c := range req.Chunks {
+		digest := strings.ToLower(c.Sha256)
+		if ref, ok := refs[chunkField(c.StartPos, c.EndPos)]; ok && ref != digest {
+			zap.S().Errorf("process %d chunk [%d, %d) digest mismatch, etag %s", process.ID, c.StartPos, c.EndPos, record.Etag)
+			corrupted = true
+			break
+		}
+		pending = append(pending, &model.FileChecksum{ProcessID: process.ID, Etag: record.Etag, StartPos: c.StartPos,
+			EndPos: c.EndPos, Sha256: digest})
+	}
+	if !corrupted {
+		if err = s.integrityDao.SaveChecksums(pending); err != nil {
+			return nil, err
+		}
+	}
+	integrity := process.Integrity
+	if !corrupted && req.FileSha256 != "" && isSha256(record.Etag) {
+		if strings.EqualFold(req.FileSha256, record.Etag) {
+			integrity = consts.IntegrityVerified
+			// 校验通过的区间摘要（含此前上报的）作为相同etag其他节点的比对基准
+			if err = s.integrityDao.VerifyChecksums(process.ID); err != nil {
+				return nil, err
+			}
+		} else {
+			zap.S().Errorf("process %d file digest %s mismatch etag %s", process.ID, req.FileSha256, record.Etag)
+			corrupted = true
+		}
+	}
+	if corrupted {
+		if err = s.markCorrupted(process, record, req.SourceInstanceId); err != nil {
+			return nil, err
+		}
+		return &pb.ChecksumResponse{Integrity: consts.IntegrityCorrupted}, nil
+	}
+	if integrity != process.Integrity {
+		if err = s.modelFileProcessDao.UpdateIntegrity(process.ID, integrity); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.ChecksumResponse{Integrity: integrity}, nil
+}
+
+// Quarantined 返回因提供过损坏数据而不能作为该etag来源的节点，查询失败时不排除任何节点
+func (s *IntegrityService) Quarantined(etag string) map[string]struct{} {
+	if etag == "" {
+		return nil
+	}
+	instanceIds, err := s.integrityDao.QuarantinedInstances(etag, util.Now())
+	if err != nil {
+		zap.S().Errorf("query quarantined instances of etag %s err.%v", etag, err)
+		return nil
+	}
+	quarantined := make(map[string]struct{}, len(instanceIds))
+	for _, instanceId := range instanceIds {
+		quarantined[instanceId] = struct{}{}
+	}
+	return quarantined
+}
+
+// Purge 删除已不存在的下载进度的区间摘要及已过期的隔离
+func (s *IntegrityService) Purge() error {
+	purged, err := s.integrityDao.Purge(util.Now())
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		zap.S().Infof("purged %d checksums and quarantines", purged)
+	}
+	return nil
+}
+
+// alertTimeout 发送隔离告警的超时时间
+const alertTimeout = 10 * time.Second
+
+// quarantineAlert 隔离告警webhook的请求体
+type quarantineAlert struct {
+	Message    string `json:"message"`
+	InstanceID string `json:"instanceId"`
+	Etag       string `json:"etag"`
+	File       string `json:"file"`
+	ProcessID  int64  `json:"processId"`
+	ExpiresAt  string `json:"expiresAt"`
+}
+
+// Alert 将节点隔离发送到配置的告警webhook，未配置时不发送
+func (s *IntegrityService) Alert(ev *event.PeerQuarantined) error {
+	webhook := config.SysConfig.Scheduler.Integrity.AlertWebhook
+	if webhook == "" {
+		return nil
+	}
+	file := fmt.Sprintf("%s/%s/%s/%s", ev.Datatype, ev.Org, ev.Repo, ev.Name)
+	b, err := sonic.Marshal(&quarantineAlert{
+		Message:    fmt.Sprintf("dingospeed %s served corrupted data of %s and is quarantined", ev.InstanceID, file),
+		InstanceID: ev.InstanceID,
+		Etag:       ev.Etag,
+		File:       file,
+		ProcessID:  ev.ProcessID,
+		ExpiresAt:  ev.ExpiresAt.Format(time.DateTime),
+	})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), alertTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return myerr.New(fmt.Sprintf("alert webhook status %d", resp.StatusCode))
+	}
+	return nil
+}
+
+// markCorrupted 重置进度以便重新下载。数据来自其他节点时隔离该节点，回源下载的不隔离任何节点；
+// 来源节点自身的数据已校验通过时，损坏更可能发生在接收方，也不隔离
+func (s *IntegrityService) markCorrupted(process *model.ModelFileProcess, record model.ModelFileRecord, sourceInstanceId string) error {
+	err := s.progressService.Reset([]int64{process.ID}, func() error {
+		if err := s.modelFileProcessDao.MarkCorrupted(process.ID); err != nil {
+			return err
+		}
+		s.fileIndex.ResetProcess(process.ID, 0)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err = s.integrityDao.DeleteChecksums(process.ID); err != nil {
+		return err
+	}
+	if sourceInstanceId == "" || sourceInstanceId == process.InstanceID {
+		return nil
+	}
+	verified, err := s.sourceVerified(record.Etag, sourceInstanceId)
+	if err != nil {
+		return err
+	}
+	if verified {
+		zap.S().Warnf("process %d received corrupted data of etag %s from verified instance %s, source not quarantined",
+			process.ID, record.Etag, sourceInstanceId)
+		return nil
+	}
+	expiresAt := util.Now().Add(config.SysConfig.GetQuarantineTTL())
+	if err = s.integrityDao.Quarantine(record.Etag, sourceInstanceId, expiresAt); err != nil {
+		return err
+	}
+	prom.SpeedQuarantinedCnt.WithLabelValues(sourceInstanceId).Inc()
+	zap.S().Errorf("instance %s served corrupted data of %s/%s/%s/%s to %s, quarantined for etag %s",
+		sourceInstanceId, record.Datatype, record.Org, record.Repo, record.Name, process.InstanceID, record.Etag)
+	s.bus.Publish(event.TopicPeerQuarantined, &event.PeerQuarantined{
+		InstanceID: sourceInstanceId,
+		Etag:       record.Etag,
+		ProcessID:  process.ID,
+		Datatype:   record.Datatype,
+		Org:        record.Org,
+		Repo:       record.Repo,
+		Name:       record.Name,
+		ExpiresAt:  expiresAt,
+	})
+	return nil
+}
+
+// sourceVerified 节点上相同etag的任一进度是否已校验通过
+func (s *IntegrityService) sourceVerified(etag, instanceId string) (bool, error) {
+	records, err := s.modelFileRecordDao.BatchQueryByEtags([]string{etag})
+	if err != nil {
+		return false, err
+	}
+	for _, record := range records {
+		processDto, err := s.modelFileProcessDao.GetModelFileProcessByInstanceId(record.ID, instanceId)
+		if err != nil {
+			return false, err
+		}
+		if processDto == nil {
+			continue
+		}
+		process, err := s.modelFileProcessDao.GetById(processDto.ID)
+		if err != nil {
+			return false, err
+		}
+		if process != nil && process.Integrity == consts.IntegrityVerified {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// chunkField 区间在比对基准中的键
+func chunkField(start, end int64) string {
+	return fmt.Sprintf("%d-%d", start, end)
+}
+
+// isSha256 LFS文件的etag为内容的SHA-256，普通文件的etag为git blob的SHA-1，无法比对
+func isSha256(etag string) bool {
+	if len(etag) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(etag)
+	return err == nil
+}
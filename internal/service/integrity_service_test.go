@@ -0,0 +1,117 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/consts"
+	"dingoscheduler/pkg/event"
+	pb "dingoscheduler/pkg/proto/manager"
+
+	"github.com/bytedance/sonic"
+)
+
+// quarantineSource speed-b从未校验的speed-a下载的数据校验失败，隔离speed-a
+func quarantineSource(t *testing.T, s *testScheduler) {
+	t.Helper()
+	ctx := context.Background()
+	s.register(t, "speed-a", 8001)
+	s.register(t, "speed-b", 8002)
+	first, err := s.SchedulerFile(ctx, fileRequest("speed-a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.ReportFileProcess(ctx, &pb.FileProcessRequest{ProcessId: first.ProcessId, StaPos: 0, EndPos: 1 << 20,
+		Status: consts.StatusDownloaded}); err != nil {
+		t.Fatal(err)
+	}
+	s.progress.Flush()
+	second, err := s.SchedulerFile(ctx, fileRequest("speed-b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.ReportChecksum(ctx, &pb.ChecksumRequest{ProcessId: second.ProcessId, InstanceId: "speed-b",
+		SourceInstanceId: "speed-a", FileSha256: badSha256}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestQuarantineExpires 隔离到期后节点重新作为来源，过期的隔离被清理
+func TestQuarantineExpires(t *testing.T) {
+	s := newTestScheduler(t)
+	config.SysConfig.Scheduler.Integrity.QuarantineTTL = 1
+	quarantineSource(t, s)
+	if _, ok := s.integrityService.Quarantined(lfsEtag)["speed-a"]; !ok {
+		t.Fatal("speed-a not quarantined")
+	}
+	resp, err := s.SchedulerFile(context.Background(), fileRequest("speed-c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.SchedulerType != consts.SchedulerNo {
+		t.Fatalf("download during quarantine = %+v, want origin", resp)
+	}
+
+	time.Sleep(config.SysConfig.GetQuarantineTTL() + 100*time.Millisecond)
+	if quarantined := s.integrityService.Quarantined(lfsEtag); len(quarantined) != 0 {
+		t.Fatalf("quarantined after ttl = %v", quarantined)
+	}
+	if resp, err = s.SchedulerFile(context.Background(), fileRequest("speed-d")); err != nil {
+		t.Fatal(err)
+	}
+	if resp.SchedulerType != consts.SchedulerYes || resp.MasterInstanceId != "speed-a" {
+		t.Fatalf("download after quarantine = %+v, want peer speed-a", resp)
+	}
+	if err = s.integrityService.Purge(); err != nil {
+		t.Fatal(err)
+	}
+	if instanceIds, _ := s.integrityService.integrityDao.QuarantinedInstances(lfsEtag, time.Time{}); len(instanceIds) != 0 {
+		t.Fatalf("expired quarantine not purged: %v", instanceIds)
+	}
+}
+
+// TestQuarantineAlert 隔离事件以JSON POST到配置的告警地址
+func TestQuarantineAlert(t *testing.T) {
+	s := newTestScheduler(t)
+	alerts := make(chan []byte, 1)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		alerts <- b
+	}))
+	defer webhook.Close()
+	config.SysConfig.Scheduler.Integrity.AlertWebhook = webhook.URL
+	quarantined, unsubscribe := s.integrityService.bus.Subscribe(event.TopicPeerQuarantined, 1)
+	defer unsubscribe()
+
+	quarantineSource(t, s)
+	ev := (<-quarantined).(*event.PeerQuarantined)
+	if err := s.integrityService.Alert(ev); err != nil {
+		t.Fatal(err)
+	}
+	var alert quarantineAlert
+	if err := sonic.Unmarshal(<-alerts, &alert); err != nil {
+		t.Fatal(err)
+	}
+	if alert.InstanceID != "speed-a" || alert.Etag != lfsEtag || alert.File != "models/org/repo/model.bin" {
+		t.Fatalf("alert = %+v", alert)
+	}
+}
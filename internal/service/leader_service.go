@@ -0,0 +1,105 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package service
+
+import (
+	"sync"
+	"time"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/prom"
+
+	"go.uber.org/zap"
+)
+
+// leaderLease 选主租约的名称
+const leaderLease = "leader"
+
+// LeaderService 多副本部署时通过租约选主，定时任务、失效节点检查等单例任务只在主副本执行。
+// 未开启多副本部署时本副本始终为主。
+type LeaderService struct {
+	leaseDao dao.LeaseStore
+	holder   string
+	mu       sync.Mutex
+	leader   bool
+	until    time.Time // 租约在本机的有效期，续约失败时到期自动让出，不依赖下一次竞选的结果
+}
+
+func NewLeaderService(leaseDao dao.LeaseStore) *LeaderService {
+	return &LeaderService{leaseDao: leaseDao, holder: dao.Holder()}
+}
+
+// IsLeader 本副本当前是否为主
+func (s *LeaderService) IsLeader() bool {
+	if !config.SysConfig.GetHAEnabled() {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.leader && time.Now().Before(s.until)
+}
+
+// Campaign 竞选或续约，主副本失联超过租约时长后由其他副本接替，返回本副本是否为主
+func (s *LeaderService) Campaign() (bool, error) {
+	lease := config.SysConfig.GetLeaderLease()
+	// 以发起请求的时间计算有效期，数据库响应慢时宁可提前让出
+	start := time.Now()
+	ok, err := s.leaseDao.Acquire(leaderLease, s.holder, lease)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		// 续约失败时保留到原有效期，期间其他副本也无法取得租约
+		return s.leader && time.Now().Before(s.until), err
+	}
+	if ok {
+		s.until = start.Add(lease)
+	}
+	s.setLeader(ok)
+	return ok, nil
+}
+
+// Resign 主动让出租约，副本停止时调用，其他副本无需等待租约过期即可接替
+func (s *LeaderService) Resign() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.leader {
+		return nil
+	}
+	s.setLeader(false)
+	return s.leaseDao.Release(leaderLease, s.holder)
+}
+
+// Leader 返回当前主副本的标识
+func (s *LeaderService) Leader() (string, error) {
+	if !config.SysConfig.GetHAEnabled() {
+		return s.holder, nil
+	}
+	return s.leaseDao.Holder(leaderLease)
+}
+
+func (s *LeaderService) setLeader(leader bool) {
+	if leader == s.leader {
+		return
+	}
+	s.leader = leader
+	if leader {
+		prom.SchedulerLeader.Set(1)
+		zap.S().Infof("[Leader] %s elected.", s.holder)
+	} else {
+		prom.SchedulerLeader.Set(0)
+		zap.S().Warnf("[Leader] %s stepped down.", s.holder)
+	}
+}
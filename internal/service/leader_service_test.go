@@ -0,0 +1,72 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"dingoscheduler/internal/dao/memory"
+	"dingoscheduler/pkg/config"
+)
+
+func TestLeaderFailover(t *testing.T) {
+	config.SysConfig = &config.Config{}
+	config.SysConfig.Scheduler.HA = config.HA{Enabled: true, LeaderLease: 1}
+	leases := memory.NewLeaseStore(memory.NewDB())
+	a := &LeaderService{leaseDao: leases, holder: "a"}
+	b := &LeaderService{leaseDao: leases, holder: "b"}
+
+	if ok, err := a.Campaign(); err != nil || !ok {
+		t.Fatalf("a campaign %v, err %v", ok, err)
+	}
+	if ok, err := b.Campaign(); err != nil || ok {
+		t.Fatalf("b elected while a holds the lease, err %v", err)
+	}
+	if !a.IsLeader() || b.IsLeader() {
+		t.Fatal("a should be the only leader")
+	}
+	if leader, _ := b.Leader(); leader != "a" {
+		t.Fatalf("leader = %q", leader)
+	}
+
+	// a失联，租约过期后b接替，a在本机的有效期同时到期
+	time.Sleep(1100 * time.Millisecond)
+	if a.IsLeader() {
+		t.Fatal("a still leader after lease expired")
+	}
+	if ok, err := b.Campaign(); err != nil || !ok {
+		t.Fatalf("b campaign %v, err %v", ok, err)
+	}
+	if ok, _ := a.Campaign(); ok || a.IsLeader() {
+		t.Fatal("a re-elected while b holds the lease")
+	}
+
+	// b主动让出后a无需等待过期
+	if err := b.Resign(); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := a.Campaign(); err != nil || !ok {
+		t.Fatalf("a campaign after resign %v, err %v", ok, err)
+	}
+}
+
+func TestLeaderWithoutHA(t *testing.T) {
+	config.SysConfig = &config.Config{}
+	s := &LeaderService{leaseDao: memory.NewLeaseStore(memory.NewDB()), holder: "a"}
+	if !s.IsLeader() {
+		t.Fatal("single replica should always be leader")
+	}
+}
@@ -0,0 +1,129 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package service
+
+import (
+	"time"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/data"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/event"
+	"dingoscheduler/pkg/prom"
+	"dingoscheduler/pkg/util"
+
+	"github.com/bytedance/sonic"
+	"go.uber.org/zap"
+)
+
+type LivenessService struct {
+	baseData      *data.BaseData
+	dingospeedDao dao.DingospeedStore
+	eventDao      dao.EventStore
+	bus           *event.Bus
+}
+
+const (
+	eventRetention = 10 * time.Minute // 已广播的事件保留的时长，远大于各副本轮询的间隔
+	eventBatchSize = 100              // 每次轮询读取的事件数
+)
+
+func NewLivenessService(baseData *data.BaseData, dingospeedDao dao.DingospeedStore, eventDao dao.EventStore, bus *event.Bus) *LivenessService {
+	return &LivenessService{
+		baseData:      baseData,
+		dingospeedDao: dingospeedDao,
+		eventDao:      eventDao,
+		bus:           bus,
+	}
+}
+
+// Sweep 将心跳超过租期的节点标记为失效，清除以其为master的下载进度，并通知订阅方重新调度。
+// 多副本部署时下载方的长连接可能在任一副本上，事件写入数据库由各副本轮询后通知本副本的连接。
+func (s *LivenessService) Sweep() error {
+	deadline := time.Now().Add(-config.SysConfig.GetHeartbeatLease())
+	speeds, err := s.dingospeedDao.ListExpired(deadline)
+	if err != nil {
+		return err
+	}
+	for _, speed := range speeds {
+		// 标记失效、清除master与写入失效事件在同一个事务中完成，中途失败时下次检查会重新处理该节点
+		var notify func([]*model.ModelFileProcess) (*model.SchedulerEvent, error)
+		if config.SysConfig.GetHAEnabled() {
+			notify = func(processes []*model.ModelFileProcess) (*model.SchedulerEvent, error) {
+				b, err := sonic.Marshal(newInstanceExpired(speed.InstanceID, processes))
+				if err != nil {
+					return nil, err
+				}
+				return &model.SchedulerEvent{Topic: event.TopicInstanceExpired, Payload: string(b)}, nil
+			}
+		}
+		processes, ok, err := s.dingospeedDao.Expire(speed.ID, speed.InstanceID, deadline, notify)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		// 缓存中的节点信息不再返回给调度
+		s.baseData.Cache.Delete(util.GetSpeedKey(speed.InstanceID, speed.Online))
+		prom.PromSpeedExpired(speed.InstanceID)
+		zap.S().Warnf("instance %s expired, last heartbeat %s, %d processes need reschedule", speed.InstanceID, speed.UpdatedAt.Format(time.DateTime), len(processes))
+		if notify == nil {
+			s.bus.Publish(event.TopicInstanceExpired, newInstanceExpired(speed.InstanceID, processes))
+		}
+	}
+	if config.SysConfig.GetHAEnabled() {
+		if _, err = s.eventDao.DeleteBefore(time.Now().Add(-eventRetention)); err != nil {
+			zap.S().Errorf("delete events err.%v", err)
+		}
+	}
+	return nil
+}
+
+func newInstanceExpired(instanceId string, processes []*model.ModelFileProcess) *event.InstanceExpired {
+	processIds := make(map[string][]int64)
+	for _, p := range processes {
+		processIds[p.InstanceID] = append(processIds[p.InstanceID], p.ID)
+	}
+	return &event.InstanceExpired{InstanceID: instanceId, ProcessIds: processIds}
+}
+
+// ExpiredEventsAfter 返回多副本部署时id大于afterId的节点失效事件及最后一个事件的id
+func (s *LivenessService) ExpiredEventsAfter(afterId int64) ([]*event.InstanceExpired, int64, error) {
+	rows, err := s.eventDao.ListAfter(afterId, eventBatchSize)
+	if err != nil {
+		return nil, afterId, err
+	}
+	events := make([]*event.InstanceExpired, 0, len(rows))
+	for _, row := range rows {
+		afterId = row.ID
+		if row.Topic != event.TopicInstanceExpired {
+			continue
+		}
+		var expired event.InstanceExpired
+		if err = sonic.UnmarshalString(row.Payload, &expired); err != nil {
+			zap.S().Errorf("unmarshal event %d err.%v", row.ID, err)
+			continue
+		}
+		events = append(events, &expired)
+	}
+	return events, afterId, nil
+}
+
+// LastEventId 返回最新事件的id，副本启动时从此处开始轮询
+func (s *LivenessService) LastEventId() (int64, error) {
+	return s.eventDao.LastId()
+}
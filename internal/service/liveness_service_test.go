@@ -0,0 +1,79 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"dingoscheduler/internal/dao/memory"
+	"dingoscheduler/internal/data"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/event"
+	pb "dingoscheduler/pkg/proto/manager"
+)
+
+// TestSweepBroadcastsExpiredWhenHA 多副本部署时失效事件写入数据库，供各副本通知本副本上的下载方
+func TestSweepBroadcastsExpiredWhenHA(t *testing.T) {
+	config.SysConfig = &config.Config{}
+	config.SysConfig.SetDefaults()
+	config.SysConfig.Scheduler.HA.Enabled = true
+	config.SysConfig.Scheduler.Liveness.Lease = 1
+	db := memory.NewDB()
+	baseData := &data.BaseData{
+		Cache: data.NewMemoryCache(config.SysConfig.GetDefaultExpiration(), config.SysConfig.GetCleanupInterval()),
+	}
+	speeds, records := memory.NewDingospeedStore(db, baseData), memory.NewRecordStore(db)
+	if _, err := speeds.Save(&model.Dingospeed{InstanceID: "speed-a", Online: true}); err != nil {
+		t.Fatal(err)
+	}
+	processId, _, err := records.SaveSchedulerRecord(&pb.SchedulerFileRequest{DataType: "models", Org: "org", Repo: "repo",
+		Name: "model.bin", Etag: lfsEtag}, &model.ModelFileProcess{InstanceID: "speed-b", MasterInstanceID: "speed-a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bus := event.NewBus()
+	local, unsubscribe := bus.Subscribe(event.TopicInstanceExpired, 1)
+	defer unsubscribe()
+	// 主副本和另一个副本共用同一张事件表
+	leader := NewLivenessService(baseData, speeds, memory.NewEventStore(db), bus)
+	replica := NewLivenessService(baseData, speeds, memory.NewEventStore(db), event.NewBus())
+	lastId, err := replica.LastEventId()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(config.SysConfig.GetHeartbeatLease() + 100*time.Millisecond)
+	if err = leader.Sweep(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case ev := <-local:
+		t.Fatalf("event %+v published to the local bus only", ev)
+	default:
+	}
+	events, lastId, err := replica.ExpiredEventsAfter(lastId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].InstanceID != "speed-a" || len(events[0].ProcessIds["speed-b"]) != 1 ||
+		events[0].ProcessIds["speed-b"][0] != processId {
+		t.Fatalf("events = %+v", events)
+	}
+	if events, _, err = replica.ExpiredEventsAfter(lastId); err != nil || len(events) != 0 {
+		t.Fatalf("events after %d = %+v, err %v", lastId, events, err)
+	}
+}
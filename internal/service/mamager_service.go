@@ -9,13 +9,13 @@ import (
 )
 
 type ManagerService struct {
-	repositoryDao     *dao.RepositoryDao
+	repositoryDao     dao.RepositoryStore
 	repositoryService *RepositoryService
-	cacheJobDao       *dao.CacheJobDao
+	cacheJobDao       dao.CacheJobStore
 	cacheJobService   *CacheJobService
 }
 
-func NewManagerService(repositoryDao *dao.RepositoryDao, repositoryService *RepositoryService, cacheJobDao *dao.CacheJobDao,
+func NewManagerService(repositoryDao dao.RepositoryStore, repositoryService *RepositoryService, cacheJobDao dao.CacheJobStore,
 	cacheJobService *CacheJobService) *ManagerService {
 	return &ManagerService{
 		repositoryService: repositoryService,
@@ -5,10 +5,10 @@ import (
 )
 
 type OrganizationService struct {
-	organizationDao *dao.OrganizationDao
+	organizationDao dao.OrganizationStore
 }
 
-func NewOrganizationService(organizationDao *dao.OrganizationDao) *OrganizationService {
+func NewOrganizationService(organizationDao dao.OrganizationStore) *OrganizationService {
 	return &OrganizationService{
 		organizationDao: organizationDao,
 	}
@@ -0,0 +1,221 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package service
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/model/dto"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/consts"
+	"dingoscheduler/pkg/prom"
+	pb "dingoscheduler/pkg/proto/manager"
+
+	"go.uber.org/zap"
+)
+
+// ErrProgressBacklog 等待写入的进度过多，拒绝新进度的上报，由dingospeed稍后重试
+var ErrProgressBacklog = errors.New("too many pending process reports")
+
+// ProgressService 在内存中按进度id合并上报，定期批量写入数据库
+type ProgressService struct {
+	modelFileProcessDao dao.ProcessStore
+	fileIndex           *FileIndex
+	mu                  sync.Mutex
+	pending             map[int64]*dto.ProcessReport
+	flushing            map[int64]*dto.ProcessReport // 正在写入数据库的批次，写入完成前仍需对调度可见
+	flushMu             sync.Mutex                   // 同一时间只有一次Flush
+	writeMu             sync.RWMutex                 // 批次写入持读锁、Reset持写锁，Reset等待期间不再开始新的批次
+}
+
+func NewProgressService(modelFileProcessDao dao.ProcessStore, fileIndex *FileIndex) *ProgressService {
+	return &ProgressService{
+		modelFileProcessDao: modelFileProcessDao,
+		fileIndex:           fileIndex,
+		pending:             make(map[int64]*dto.ProcessReport),
+		flushing:            make(map[int64]*dto.ProcessReport),
+	}
+}
+
+// Report 合并进度上报，等待写入的进度数达到上限时拒绝尚未等待写入的进度
+func (s *ProgressService) Report(req *pb.FileProcessRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	report, ok := s.pending[req.ProcessId]
+	if !ok {
+		if len(s.pending) >= config.SysConfig.GetMaxPendingReports() {
+			prom.PromProgressDropped("backlog", 1)
+			return ErrProgressBacklog
+		}
+		report = &dto.ProcessReport{}
+		s.pending[req.ProcessId] = report
+	}
+	if req.Status != consts.StatusDownloadBreak {
+		report.Ranges = report.Ranges.Add(req.StaPos, req.EndPos)
+	}
+	report.Status = req.Status
+	return nil
+}
+
+// Reset 在没有批次写入时执行reset重置进度，成功后丢弃这些进度尚未写入的上报。
+// 与单个批次的写入互斥，避免写入中的旧区间覆盖重置后的进度，最多等待正在写入的一个批次。
+func (s *ProgressService) Reset(processIds []int64, reset func() error) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if err := reset(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range processIds {
+		delete(s.pending, id)
+		delete(s.flushing, id)
+	}
+	return nil
+}
+
+// Pending 进度是否有尚未写入数据库的上报
+func (s *ProgressService) Pending(processId int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok1 := s.pending[processId]
+	_, ok2 := s.flushing[processId]
+	return ok1 || ok2
+}
+
+// Overlay 将尚未写入数据库的上报合并到查询出的进度上，使调度使用最新的进度，合并后仍按offset_num从大到小排列
+func (s *ProgressService) Overlay(processDtos []*dto.ModelFileProcessDto) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.pending) == 0 && len(s.flushing) == 0 {
+		return
+	}
+	for _, item := range processDtos {
+		flushing, ok1 := s.flushing[item.ID]
+		pending, ok2 := s.pending[item.ID]
+		if !ok1 && !ok2 {
+			continue
+		}
+		rs := item.RangeSet()
+		for _, report := range []*dto.ProcessReport{flushing, pending} {
+			if report == nil {
+				continue
+			}
+			for _, r := range report.Ranges {
+				rs = rs.Add(r.Start, r.End)
+			}
+		}
+		item.OffsetNum = rs.Prefix()
+		item.Ranges = rs.String()
+	}
+	sort.SliceStable(processDtos, func(i, j int) bool { return processDtos[i].OffsetNum > processDtos[j].OffsetNum })
+}
+
+// Flush 将合并后的上报按批次写入数据库。写入失败的上报放回等待下次写入，下次逐条写入，
+// 避免一条无法写入的上报使同批次的其他上报一直失败，失败次数达到上限后丢弃。
+func (s *ProgressService) Flush() {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+	s.mu.Lock()
+	batch := s.pending
+	s.pending = make(map[int64]*dto.ProcessReport)
+	s.flushing = batch
+	s.mu.Unlock()
+	if len(batch) == 0 {
+		return
+	}
+	ids := make([]int64, 0, len(batch))
+	retries := make([]int64, 0)
+	for id, report := range batch {
+		if report.Attempts > 0 {
+			retries = append(retries, id)
+		} else {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	sort.Slice(retries, func(i, j int) bool { return retries[i] < retries[j] })
+	batchSize := config.SysConfig.GetProgressBatchSize()
+	for start := 0; start < len(ids); start += batchSize {
+		s.write(ids[start:min(start+batchSize, len(ids))])
+	}
+	// 逐条写入的数量不超过一个批次，其余留到下次，数据库不可用时不会每次都逐条重试全部上报
+	for i, id := range retries {
+		if i < batchSize {
+			s.write([]int64{id})
+			continue
+		}
+		s.mu.Lock()
+		if report, ok := s.flushing[id]; ok {
+			s.requeue(map[int64]*dto.ProcessReport{id: report}, false)
+		}
+		s.mu.Unlock()
+	}
+	s.mu.Lock()
+	s.flushing = make(map[int64]*dto.ProcessReport)
+	s.mu.Unlock()
+}
+
+// write 写入一个批次，已被Reset丢弃的上报不再写入
+func (s *ProgressService) write(ids []int64) {
+	s.writeMu.RLock()
+	defer s.writeMu.RUnlock()
+	s.mu.Lock()
+	chunk := make(map[int64]*dto.ProcessReport, len(ids))
+	for _, id := range ids {
+		if report, ok := s.flushing[id]; ok {
+			chunk[id] = report
+		}
+	}
+	s.mu.Unlock()
+	if len(chunk) == 0 {
+		return
+	}
+	if err := s.modelFileProcessDao.BatchReportFileProcess(chunk); err != nil {
+		zap.S().Errorf("flush %d process reports err.%v", len(chunk), err)
+		s.mu.Lock()
+		s.requeue(chunk, true)
+		s.mu.Unlock()
+		return
+	}
+	s.fileIndex.ApplyReports(chunk)
+}
+
+// requeue 将上报放回等待写入，failed时计入一次失败，调用方须持有mu
+func (s *ProgressService) requeue(chunk map[int64]*dto.ProcessReport, failed bool) {
+	maxAttempts := config.SysConfig.GetProgressMaxAttempts()
+	for id, report := range chunk {
+		if failed {
+			report.Attempts++
+		}
+		if newer, ok := s.pending[id]; ok {
+			// 保留较新的状态，区间合并
+			for _, r := range newer.Ranges {
+				report.Ranges = report.Ranges.Add(r.Start, r.End)
+			}
+			report.Status = newer.Status
+		}
+		if report.Attempts >= maxAttempts {
+			delete(s.pending, id)
+			prom.PromProgressDropped("attempts", 1)
+			zap.S().Errorf("drop report of process %d after %d failed attempts, ranges %s", id, report.Attempts, report.Ranges.String())
+			continue
+		}
+		s.pending[id] = report
+	}
+}
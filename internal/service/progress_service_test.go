@@ -0,0 +1,279 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/dao/memory"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/model/dto"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/consts"
+	pb "dingoscheduler/pkg/proto/manager"
+)
+
+// blockingProcessStore 批量写入进度时阻塞，直到测试放行
+type blockingProcessStore struct {
+	dao.ProcessStore
+	started chan struct{}
+	release chan struct{}
+	err     error
+}
+
+func (s *blockingProcessStore) BatchReportFileProcess(reports map[int64]*dto.ProcessReport) error {
+	select {
+	case <-s.started:
+	default:
+		close(s.started)
+	}
+	<-s.release
+	if s.err != nil {
+		return s.err
+	}
+	return s.ProcessStore.BatchReportFileProcess(reports)
+}
+
+// TestProgressResetDuringFlush 写入中的旧进度不能覆盖重置后的进度，写入失败时也不能被放回等待写入
+func TestProgressResetDuringFlush(t *testing.T) {
+	for name, flushErr := range map[string]error{"ok": nil, "failed": errors.New("db down")} {
+		t.Run(name, func(t *testing.T) {
+			config.SysConfig = &config.Config{}
+			config.SysConfig.SetDefaults()
+			db := memory.NewDB()
+			processes := &blockingProcessStore{ProcessStore: memory.NewProcessStore(db), started: make(chan struct{}),
+				release: make(chan struct{}), err: flushErr}
+			fileIndex := NewFileIndex(memory.NewRecordStore(db), processes)
+			progress := NewProgressService(processes, fileIndex)
+			id, err := processes.Save(&model.ModelFileProcess{RecordID: 1, InstanceID: "speed-a", Status: consts.StatusDownloading})
+			if err != nil {
+				t.Fatal(err)
+			}
+			progress.Report(&pb.FileProcessRequest{ProcessId: id, StaPos: 0, EndPos: 100, Status: consts.StatusDownloaded})
+
+			flushed := make(chan struct{})
+			go func() {
+				progress.Flush()
+				close(flushed)
+			}()
+			<-processes.started
+			reset := make(chan error, 1)
+			go func() {
+				reset <- progress.Reset([]int64{id}, func() error {
+					return processes.ResetProcess(&model.ModelFileProcess{ID: id, Status: consts.StatusDownloadBreak})
+				})
+			}()
+			select {
+			case <-reset:
+				t.Fatal("reset ran while the batch was being written")
+			case <-time.After(50 * time.Millisecond):
+			}
+			close(processes.release)
+			<-flushed
+			if err = <-reset; err != nil {
+				t.Fatal(err)
+			}
+
+			processDtos := []*dto.ModelFileProcessDto{{ID: id}}
+			progress.Overlay(processDtos)
+			if processDtos[0].OffsetNum != 0 {
+				t.Fatalf("overlay offset = %d, want 0", processDtos[0].OffsetNum)
+			}
+			processes.err = nil
+			progress.Flush()
+			process, err := processes.GetById(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if process.OffsetNum != 0 || process.Status != consts.StatusDownloadBreak {
+				t.Fatalf("process = %+v, want reset", process)
+			}
+		})
+	}
+}
+
+// failingProcessStore 批次中含有failId时写入失败
+type failingProcessStore struct {
+	dao.ProcessStore
+	failId int64
+	writes [][]int64
+}
+
+func (s *failingProcessStore) BatchReportFileProcess(reports map[int64]*dto.ProcessReport) error {
+	ids := make([]int64, 0, len(reports))
+	for id := range reports {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	s.writes = append(s.writes, ids)
+	if _, ok := reports[s.failId]; ok {
+		return errors.New("data too long")
+	}
+	return s.ProcessStore.BatchReportFileProcess(reports)
+}
+
+// TestProgressFlushIsolatesFailingReport 一条无法写入的上报不影响同批次的其他上报，多次失败后丢弃
+func TestProgressFlushIsolatesFailingReport(t *testing.T) {
+	config.SysConfig = &config.Config{}
+	config.SysConfig.SetDefaults()
+	config.SysConfig.Scheduler.Progress.MaxAttempts = 3
+	db := memory.NewDB()
+	processes := &failingProcessStore{ProcessStore: memory.NewProcessStore(db)}
+	progress := NewProgressService(processes, NewFileIndex(memory.NewRecordStore(db), processes))
+	ids := make([]int64, 0, 3)
+	for _, instanceId := range []string{"speed-a", "speed-b", "speed-c"} {
+		id, err := processes.Save(&model.ModelFileProcess{RecordID: 1, InstanceID: instanceId, Status: consts.StatusDownloading})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+		if err = progress.Report(&pb.FileProcessRequest{ProcessId: id, StaPos: 0, EndPos: 100, Status: consts.StatusDownloading}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	processes.failId = ids[1]
+
+	wantWrites := [][]int64{
+		ids,                          // 整批写入失败
+		{ids[0]}, {ids[1]}, {ids[2]}, // 逐条写入，只有ids[1]失败
+		{ids[1]}, // 第三次失败后丢弃
+	}
+	for i := 0; i < 3; i++ {
+		progress.Flush()
+	}
+	if !slices.EqualFunc(processes.writes, wantWrites, slices.Equal[[]int64]) {
+		t.Fatalf("writes = %v, want %v", processes.writes, wantWrites)
+	}
+	if progress.Pending(ids[1]) {
+		t.Fatal("failing report still pending after max attempts")
+	}
+	for _, id := range []int64{ids[0], ids[2]} {
+		process, err := processes.GetById(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if process.OffsetNum != 100 {
+			t.Fatalf("process %d offset = %d, want 100", id, process.OffsetNum)
+		}
+	}
+	progress.Flush()
+	if len(processes.writes) != len(wantWrites) {
+		t.Fatalf("dropped report written again: %v", processes.writes)
+	}
+}
+
+func TestProgressReportBacklog(t *testing.T) {
+	config.SysConfig = &config.Config{}
+	config.SysConfig.SetDefaults()
+	config.SysConfig.Scheduler.Progress.MaxPending = 2
+	db := memory.NewDB()
+	processes := memory.NewProcessStore(db)
+	progress := NewProgressService(processes, NewFileIndex(memory.NewRecordStore(db), processes))
+	tests := []struct {
+		processId int64
+		wantErr   error
+	}{
+		{processId: 1},
+		{processId: 2},
+		{processId: 3, wantErr: ErrProgressBacklog},
+		{processId: 1}, // 已在等待写入的进度仍可合并
+	}
+	for _, tt := range tests {
+		err := progress.Report(&pb.FileProcessRequest{ProcessId: tt.processId, StaPos: 0, EndPos: 10, Status: consts.StatusDownloading})
+		if !errors.Is(err, tt.wantErr) {
+			t.Fatalf("report %d err = %v, want %v", tt.processId, err, tt.wantErr)
+		}
+	}
+	progress.Flush()
+	if err := progress.Report(&pb.FileProcessRequest{ProcessId: 3, StaPos: 0, EndPos: 10, Status: consts.StatusDownloading}); err != nil {
+		t.Fatalf("report after flush err = %v", err)
+	}
+}
+
+// gatedProcessStore 每个批次写入前通知测试并等待放行
+type gatedProcessStore struct {
+	dao.ProcessStore
+	started chan map[int64]*dto.ProcessReport
+	release chan struct{}
+}
+
+func (s *gatedProcessStore) BatchReportFileProcess(reports map[int64]*dto.ProcessReport) error {
+	s.started <- reports
+	<-s.release
+	return s.ProcessStore.BatchReportFileProcess(reports)
+}
+
+// TestProgressResetWaitsForOneChunk Reset只等待正在写入的批次，不等待整个Flush，被重置的进度不再写入
+func TestProgressResetWaitsForOneChunk(t *testing.T) {
+	config.SysConfig = &config.Config{}
+	config.SysConfig.SetDefaults()
+	config.SysConfig.Scheduler.Progress.BatchSize = 1
+	db := memory.NewDB()
+	processes := &gatedProcessStore{ProcessStore: memory.NewProcessStore(db), started: make(chan map[int64]*dto.ProcessReport),
+		release: make(chan struct{})}
+	progress := NewProgressService(processes, NewFileIndex(memory.NewRecordStore(db), processes))
+	ids := make([]int64, 0, 2)
+	for _, instanceId := range []string{"speed-a", "speed-b"} {
+		id, err := processes.Save(&model.ModelFileProcess{RecordID: 1, InstanceID: instanceId, Status: consts.StatusDownloading})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+		if err = progress.Report(&pb.FileProcessRequest{ProcessId: id, StaPos: 0, EndPos: 100, Status: consts.StatusDownloading}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	flushed := make(chan struct{})
+	go func() {
+		progress.Flush()
+		close(flushed)
+	}()
+	if first := <-processes.started; len(first) != 1 || first[ids[0]] == nil {
+		t.Fatalf("first chunk = %v", first)
+	}
+	reset := make(chan error, 1)
+	go func() {
+		reset <- progress.Reset([]int64{ids[1]}, func() error {
+			return processes.ResetProcess(&model.ModelFileProcess{ID: ids[1], Status: consts.StatusDownloadBreak})
+		})
+	}()
+	time.Sleep(50 * time.Millisecond)
+	processes.release <- struct{}{}
+	select {
+	case err := <-reset:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case chunk := <-processes.started:
+		t.Fatalf("chunk %v written before the waiting reset", chunk)
+	}
+	select {
+	case <-flushed:
+	case chunk := <-processes.started:
+		t.Fatalf("reset report written: %v", chunk)
+	}
+	process, err := processes.GetById(ids[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if process.OffsetNum != 0 || process.Status != consts.StatusDownloadBreak {
+		t.Fatalf("process = %+v, want reset", process)
+	}
+}
@@ -0,0 +1,217 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package service
+
+import (
+	"io"
+	"time"
+
+	"dingoscheduler/internal/model"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/consts"
+	myerr "dingoscheduler/pkg/error"
+	pb "dingoscheduler/pkg/proto/manager"
+
+	"go.uber.org/zap"
+)
+
+type repoKey struct {
+	datatype string
+	org      string
+	repo     string
+}
+
+// ReportInventory 接收dingospeed磁盘上的全部缓存文件，与数据库中该节点的记录对账
+func (s *SchedulerService) ReportInventory(stream pb.Manager_ReportInventoryServer) error {
+	// 对账开始后更新的进度可能是上报之后新下载的，不视为差异
+	since := time.Now()
+	instanceId := ""
+	repos := make([]*pb.InventoryRepo, 0)
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if req.InstanceId == "" || (instanceId != "" && req.InstanceId != instanceId) {
+			return myerr.New("invalid instanceId")
+		}
+		instanceId = req.InstanceId
+		if req.Repo != nil {
+			repos = append(repos, req.Repo)
+		}
+	}
+	if instanceId == "" {
+		return myerr.New("empty inventory")
+	}
+	resp, err := s.reconcile(instanceId, repos, since)
+	if err != nil {
+		zap.S().Errorf("reconcile %s err.%v", instanceId, err)
+		return err
+	}
+	zap.S().Infof("reconcile %s, added:%d, updated:%d, removed:%d, failed:%d, incomplete repos:%d",
+		instanceId, resp.ProcessesAdded, resp.ProcessesUpdated, resp.ProcessesRemoved, resp.Failed, resp.ReposIncomplete)
+	return stream.SendAndClose(resp)
+}
+
+// reconcile 以节点磁盘为准修正差异：补齐缺少的记录和进度，重置不一致的进度，删除磁盘上已不存在的进度，
+// 并标记缺少文件的仓库。
+func (s *SchedulerService) reconcile(instanceId string, repos []*pb.InventoryRepo, since time.Time) (*pb.InventoryResponse, error) {
+	resp := &pb.InventoryResponse{}
+	inventory := make(map[fileKey]*pb.InventoryFile)
+	repoComplete := make(map[repoKey]bool, len(repos))
+	for _, repo := range repos {
+		rk := repoKey{repo.Datatype, repo.Org, repo.Repo}
+		complete := true
+		for _, f := range repo.Files {
+			inventory[fileKey{repo.Datatype, repo.Org, repo.Repo, f.Name, f.Etag}] = f
+			if f.Offset < f.FileSize {
+				complete = false
+			}
+		}
+		repoComplete[rk] = complete
+	}
+
+	// 先写入内存中的上报，使updated_at反映最新的进度
+	s.progressService.Flush()
+	if config.SysConfig.GetHAEnabled() {
+		// 其他副本内存中的上报无法在此写入，等待一个写入间隔，它们被写入后updated_at晚于since而被跳过
+		time.Sleep(config.SysConfig.GetProgressFlushInterval())
+	}
+	files, err := s.modelFileProcessDao.ListInstanceFiles(instanceId)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[fileKey]struct{}, len(files))
+	resets := make([]*model.ModelFileProcess, 0)
+	ghostIds := make([]int64, 0)
+	ghostRecordIds := make([]int64, 0)
+	for _, f := range files {
+		key := fileKey{f.Datatype, f.Org, f.Repo, f.Name, f.Etag}
+		seen[key] = struct{}{}
+		inv, ok := inventory[key]
+		if !ok {
+			// 已知的文件不在磁盘上，仓库不完整
+			repoComplete[repoKey{f.Datatype, f.Org, f.Repo}] = false
+		}
+		if f.UpdatedAt.After(since) || s.progressService.Pending(f.ProcessID) {
+			continue
+		}
+		if !ok {
+			ghostIds = append(ghostIds, f.ProcessID)
+			ghostRecordIds = append(ghostRecordIds, f.RecordID)
+			continue
+		}
+		if inv.Offset != f.OffsetNum {
+			resets = append(resets, &model.ModelFileProcess{ID: f.ProcessID, OffsetNum: inv.Offset, Status: inventoryStatus(inv)})
+		}
+	}
+
+	if len(resets) > 0 {
+		resetIds := make([]int64, 0, len(resets))
+		for _, p := range resets {
+			resetIds = append(resetIds, p.ID)
+		}
+		err = s.progressService.Reset(resetIds, func() error {
+			if err := s.modelFileProcessDao.ResetOffsets(resets); err != nil {
+				return err
+			}
+			for _, p := range resets {
+				s.fileIndex.ResetProcess(p.ID, p.OffsetNum)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		resp.ProcessesUpdated = int64(len(resets))
+	}
+	if len(ghostIds) > 0 {
+		err = s.progressService.Reset(ghostIds, func() error {
+			var err error
+			if resp.ProcessesRemoved, err = s.modelFileProcessDao.DeleteByIds(ghostIds); err != nil {
+				return err
+			}
+			s.fileIndex.RemoveProcesses(ghostRecordIds, instanceId)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 磁盘上有但没有进度的文件，与离线同步一样按批新建记录和进度
+	summary := &pb.SyncFileProcessSummary{}
+	batchSize := config.SysConfig.GetProgressBatchSize()
+	entries := make([]*pb.FileProcessEntry, 0, batchSize)
+	for key, inv := range inventory {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		entries = append(entries, &pb.FileProcessEntry{
+			DataType:   key.datatype,
+			Org:        key.org,
+			Repo:       key.repo,
+			Name:       key.name,
+			Etag:       key.etag,
+			InstanceId: instanceId,
+			EndPos:     inv.Offset,
+			FileSize:   inv.FileSize,
+			Status:     inventoryStatus(inv),
+		})
+		if len(entries) >= batchSize {
+			s.syncFileProcessBatch(entries, summary)
+			entries = entries[:0]
+		}
+	}
+	s.syncFileProcessBatch(entries, summary)
+	resp.ProcessesAdded = summary.Inserted
+	resp.ProcessesUpdated += summary.Updated
+	resp.Failed = summary.Failed
+
+	repositories, err := s.repositoryDao.ListByInstanceId(instanceId)
+	if err != nil {
+		return nil, err
+	}
+	incompleteIds := make([]int64, 0)
+	completeIds := make([]int64, 0)
+	for _, repository := range repositories {
+		complete := repoComplete[repoKey{repository.Datatype, repository.Org, repository.Repo}]
+		if !complete && !repository.Incomplete {
+			incompleteIds = append(incompleteIds, repository.ID)
+		} else if complete && repository.Incomplete {
+			completeIds = append(completeIds, repository.ID)
+		}
+		if !complete {
+			resp.ReposIncomplete++
+		}
+	}
+	if err = s.repositoryDao.UpdateIncomplete(incompleteIds, true); err != nil {
+		return nil, err
+	}
+	if err = s.repositoryDao.UpdateIncomplete(completeIds, false); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func inventoryStatus(f *pb.InventoryFile) int32 {
+	if f.Offset >= f.FileSize {
+		return consts.StatusDownloaded
+	}
+	return consts.StatusDownloadBreak
+}
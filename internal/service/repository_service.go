@@ -15,6 +15,7 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -26,10 +27,12 @@ import (
 	"dingoscheduler/internal/model"
 	"dingoscheduler/internal/model/dto"
 	"dingoscheduler/internal/model/query"
+	"dingoscheduler/internal/session"
 	"dingoscheduler/pkg/common"
 	"dingoscheduler/pkg/config"
 	"dingoscheduler/pkg/consts"
 	myerr "dingoscheduler/pkg/error"
+	pb "dingoscheduler/pkg/proto/manager"
 	"dingoscheduler/pkg/util"
 
 	"github.com/bytedance/sonic"
@@ -40,18 +43,19 @@ import (
 
 type RepositoryService struct {
 	baseData        *data.BaseData
-	dingospeedDao   *dao.DingospeedDao
-	repositoryDao   *dao.RepositoryDao
-	organizationDao *dao.OrganizationDao
-	tagDao          *dao.TagDao
-	hfTokenDao      *dao.HfTokenDao
+	dingospeedDao   dao.DingospeedStore
+	repositoryDao   dao.RepositoryStore
+	organizationDao dao.OrganizationStore
+	tagDao          dao.TagStore
+	hfTokenDao      dao.HfTokenStore
+	sessionRelay    *SessionRelay
 	client          *http.Client
 	persistSync     sync.Mutex
 }
 
-func NewRepositoryService(dingospeedDao *dao.DingospeedDao,
-	repositoryDao *dao.RepositoryDao, baseData *data.BaseData, organizationDao *dao.OrganizationDao,
-	tagDao *dao.TagDao, hfTokenDao *dao.HfTokenDao) *RepositoryService {
+func NewRepositoryService(dingospeedDao dao.DingospeedStore,
+	repositoryDao dao.RepositoryStore, baseData *data.BaseData, organizationDao dao.OrganizationStore,
+	tagDao dao.TagStore, hfTokenDao dao.HfTokenStore, sessionRelay *SessionRelay) *RepositoryService {
 	return &RepositoryService{
 		baseData:        baseData,
 		dingospeedDao:   dingospeedDao,
@@ -59,6 +63,7 @@ func NewRepositoryService(dingospeedDao *dao.DingospeedDao,
 		organizationDao: organizationDao,
 		tagDao:          tagDao,
 		hfTokenDao:      hfTokenDao,
+		sessionRelay:    sessionRelay,
 		client:          &http.Client{},
 	}
 }
@@ -115,8 +120,7 @@ func (s *RepositoryService) GetRepositoryById(id int64) (*dto.Repository, error)
 func (s *RepositoryService) RepositoryCardById(c echo.Context, instanceId string, id int64) (*common.Response, error) {
 	cardKey := util.GetCardKey(instanceId, id)
 	var commResp *common.Response
-	if v, ok := s.baseData.Cache.Get(cardKey); ok {
-		commResp = v.(*common.Response)
+	if s.baseData.Cache.Get(cardKey, &commResp) {
 		s.baseData.Cache.Set(cardKey, commResp, config.SysConfig.GetCacheExpiration())
 	} else {
 		targetURL, repository, err := s.getRepository(instanceId, id)
@@ -271,3 +275,18 @@ func (s *RepositoryService) MountRepository(repoReq *query.RepositoryReq) error
 	}
 	return nil
 }
+
+// EvictRepository 通知dingospeed清除本地缓存的仓库，只能通过长连接下发
+func (s *RepositoryService) EvictRepository(instanceId string, req *query.EvictRepoReq) error {
+	err := s.sessionRelay.Call(instanceId, &pb.SessionCommand{
+		Body: &pb.SessionCommand_EvictRepo{EvictRepo: &pb.EvictRepoCommand{
+			Datatype: req.Datatype,
+			Org:      req.Org,
+			Repo:     req.Repo,
+		}},
+	})
+	if errors.Is(err, session.ErrNoSession) {
+		return myerr.New("该区域dingspeed未建立连接。")
+	}
+	return err
+}
@@ -16,18 +16,25 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"sync"
-	"time"
+	"io"
+	"slices"
+	"sort"
 
 	"dingoscheduler/internal/dao"
 	"dingoscheduler/internal/data"
 	"dingoscheduler/internal/model"
 	"dingoscheduler/internal/model/dto"
 	"dingoscheduler/internal/model/query"
+	"dingoscheduler/internal/selector"
+	"dingoscheduler/internal/session"
 	"dingoscheduler/pkg/config"
 	"dingoscheduler/pkg/consts"
 	myerr "dingoscheduler/pkg/error"
+	"dingoscheduler/pkg/event"
+	"dingoscheduler/pkg/keylock"
+	"dingoscheduler/pkg/prom"
 	pb "dingoscheduler/pkg/proto/manager"
 	"dingoscheduler/pkg/util"
 
@@ -35,28 +42,34 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-var (
-	heartGap = 5 * time.Minute
-)
-
 type SchedulerService struct {
 	pb.UnimplementedManagerServer
 	baseData            *data.BaseData
-	dingospeedDao       *dao.DingospeedDao
-	modelFileRecordDao  *dao.ModelFileRecordDao
-	modelFileProcessDao *dao.ModelFileProcessDao
-	repositoryDao       *dao.RepositoryDao
-	cacheJobDao         *dao.CacheJobDao
-	scheudlerLock       sync.Mutex
+	dingospeedDao       dao.DingospeedStore
+	modelFileRecordDao  dao.RecordStore
+	modelFileProcessDao dao.ProcessStore
+	repositoryDao       dao.RepositoryStore
+	cacheJobDao         dao.CacheJobStore
+	sessionManager      *session.Manager
+	progressService     *ProgressService
+	fileIndex           *FileIndex
+	integrityService    *IntegrityService
+	leaseLocker         *dao.LeaseLocker
+	fileLocks           *keylock.Manager
 }
 
 func NewSchedulerService(
 	baseData *data.BaseData,
-	dingospeedDao *dao.DingospeedDao,
-	modelFileRecordDao *dao.ModelFileRecordDao,
-	modelFileProcessDao *dao.ModelFileProcessDao,
-	repositoryDao *dao.RepositoryDao,
-	cacheJobDao *dao.CacheJobDao,
+	dingospeedDao dao.DingospeedStore,
+	modelFileRecordDao dao.RecordStore,
+	modelFileProcessDao dao.ProcessStore,
+	repositoryDao dao.RepositoryStore,
+	cacheJobDao dao.CacheJobStore,
+	sessionManager *session.Manager,
+	progressService *ProgressService,
+	fileIndex *FileIndex,
+	integrityService *IntegrityService,
+	leaseLocker *dao.LeaseLocker,
 ) *SchedulerService {
 	return &SchedulerService{
 		baseData:            baseData,
@@ -65,6 +78,63 @@ func NewSchedulerService(
 		modelFileProcessDao: modelFileProcessDao,
 		repositoryDao:       repositoryDao,
 		cacheJobDao:         cacheJobDao,
+		sessionManager:      sessionManager,
+		progressService:     progressService,
+		fileIndex:           fileIndex,
+		integrityService:    integrityService,
+		leaseLocker:         leaseLocker,
+		fileLocks:           keylock.New("scheduler", config.SysConfig.GetLockTimeout()),
+	}
+}
+
+// Session 处理dingospeed的长连接，第一条消息须为hello
+func (s *SchedulerService) Session(stream pb.Manager_SessionServer) error {
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	hello := msg.GetHello()
+	if hello == nil || hello.InstanceId == "" {
+		return myerr.New("session must start with hello")
+	}
+	sess := s.sessionManager.Open(hello.InstanceId, stream)
+	defer s.sessionManager.Close(sess)
+	ctx := stream.Context()
+	for {
+		msg, err = stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch body := msg.Body.(type) {
+		case *pb.SessionRequest_Heartbeat:
+			if _, err = s.Heartbeat(ctx, body.Heartbeat); err != nil {
+				zap.S().Errorf("session heartbeat %s err.%v", hello.InstanceId, err)
+			}
+		case *pb.SessionRequest_FileProcess:
+			if _, err = s.ReportFileProcess(ctx, body.FileProcess); err != nil {
+				zap.S().Errorf("session report process %s err.%v", hello.InstanceId, err)
+			}
+		case *pb.SessionRequest_Ack:
+			sess.Ack(body.Ack)
+		}
+	}
+}
+
+// NotifyMasterExpired 通知下载方其master已失效，需重新调度
+func (s *SchedulerService) NotifyMasterExpired(expired *event.InstanceExpired) {
+	for instanceId, processIds := range expired.ProcessIds {
+		err := s.sessionManager.Notify(instanceId, &pb.SessionCommand{
+			Body: &pb.SessionCommand_MasterExpired{MasterExpired: &pb.MasterExpiredCommand{
+				MasterInstanceId: expired.InstanceID,
+				ProcessIds:       processIds,
+			}},
+		})
+		if err != nil && !errors.Is(err, session.ErrNoSession) {
+			zap.S().Errorf("notify %s master %s expired err.%v", instanceId, expired.InstanceID, err)
+		}
 	}
 }
 
@@ -77,7 +147,8 @@ func (s *SchedulerService) Register(ctx context.Context, req *pb.RegisterRequest
 		Host:       req.Host,
 		Port:       req.Port,
 		Online:     req.Online,
-		UpdatedAt:  time.Now(),
+		Aidc:       req.Aidc,
+		UpdatedAt:  util.Now(),
 	}
 	speed, err := s.dingospeedDao.GetEntity(req.InstanceId, req.Online)
 	if err != nil {
@@ -97,7 +168,9 @@ func (s *SchedulerService) Register(ctx context.Context, req *pb.RegisterRequest
 		}
 		dingospeed.ID = int32(id)
 	}
-	s.updateCache(req.InstanceId, req.Online)
+	// 注册信息可能变化，删除缓存后重新加载，共享缓存时其他副本同时失效
+	s.baseData.Cache.Delete(util.GetSpeedKey(req.InstanceId, req.Online))
+	s.updateCache(req.InstanceId, req.Online, nil)
 	zap.S().Infof("register success.instanceId:%s, host:%s, port:%d, online:%v", req.InstanceId, req.Host, req.Port, req.Online)
 	return &pb.RegisterResponse{
 		Success: true,
@@ -107,23 +180,39 @@ func (s *SchedulerService) Register(ctx context.Context, req *pb.RegisterRequest
 
 func (s *SchedulerService) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest) (*emptypb.Empty, error) {
 	if req.Id > 0 {
-		err := s.dingospeedDao.HeartbeatUpdate(req.Id)
+		heartbeat := &model.Dingospeed{
+			ID:                req.Id,
+			InstanceID:        req.InstanceId,
+			ActiveUploads:     req.ActiveUploads,
+			OutboundBandwidth: req.OutboundBandwidth,
+			FreeDisk:          req.FreeDisk,
+			QueuedCacheJobs:   req.QueuedCacheJobs,
+			MaxUploads:        req.MaxUploads,
+			BandwidthLimit:    req.BandwidthLimit,
+			Version:           req.Version,
+		}
+		err := s.dingospeedDao.HeartbeatUpdate(heartbeat)
 		if err != nil {
 			return nil, err
 		}
-		s.updateCache(req.InstanceId, req.Online)
+		s.updateCache(req.InstanceId, req.Online, heartbeat)
+		prom.PromSpeedLoad(req.InstanceId, req.ActiveUploads, req.OutboundBandwidth, req.FreeDisk, req.QueuedCacheJobs)
 	} else {
 		return nil, myerr.New(fmt.Sprintf("speed id is unlawful.id = %d", req.Id))
 	}
 	return nil, nil
 }
 
-func (s *SchedulerService) updateCache(instanceId string, online bool) {
+// updateCache 刷新缓存中节点的心跳时间，heartbeat不为空时同步负载信息
+func (s *SchedulerService) updateCache(instanceId string, online bool, heartbeat *model.Dingospeed) {
 	speedKey := util.GetSpeedKey(instanceId, online)
-	if v, ok := s.baseData.Cache.Get(speedKey); ok {
-		speed := v.(*model.Dingospeed)
-		speed.UpdatedAt = time.Now()
-		s.baseData.Cache.Set(speedKey, v, config.SysConfig.GetSpeedExpiration())
+	var speed *model.Dingospeed
+	if s.baseData.Cache.Get(speedKey, &speed) {
+		speed.UpdatedAt = util.Now()
+		if heartbeat != nil {
+			speed.CopyLoad(heartbeat)
+		}
+		s.baseData.Cache.Set(speedKey, speed, config.SysConfig.GetSpeedExpiration())
 	} else {
 		if _, err := s.dingospeedDao.GetEntity(instanceId, online); err != nil {
 			zap.S().Errorf("GetEntity %s, %v err.%v", instanceId, online, err)
@@ -134,10 +223,11 @@ func (s *SchedulerService) updateCache(instanceId string, online bool) {
 func (s *SchedulerService) getOptimumSpeed(instanceId string) *model.Dingospeed {
 	speedOnlineKey := util.GetSpeedKey(instanceId, true)
 	speedOfflineKey := util.GetSpeedKey(instanceId, false)
-	if v, ok := s.baseData.Cache.Get(speedOnlineKey); ok {
-		return v.(*model.Dingospeed)
-	} else if v, ok = s.baseData.Cache.Get(speedOfflineKey); ok {
-		return v.(*model.Dingospeed)
+	var speed *model.Dingospeed
+	if s.baseData.Cache.Get(speedOnlineKey, &speed) {
+		return speed
+	} else if s.baseData.Cache.Get(speedOfflineKey, &speed) {
+		return speed
 	} else {
 		if speed, err := s.dingospeedDao.GetEntity(instanceId, true); err != nil {
 			zap.S().Errorf("GetEntity %s err.%v", instanceId, err)
@@ -148,28 +238,32 @@ func (s *SchedulerService) getOptimumSpeed(instanceId string) *model.Dingospeed
 	}
 }
 
-func (s *SchedulerService) getApiLock(apiPath string) *sync.RWMutex {
-	if val, ok := s.baseData.Cache.Get(apiPath); ok {
-		s.baseData.Cache.Set(apiPath, val, config.SysConfig.GetCacheExpiration())
-		return val.(*sync.RWMutex)
+// lockFiles 获取文件的调度锁，多副本部署时还需取得分布式锁，保证各副本对同一文件只选出一个回源节点。
+// 返回的ctx在分布式锁丢失时被取消，写入数据库前需检查。
+func (s *SchedulerService) lockFiles(ctx context.Context, paths ...string) (context.Context, func(), error) {
+	unlock, err := s.fileLocks.LockAll(ctx, paths)
+	if err != nil {
+		return nil, nil, err
 	}
-	s.scheudlerLock.Lock()
-	defer s.scheudlerLock.Unlock()
-	if val, ok := s.baseData.Cache.Get(apiPath); ok {
-		s.baseData.Cache.Set(apiPath, val, config.SysConfig.GetCacheExpiration())
-		return val.(*sync.RWMutex)
+	leaseCtx, unlockLeases, err := s.leaseLocker.LockAll(ctx, paths)
+	if err != nil {
+		unlock()
+		return nil, nil, err
 	}
-	newLock := &sync.RWMutex{}
-	s.baseData.Cache.Set(apiPath, newLock, config.SysConfig.GetCacheExpiration())
-	return newLock
+	return leaseCtx, func() {
+		unlockLeases()
+		unlock()
+	}, nil
 }
 
 func (s *SchedulerService) SchedulerFile(ctx context.Context, req *pb.SchedulerFileRequest) (*pb.SchedulerFileResponse, error) {
 	schedulerFilePath := fmt.Sprintf("scheduler/%s/%s/%s/%s", req.DataType, req.Org, req.Repo, req.Etag)
-	lock := s.getApiLock(schedulerFilePath)
-	lock.Lock()
-	defer lock.Unlock()
-	record, err := s.modelFileRecordDao.FirstModelFileRecord(&query.ModelFileRecordQuery{
+	ctx, unlock, err := s.lockFiles(ctx, schedulerFilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	record, err := s.findRecord(&query.ModelFileRecordQuery{
 		Datatype: req.DataType,
 		Org:      req.Org,
 		Repo:     req.Repo,
@@ -179,77 +273,161 @@ func (s *SchedulerService) SchedulerFile(ctx context.Context, req *pb.SchedulerF
 	if err != nil {
 		return nil, err
 	}
+	if err = context.Cause(ctx); err != nil {
+		return nil, err
+	}
 	process := &model.ModelFileProcess{
 		InstanceID: req.InstanceId,
 	}
 	if record != nil {
-		var resp = &pb.SchedulerFileResponse{}
-		processDtos, err := s.modelFileProcessDao.GetModelFileProcess(record.ID)
+		processDtos, err := s.getProcesses(record.ID)
 		if err != nil {
 			return nil, err
 		}
-		if len(processDtos) > 0 {
-			return s.schedulerFileForRecordAndProcess(processDtos, process, record.ID, req)
-		} else {
-			process.RecordID = record.ID
-			process.OffsetNum = 0
-			if processId, err := s.modelFileProcessDao.Save(process); err != nil {
-				return nil, err
-			} else {
-				process.ID = processId
-			}
-			resp = &pb.SchedulerFileResponse{
-				SchedulerType: consts.SchedulerNo,
-				ProcessId:     process.ID,
-			}
-		}
-		return resp, nil
+		return s.schedulerFileForRecordAndProcess(processDtos, process, record.ID, req)
 	} else {
-		process.OffsetNum = 0 // 初始
-		if processId, err := s.modelFileRecordDao.SaveSchedulerRecord(req, process); err != nil {
+		resp := s.schedulerNewFile(req, process)
+		processId, inserted, err := s.modelFileRecordDao.SaveSchedulerRecord(req, process)
+		if err != nil {
 			return nil, err
-		} else {
-			process.ID = processId
 		}
-		return &pb.SchedulerFileResponse{
-			SchedulerType: consts.SchedulerNo,
-			ProcessId:     process.ID,
-		}, nil
+		process.ID = processId
+		if !inserted {
+			return s.schedulerExistingRecord(req, process.RecordID)
+		}
+		s.indexNewRecord(req, process)
+		resp.ProcessId = process.ID
+		return resp, nil
 	}
 }
 
-func (s *SchedulerService) schedulerFileForRecordAndProcess(processDtos []*dto.ModelFileProcessDto, process *model.ModelFileProcess, recordId int64, req *pb.SchedulerFileRequest) (resp *pb.SchedulerFileResponse, err error) {
-	resp = &pb.SchedulerFileResponse{}
-	processHistory := make(map[string]*dto.ModelFileProcessDto, 0)
-	var masterProcess *dto.ModelFileProcessDto
-	for _, item := range processDtos {
-		tmp := item
-		speed := s.getOptimumSpeed(item.InstanceID)
-		if speed != nil {
-			tmp.Host = speed.Host
-			tmp.Port = speed.Port
-			tmp.UpdatedAt = speed.UpdatedAt
+// schedulerExistingRecord 保存新文件时记录已由其他副本新建，按数据库中的全部下载进度重新调度
+func (s *SchedulerService) schedulerExistingRecord(req *pb.SchedulerFileRequest, recordId int64) (*pb.SchedulerFileResponse, error) {
+	processDtos, err := s.reloadRecord(req, recordId)
+	if err != nil {
+		return nil, err
+	}
+	s.progressService.Overlay(processDtos)
+	return s.schedulerFileForRecordAndProcess(processDtos, &model.ModelFileProcess{InstanceID: req.InstanceId}, recordId, req)
+}
+
+// schedulerNewFile 新文件没有下载进度，尝试从持有相同etag的其他文件的节点下载，由调用方保存记录
+func (s *SchedulerService) schedulerNewFile(req *pb.SchedulerFileRequest, process *model.ModelFileProcess) *pb.SchedulerFileResponse {
+	resp := &pb.SchedulerFileResponse{}
+	selReq := newSelectorRequest(req, s.getInstanceAidc(req.InstanceId))
+	ranked := s.fallbackByEtag(nil, 0, req.Etag, selReq)
+	process.MasterInstanceID = fillMaster(resp, ranked, selReq, req.Stripe)
+	process.OffsetNum = 0 // 初始
+	return resp
+}
+
+// SchedulerRepo 在一次请求中调度仓库内的多个文件。先按固定顺序获取所有文件的锁，避免与其他请求死锁，
+// 已有记录的文件逐个调度，新文件的记录在一个事务中批量保存。
+func (s *SchedulerService) SchedulerRepo(ctx context.Context, req *pb.SchedulerRepoRequest) (*pb.SchedulerRepoResponse, error) {
+	if req.InstanceId == "" || req.DataType == "" || req.Org == "" || req.Repo == "" {
+		return nil, myerr.New("invalid parameter")
+	}
+	fileReqs := make([]*pb.SchedulerFileRequest, 0, len(req.Files))
+	lockPaths := make([]string, 0, len(req.Files))
+	for _, f := range req.Files {
+		fileReqs = append(fileReqs, &pb.SchedulerFileRequest{
+			DataType:   req.DataType,
+			Org:        req.Org,
+			Repo:       req.Repo,
+			Name:       f.Name,
+			Etag:       f.Etag,
+			InstanceId: req.InstanceId,
+			StartPos:   f.StartPos,
+			FileSize:   f.FileSize,
+			Stripe:     req.Stripe,
+		})
+		lockPaths = append(lockPaths, fmt.Sprintf("scheduler/%s/%s/%s/%s", req.DataType, req.Org, req.Repo, f.Etag))
+	}
+	ctx, unlock, err := s.lockFiles(ctx, lockPaths...)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	resp := &pb.SchedulerRepoResponse{Plans: make([]*pb.FilePlan, 0, len(fileReqs))}
+	newReqs := make([]*pb.SchedulerFileRequest, 0)
+	newProcesses := make([]*model.ModelFileProcess, 0)
+	newPlans := make([]*pb.FilePlan, 0)
+	names := make(map[string]struct{}, len(fileReqs))
+	for _, fileReq := range fileReqs {
+		plan := &pb.FilePlan{Name: fileReq.Name, Etag: fileReq.Etag}
+		resp.Plans = append(resp.Plans, plan)
+		if _, ok := names[fileReq.Name]; ok {
+			plan.ErrorMsg = "duplicate file"
+			continue
 		}
-		// 标记要同步的process
-		if masterProcess == nil && item.InstanceID != req.InstanceId &&
-			item.OffsetNum > req.StartPos && time.Now().Sub(item.UpdatedAt) <= heartGap {
-			masterProcess = tmp
+		names[fileReq.Name] = struct{}{}
+		if err = context.Cause(ctx); err != nil {
+			plan.ErrorMsg = err.Error()
+			continue
 		}
-		if _, ok := processHistory[item.InstanceID]; !ok {
-			processHistory[item.InstanceID] = tmp
+		record, err := s.findRecord(&query.ModelFileRecordQuery{
+			Datatype: fileReq.DataType,
+			Org:      fileReq.Org,
+			Repo:     fileReq.Repo,
+			FileName: fileReq.Name,
+			Etag:     fileReq.Etag,
+		})
+		if err != nil {
+			plan.ErrorMsg = err.Error()
+			continue
+		}
+		process := &model.ModelFileProcess{
+			InstanceID: req.InstanceId,
+		}
+		if record == nil {
+			plan.Result = s.schedulerNewFile(fileReq, process)
+			newReqs = append(newReqs, fileReq)
+			newProcesses = append(newProcesses, process)
+			newPlans = append(newPlans, plan)
+			continue
+		}
+		processDtos, err := s.getProcesses(record.ID)
+		if err != nil {
+			plan.ErrorMsg = err.Error()
+			continue
+		}
+		if plan.Result, err = s.schedulerFileForRecordAndProcess(processDtos, process, record.ID, fileReq); err != nil {
+			plan.ErrorMsg = err.Error()
 		}
 	}
-	if masterProcess != nil {
-		resp.SchedulerType = consts.SchedulerYes
-		resp.MasterInstanceId = masterProcess.InstanceID
-		resp.Host = masterProcess.Host
-		resp.Port = masterProcess.Port
-		resp.MaxOffset = masterProcess.OffsetNum
-		process.MasterInstanceID = masterProcess.InstanceID
-	} else {
-		resp.SchedulerType = consts.SchedulerNo
-		process.MasterInstanceID = ""
+	if len(newReqs) > 0 {
+		var inserted []bool
+		if err = context.Cause(ctx); err == nil {
+			inserted, err = s.modelFileRecordDao.BatchSaveSchedulerRecords(newReqs, newProcesses)
+		}
+		if err != nil {
+			for _, plan := range newPlans {
+				plan.Result = nil
+				plan.ErrorMsg = err.Error()
+			}
+		} else {
+			for i, plan := range newPlans {
+				if !inserted[i] {
+					if plan.Result, err = s.schedulerExistingRecord(newReqs[i], newProcesses[i].RecordID); err != nil {
+						plan.ErrorMsg = err.Error()
+					}
+					continue
+				}
+				s.indexNewRecord(newReqs[i], newProcesses[i])
+				plan.Result.ProcessId = newProcesses[i].ID
+			}
+		}
 	}
+	zap.S().Infof("scheduler repo %s/%s/%s@%s for %s, %d files, %d new", req.DataType, req.Org, req.Repo, req.Revision, req.InstanceId, len(fileReqs), len(newReqs))
+	return resp, nil
+}
+
+func (s *SchedulerService) schedulerFileForRecordAndProcess(processDtos []*dto.ModelFileProcessDto, process *model.ModelFileProcess, recordId int64, req *pb.SchedulerFileRequest) (resp *pb.SchedulerFileResponse, err error) {
+	resp = &pb.SchedulerFileResponse{}
+	selReq := newSelectorRequest(req, s.getInstanceAidc(req.InstanceId))
+	ranked, processHistory := s.selectPeers(processDtos, selReq)
+	ranked = s.fallbackByEtag(ranked, recordId, req.Etag, selReq)
+	process.MasterInstanceID = fillMaster(resp, ranked, selReq, req.Stripe)
 	if processDto, ok := processHistory[req.InstanceId]; ok {
 		// 存在下载进度，被重新调度要下载
 		resp.ProcessId = processDto.ID
@@ -261,7 +439,14 @@ func (s *SchedulerService) schedulerFileForRecordAndProcess(processDtos []*dto.M
 			process.OffsetNum = processDto.OffsetNum
 		}
 		// 本地缓存被清空，数据库process将重新下载
-		if err = s.modelFileProcessDao.ResetProcess(process); err != nil {
+		err = s.progressService.Reset([]int64{process.ID}, func() error {
+			if err := s.modelFileProcessDao.ResetProcess(process); err != nil {
+				return err
+			}
+			s.fileIndex.ResetProcess(process.ID, process.OffsetNum)
+			return nil
+		})
+		if err != nil {
 			return nil, err
 		}
 		return resp, nil
@@ -272,11 +457,363 @@ func (s *SchedulerService) schedulerFileForRecordAndProcess(processDtos []*dto.M
 		} else {
 			process.ID = processId
 		}
+		s.fileIndex.PutProcess(process)
 		resp.ProcessId = process.ID
 		return resp, nil
 	}
 }
 
+// RescheduleFile 下载中master失效时重新选择master，从请求方当前位置继续下载，不重置进度
+func (s *SchedulerService) RescheduleFile(ctx context.Context, req *pb.RescheduleFileRequest) (*pb.SchedulerFileResponse, error) {
+	if req.ProcessId <= 0 || req.InstanceId == "" {
+		return nil, myerr.New("invalid parameter")
+	}
+	process, err := s.modelFileProcessDao.GetById(req.ProcessId)
+	if err != nil {
+		return nil, err
+	}
+	if process == nil || process.InstanceID != req.InstanceId {
+		return nil, myerr.New(fmt.Sprintf("process %d not found for instance %s", req.ProcessId, req.InstanceId))
+	}
+	records, err := s.modelFileRecordDao.GetByIDs([]int64{process.RecordID})
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, myerr.New(fmt.Sprintf("record %d not found", process.RecordID))
+	}
+	record := records[0]
+	schedulerFilePath := fmt.Sprintf("scheduler/%s/%s/%s/%s", record.Datatype, record.Org, record.Repo, record.Etag)
+	ctx, unlock, err := s.lockFiles(ctx, schedulerFilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	processDtos, err := s.getProcesses(record.ID)
+	if err != nil {
+		return nil, err
+	}
+	selReq := &selector.Request{
+		InstanceID: req.InstanceId,
+		Aidc:       s.getInstanceAidc(req.InstanceId),
+		Etag:       record.Etag,
+		StartPos:   req.Offset,
+		FileSize:   record.FileSize,
+	}
+	resp := &pb.SchedulerFileResponse{ProcessId: process.ID}
+	ranked, _ := s.selectPeers(processDtos, selReq, req.FailedInstanceId)
+	ranked = s.fallbackByEtag(ranked, record.ID, record.Etag, selReq, req.FailedInstanceId)
+	masterInstanceId := fillMaster(resp, ranked, selReq, req.Stripe)
+	if err = context.Cause(ctx); err != nil {
+		return nil, err
+	}
+	if err = s.modelFileProcessDao.UpdateMaster(process.ID, masterInstanceId); err != nil {
+		return nil, err
+	}
+	zap.S().Infof("reschedule process %d of %s, failed master %s, new master %s, offset %d", process.ID, req.InstanceId, req.FailedInstanceId, masterInstanceId, req.Offset)
+	return resp, nil
+}
+
+// findRecord 优先从内存索引查找文件记录，未命中时查询数据库，并将记录及其下载进度加入索引。
+// 多副本部署时其他副本的新建、删除和进度不会进入本副本的索引，持有租约后直接查询数据库。
+func (s *SchedulerService) findRecord(q *query.ModelFileRecordQuery) (*model.ModelFileRecord, error) {
+	haEnabled := config.SysConfig.GetHAEnabled()
+	if !haEnabled {
+		if record, ok := s.fileIndex.GetRecord(q); ok {
+			return record, nil
+		}
+	}
+	record, err := s.modelFileRecordDao.FirstModelFileRecord(q)
+	if err != nil || record == nil {
+		return nil, err
+	}
+	record.Datatype, record.Org, record.Repo, record.Name, record.Etag = q.Datatype, q.Org, q.Repo, q.FileName, q.Etag
+	if haEnabled {
+		return record, nil
+	}
+	processDtos, err := s.modelFileProcessDao.GetModelFileProcess(record.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.fileIndex.PutRecord(record, processDtos)
+	return record, nil
+}
+
+// getProcesses 获取文件的下载进度，并合并尚未写入数据库的进度上报，多副本部署时不使用本副本的索引
+func (s *SchedulerService) getProcesses(recordId int64) ([]*dto.ModelFileProcessDto, error) {
+	var processDtos []*dto.ModelFileProcessDto
+	ok := false
+	if !config.SysConfig.GetHAEnabled() {
+		processDtos, ok = s.fileIndex.GetProcesses(recordId)
+	}
+	if !ok {
+		var err error
+		if processDtos, err = s.modelFileProcessDao.GetModelFileProcess(recordId); err != nil {
+			return nil, err
+		}
+	}
+	s.progressService.Overlay(processDtos)
+	return processDtos, nil
+}
+
+// reloadRecord 记录已存在于数据库时加载其全部下载进度并加入索引，避免索引中只有本节点的进度
+func (s *SchedulerService) reloadRecord(req *pb.SchedulerFileRequest, recordId int64) ([]*dto.ModelFileProcessDto, error) {
+	processDtos, err := s.modelFileProcessDao.GetModelFileProcess(recordId)
+	if err != nil {
+		return nil, err
+	}
+	s.fileIndex.PutRecord(&model.ModelFileRecord{
+		ID:       recordId,
+		Datatype: req.DataType,
+		Org:      req.Org,
+		Repo:     req.Repo,
+		Name:     req.Name,
+		Etag:     req.Etag,
+		FileSize: req.FileSize,
+	}, processDtos)
+	return processDtos, nil
+}
+
+func (s *SchedulerService) indexNewRecord(req *pb.SchedulerFileRequest, process *model.ModelFileProcess) {
+	s.fileIndex.PutRecord(&model.ModelFileRecord{
+		ID:       process.RecordID,
+		Datatype: req.DataType,
+		Org:      req.Org,
+		Repo:     req.Repo,
+		Name:     req.Name,
+		Etag:     req.Etag,
+		FileSize: req.FileSize,
+	}, []*dto.ModelFileProcessDto{{ID: process.ID, InstanceID: process.InstanceID, OffsetNum: process.OffsetNum}})
+}
+
+// selectPeers 筛选能从startPos起提供数据的节点，按链路代价和配置的策略排序，超出上限的截断。
+// 同时返回各节点的下载进度，exclude中的节点不作为候选。
+func (s *SchedulerService) selectPeers(processDtos []*dto.ModelFileProcessDto, selReq *selector.Request, exclude ...string) ([]*selector.Peer, map[string]*dto.ModelFileProcessDto) {
+	processHistory := make(map[string]*dto.ModelFileProcessDto, 0)
+	now := util.Now()
+	lease := config.SysConfig.GetHeartbeatLease()
+	peers := make([]*selector.Peer, 0, len(processDtos))
+	quarantined := s.integrityService.Quarantined(selReq.Etag)
+	for _, item := range processDtos {
+		if _, ok := processHistory[item.InstanceID]; ok {
+			continue
+		}
+		processHistory[item.InstanceID] = item
+		if item.InstanceID == selReq.InstanceID || slices.Contains(exclude, item.InstanceID) {
+			continue
+		}
+		if _, ok := quarantined[item.InstanceID]; ok {
+			continue
+		}
+		available := item.AvailableFrom(selReq.StartPos)
+		if available <= selReq.StartPos {
+			continue
+		}
+		peer := &selector.Peer{
+			InstanceID: item.InstanceID,
+			Available:  available,
+			Process:    item,
+		}
+		if speed := s.getOptimumSpeed(item.InstanceID); speed != nil {
+			peer.Host = speed.Host
+			peer.Port = speed.Port
+			peer.Aidc = selector.Aidc(speed)
+			peer.HeartbeatAt = speed.UpdatedAt
+			peer.Load = selector.Load(speed)
+		}
+		peer.Fresh = now.Sub(peer.HeartbeatAt) <= lease
+		peer.Score = selector.Score(selReq, peer, lease, now)
+		peers = append(peers, peer)
+	}
+	peers = selector.ApplyTopology(selReq, peers)
+	ranked := selector.ForAidc(selReq.Aidc).Select(selReq, peers)
+	if maxCandidates := config.SysConfig.GetMaxCandidates(); len(ranked) > maxCandidates {
+		ranked = ranked[:maxCandidates]
+	}
+	return ranked, processHistory
+}
+
+// fallbackByEtag 文件本身没有可用节点时，从持有相同etag（内容相同）的其他文件的节点中选择，
+// 这些节点排在前面并带上其持有的文件信息
+func (s *SchedulerService) fallbackByEtag(ranked []*selector.Peer, recordId int64, etag string, selReq *selector.Request, exclude ...string) []*selector.Peer {
+	if etag == "" || (len(ranked) > 0 && ranked[0].Usable()) {
+		return ranked
+	}
+	records, err := s.findRecordsByEtag(etag)
+	if err != nil {
+		zap.S().Errorf("findRecordsByEtag %s err.%v", etag, err)
+		return ranked
+	}
+	sources := make(map[int64]*model.ModelFileRecord, len(records))
+	processDtos := make([]*dto.ModelFileProcessDto, 0)
+	for _, record := range records {
+		if record.ID == recordId {
+			continue
+		}
+		items, err := s.getProcesses(record.ID)
+		if err != nil {
+			zap.S().Errorf("getProcesses %d err.%v", record.ID, err)
+			return ranked
+		}
+		sources[record.ID] = record
+		processDtos = append(processDtos, items...)
+	}
+	if len(processDtos) == 0 {
+		return ranked
+	}
+	sort.SliceStable(processDtos, func(i, j int) bool { return processDtos[i].OffsetNum > processDtos[j].OffsetNum })
+	aliases, _ := s.selectPeers(processDtos, selReq, exclude...)
+	if len(aliases) == 0 || !aliases[0].Usable() {
+		return ranked
+	}
+	merged := make([]*selector.Peer, 0, len(aliases)+len(ranked))
+	seen := make(map[string]struct{}, len(aliases))
+	for _, p := range aliases {
+		p.Source = sources[p.Process.RecordID]
+		seen[p.InstanceID] = struct{}{}
+		merged = append(merged, p)
+	}
+	for _, p := range ranked {
+		if _, ok := seen[p.InstanceID]; !ok {
+			merged = append(merged, p)
+		}
+	}
+	if maxCandidates := config.SysConfig.GetMaxCandidates(); len(merged) > maxCandidates {
+		merged = merged[:maxCandidates]
+	}
+	return merged
+}
+
+func (s *SchedulerService) findRecordsByEtag(etag string) ([]*model.ModelFileRecord, error) {
+	if !config.SysConfig.GetHAEnabled() {
+		if records, ok := s.fileIndex.GetRecordsByEtag(etag); ok {
+			return records, nil
+		}
+	}
+	records, err := s.modelFileRecordDao.BatchQueryByEtags([]string{etag})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.ModelFileRecord, 0, len(records))
+	for i := range records {
+		result = append(result, &records[i])
+	}
+	return result, nil
+}
+
+// fileSource 节点持有的是其他文件时返回该文件的位置
+func fileSource(record *model.ModelFileRecord) *pb.FileSource {
+	if record == nil {
+		return nil
+	}
+	return &pb.FileSource{
+		Datatype: record.Datatype,
+		Org:      record.Org,
+		Repo:     record.Repo,
+		Name:     record.Name,
+	}
+}
+
+// fillMaster 填充调度结果，排名第一且心跳有效、未饱和的节点作为master，没有时回源下载。返回master实例id
+func fillMaster(resp *pb.SchedulerFileResponse, ranked []*selector.Peer, selReq *selector.Request, stripe bool) string {
+	resp.Candidates = toCandidates(ranked)
+	masterInstanceId := ""
+	if len(ranked) > 0 && ranked[0].Usable() {
+		master := ranked[0]
+		resp.SchedulerType = consts.SchedulerYes
+		resp.MasterInstanceId = master.InstanceID
+		resp.Host = master.Host
+		resp.Port = master.Port
+		resp.MaxOffset = master.Available
+		resp.MasterSource = fileSource(master.Source)
+		masterInstanceId = master.InstanceID
+	} else {
+		resp.SchedulerType = consts.SchedulerNo
+	}
+	if stripe {
+		if ranges := assignRanges(ranked, selReq); ranges != nil {
+			resp.SchedulerType = consts.SchedulerStripe
+			resp.Ranges = ranges
+		}
+	}
+	return masterInstanceId
+}
+
+func newSelectorRequest(req *pb.SchedulerFileRequest, aidc string) *selector.Request {
+	return &selector.Request{
+		InstanceID: req.InstanceId,
+		Aidc:       aidc,
+		Etag:       req.Etag,
+		StartPos:   req.StartPos,
+		FileSize:   req.FileSize,
+	}
+}
+
+// getInstanceAidc 获取发起调度节点所属的aidc
+func (s *SchedulerService) getInstanceAidc(instanceId string) string {
+	if speed := s.getOptimumSpeed(instanceId); speed != nil {
+		return selector.Aidc(speed)
+	}
+	return config.SysConfig.GetInstanceAidc(instanceId)
+}
+
+func toCandidates(peers []*selector.Peer) []*pb.PeerCandidate {
+	candidates := make([]*pb.PeerCandidate, 0, len(peers))
+	for _, p := range peers {
+		candidates = append(candidates, &pb.PeerCandidate{
+			InstanceId:  p.InstanceID,
+			Host:        p.Host,
+			Port:        p.Port,
+			Offset:      p.Available,
+			HeartbeatAt: util.TimeToUnix(p.HeartbeatAt),
+			Fresh:       p.Fresh,
+			Score:       p.Score,
+			Load:        p.Load,
+			Aidc:        p.Aidc,
+			Cost:        int32(p.Cost),
+			Source:      fileSource(p.Source),
+		})
+	}
+	return candidates
+}
+
+// assignRanges 将[startPos, fileSize)按固定大小切分，每个区间分给已持有该区间且分配数最少的有效节点，
+// 没有节点持有的区间由请求方回源下载。文件过小或所有区间都需回源时返回nil。
+func assignRanges(peers []*selector.Peer, req *selector.Request) []*pb.RangeAssignment {
+	if req.FileSize < config.SysConfig.GetStripeMinFileSize() || req.StartPos >= req.FileSize {
+		return nil
+	}
+	rangeSize := config.SysConfig.GetStripeRangeSize()
+	assigned := make(map[string]int, len(peers))
+	ranges := make([]*pb.RangeAssignment, 0, (req.FileSize-req.StartPos)/rangeSize+1)
+	fromPeer := false
+	for start := req.StartPos; start < req.FileSize; start += rangeSize {
+		item := &pb.RangeAssignment{StartPos: start, EndPos: min(start+rangeSize, req.FileSize)}
+		var peer *selector.Peer
+		for _, p := range peers {
+			if !p.Usable() || !p.HasRange(item.StartPos, item.EndPos) {
+				continue
+			}
+			if peer == nil || assigned[p.InstanceID] < assigned[peer.InstanceID] {
+				peer = p
+			}
+		}
+		if peer != nil {
+			item.InstanceId = peer.InstanceID
+			item.Host = peer.Host
+			item.Port = peer.Port
+			item.Source = fileSource(peer.Source)
+			assigned[peer.InstanceID]++
+			fromPeer = true
+		}
+		ranges = append(ranges, item)
+	}
+	if !fromPeer {
+		return nil
+	}
+	return ranges
+}
+
 func (s *SchedulerService) SyncFileProcess(ctx context.Context, req *pb.SyncFileProcessReq) (*emptypb.Empty, error) {
 	if len(req.FileProcessEntries) == 0 {
 		return nil, nil
@@ -292,7 +829,7 @@ func (s *SchedulerService) SyncFileProcess(ctx context.Context, req *pb.SyncFile
 
 func (s *SchedulerService) SingleFileProcess(processEntry *pb.FileProcessEntry) (*emptypb.Empty, error) {
 	if processEntry.ProcessId != 0 {
-		if err := s.modelFileProcessDao.ReportFileProcess(&pb.FileProcessRequest{
+		if err := s.progressService.Report(&pb.FileProcessRequest{
 			ProcessId: processEntry.ProcessId,
 			StaPos:    processEntry.StartPos,
 			EndPos:    processEntry.EndPos,
@@ -301,7 +838,7 @@ func (s *SchedulerService) SingleFileProcess(processEntry *pb.FileProcessEntry)
 			return nil, err
 		}
 	} else {
-		record, err := s.modelFileRecordDao.FirstModelFileRecord(&query.ModelFileRecordQuery{
+		record, err := s.findRecord(&query.ModelFileRecordQuery{
 			Datatype: processEntry.DataType,
 			Org:      processEntry.Org,
 			Repo:     processEntry.Repo,
@@ -320,7 +857,7 @@ func (s *SchedulerService) SingleFileProcess(processEntry *pb.FileProcessEntry)
 				return nil, err
 			}
 			if processDto != nil {
-				if err := s.modelFileProcessDao.ReportFileProcess(&pb.FileProcessRequest{
+				if err = s.progressService.Report(&pb.FileProcessRequest{
 					ProcessId: processDto.ID,
 					StaPos:    processEntry.StartPos,
 					EndPos:    processEntry.EndPos,
@@ -332,22 +869,31 @@ func (s *SchedulerService) SingleFileProcess(processEntry *pb.FileProcessEntry)
 				process.RecordID = record.ID
 				process.OffsetNum = processEntry.EndPos
 				process.Status = processEntry.Status
-				if _, err = s.modelFileProcessDao.Save(process); err != nil {
+				if process.ID, err = s.modelFileProcessDao.Save(process); err != nil {
 					return nil, err
 				}
+				s.fileIndex.PutProcess(process)
 			}
 			return nil, nil
 		} else {
 			process.OffsetNum = processEntry.EndPos
 			process.Status = processEntry.Status
-			if _, err = s.modelFileRecordDao.SaveSchedulerRecord(&pb.SchedulerFileRequest{
+			req := &pb.SchedulerFileRequest{
 				DataType: processEntry.DataType,
 				Org:      processEntry.Org,
 				Repo:     processEntry.Repo,
 				Name:     processEntry.Name,
 				Etag:     processEntry.Etag,
 				FileSize: processEntry.FileSize,
-			}, process); err != nil {
+			}
+			processId, inserted, err := s.modelFileRecordDao.SaveSchedulerRecord(req, process)
+			if err != nil {
+				return nil, err
+			}
+			process.ID = processId
+			if inserted {
+				s.indexNewRecord(req, process)
+			} else if _, err = s.reloadRecord(req, process.RecordID); err != nil {
 				return nil, err
 			}
 			return nil, nil
@@ -356,8 +902,231 @@ func (s *SchedulerService) SingleFileProcess(processEntry *pb.FileProcessEntry)
 	return nil, nil
 }
 
+// SyncFileProcessStream 接收节点离线期间的全部进度，按批在事务中写入，单个批次失败不影响其他批次
+func (s *SchedulerService) SyncFileProcessStream(stream pb.Manager_SyncFileProcessStreamServer) error {
+	summary := &pb.SyncFileProcessSummary{}
+	batchSize := config.SysConfig.GetProgressBatchSize()
+	entries := make([]*pb.FileProcessEntry, 0, batchSize)
+	for {
+		entry, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+		if len(entries) >= batchSize {
+			s.syncFileProcessBatch(entries, summary)
+			entries = entries[:0]
+		}
+	}
+	s.syncFileProcessBatch(entries, summary)
+	zap.S().Infof("sync file process, inserted:%d, updated:%d, failed:%d", summary.Inserted, summary.Updated, summary.Failed)
+	return stream.SendAndClose(summary)
+}
+
+func (s *SchedulerService) syncFileProcessBatch(entries []*pb.FileProcessEntry, summary *pb.SyncFileProcessSummary) {
+	if len(entries) == 0 {
+		return
+	}
+	base := summary.Inserted + summary.Updated + summary.Failed
+	// 可能新建记录的条目先加锁，避免与SchedulerFile重复创建同一文件的记录；
+	// 其他副本并发新建时由唯一键保证只有一条记录，无需分布式锁
+	lockPaths := make([]string, 0)
+	for _, entry := range entries {
+		if entry.ProcessId == 0 {
+			lockPaths = append(lockPaths, fmt.Sprintf("scheduler/%s/%s/%s/%s", entry.DataType, entry.Org, entry.Repo, entry.Etag))
+		}
+	}
+	unlock, err := s.fileLocks.LockAll(context.Background(), lockPaths)
+	if err != nil {
+		zap.S().Errorf("sync file process batch lock err.%v", err)
+		for i, entry := range entries {
+			addSyncResult(summary, &pb.SyncEntryResult{Result: consts.SyncFailed, ErrorMsg: err.Error(),
+				ProcessId: entry.ProcessId, Index: base + int64(i)})
+		}
+		summary.Failed += int64(len(entries))
+		return
+	}
+	defer unlock()
+
+	results := make([]*pb.SyncEntryResult, len(entries))
+	batch := &dto.ProcessSync{Reports: make(map[int64]*dto.ProcessReport)}
+	reportEntries := make(map[int64][]int)
+	newRecords := make(map[string]*model.ModelFileRecord)
+	newProcesses := make(map[string]*dto.NewProcess)
+	newEntries := make(map[*dto.NewProcess][]int)
+	for i, entry := range entries {
+		results[i] = &pb.SyncEntryResult{}
+		processId, recordId, err := s.resolveSyncEntry(entry)
+		if err != nil {
+			results[i].Result = consts.SyncFailed
+			results[i].ErrorMsg = err.Error()
+			continue
+		}
+		if processId != 0 {
+			report, ok := batch.Reports[processId]
+			if !ok {
+				report = &dto.ProcessReport{}
+				batch.Reports[processId] = report
+			}
+			if entry.Status != consts.StatusDownloadBreak {
+				report.Ranges = report.Ranges.Add(entry.StartPos, entry.EndPos)
+			}
+			report.Status = entry.Status
+			reportEntries[processId] = append(reportEntries[processId], i)
+			continue
+		}
+		recordKey := fmt.Sprintf("%s/%s/%s/%s/%s", entry.DataType, entry.Org, entry.Repo, entry.Name, entry.Etag)
+		processKey := recordKey + "/" + entry.InstanceId
+		np, ok := newProcesses[processKey]
+		if !ok {
+			np = &dto.NewProcess{Process: &model.ModelFileProcess{RecordID: recordId, InstanceID: entry.InstanceId}}
+			if recordId == 0 {
+				if np.Record, ok = newRecords[recordKey]; !ok {
+					np.Record = &model.ModelFileRecord{
+						Datatype: entry.DataType,
+						Org:      entry.Org,
+						Repo:     entry.Repo,
+						Name:     entry.Name,
+						Etag:     entry.Etag,
+						FileSize: entry.FileSize,
+					}
+					newRecords[recordKey] = np.Record
+				}
+			}
+			newProcesses[processKey] = np
+			batch.Processes = append(batch.Processes, np)
+		}
+		np.Process.OffsetNum = max(np.Process.OffsetNum, entry.EndPos)
+		np.Process.Status = entry.Status
+		newEntries[np] = append(newEntries[np], i)
+	}
+
+	updated, err := s.modelFileProcessDao.SyncFileProcess(batch)
+	if err != nil {
+		zap.S().Errorf("sync file process batch err.%v", err)
+		for _, indexes := range reportEntries {
+			for _, i := range indexes {
+				results[i].Result = consts.SyncFailed
+				results[i].ErrorMsg = err.Error()
+			}
+		}
+		for _, indexes := range newEntries {
+			for _, i := range indexes {
+				results[i].Result = consts.SyncFailed
+				results[i].ErrorMsg = err.Error()
+			}
+		}
+	} else {
+		for processId, indexes := range reportEntries {
+			for _, i := range indexes {
+				results[i].ProcessId = processId
+				if _, ok := updated[processId]; ok {
+					results[i].Result = consts.SyncUpdated
+				} else {
+					results[i].Result = consts.SyncFailed
+					results[i].ErrorMsg = fmt.Sprintf("process %d not found", processId)
+				}
+			}
+		}
+		// 已存在的记录可能有其他节点的进度，从数据库重新加载
+		indexed := make(map[int64]struct{})
+		for _, np := range batch.Processes {
+			if np.Record != nil && np.RecordInserted {
+				s.fileIndex.PutRecord(np.Record, nil)
+				indexed[np.Record.ID] = struct{}{}
+			}
+		}
+		for _, np := range batch.Processes {
+			if np.Record != nil {
+				if _, ok := indexed[np.Record.ID]; !ok {
+					processDtos, err := s.modelFileProcessDao.GetModelFileProcess(np.Record.ID)
+					if err != nil {
+						zap.S().Errorf("reload record %d err.%v", np.Record.ID, err)
+					} else {
+						s.fileIndex.PutRecord(np.Record, processDtos)
+					}
+					indexed[np.Record.ID] = struct{}{}
+				}
+			}
+			s.fileIndex.PutProcess(np.Process)
+			for _, i := range newEntries[np] {
+				results[i].ProcessId = np.Process.ID
+				results[i].Result = consts.SyncInserted
+			}
+		}
+		s.fileIndex.ApplyReports(batch.Reports)
+	}
+	for i, result := range results {
+		switch result.Result {
+		case consts.SyncInserted:
+			summary.Inserted++
+		case consts.SyncUpdated:
+			summary.Updated++
+		default:
+			summary.Failed++
+			result.Index = base + int64(i)
+			addSyncResult(summary, result)
+		}
+	}
+}
+
+// addSyncResult 响应中只返回失败的条目，超过上限时只计数并标记truncated，避免长时间离线后的响应超出grpc消息大小限制
+func addSyncResult(summary *pb.SyncFileProcessSummary, result *pb.SyncEntryResult) {
+	if len(summary.Results) >= config.SysConfig.GetMaxSyncResults() {
+		summary.Truncated = true
+		return
+	}
+	summary.Results = append(summary.Results, result)
+}
+
+// resolveSyncEntry 查找条目对应的进度，进度不存在时返回已有记录的id，两者都为0表示需要新建记录
+func (s *SchedulerService) resolveSyncEntry(entry *pb.FileProcessEntry) (int64, int64, error) {
+	if entry.ProcessId != 0 {
+		return entry.ProcessId, 0, nil
+	}
+	if entry.InstanceId == "" || entry.DataType == "" || entry.Org == "" || entry.Repo == "" || entry.Name == "" || entry.Etag == "" {
+		return 0, 0, myerr.New("invalid parameter")
+	}
+	record, err := s.findRecord(&query.ModelFileRecordQuery{
+		Datatype: entry.DataType,
+		Org:      entry.Org,
+		Repo:     entry.Repo,
+		FileName: entry.Name,
+		Etag:     entry.Etag,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	if record == nil {
+		return 0, 0, nil
+	}
+	processDtos, err := s.getProcesses(record.ID)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, p := range processDtos {
+		if p.InstanceID == entry.InstanceId {
+			return p.ID, record.ID, nil
+		}
+	}
+	return 0, record.ID, nil
+}
+
+func (s *SchedulerService) ReportChecksum(ctx context.Context, req *pb.ChecksumRequest) (*pb.ChecksumResponse, error) {
+	if req.ProcessId <= 0 {
+		return nil, myerr.New(fmt.Sprintf("process id is unlawful.id = %d", req.ProcessId))
+	}
+	return s.integrityService.Report(req)
+}
+
 func (s *SchedulerService) ReportFileProcess(ctx context.Context, req *pb.FileProcessRequest) (*emptypb.Empty, error) {
-	if err := s.modelFileProcessDao.ReportFileProcess(req); err != nil {
+	if req.ProcessId <= 0 {
+		return nil, myerr.New(fmt.Sprintf("process id is unlawful.id = %d", req.ProcessId))
+	}
+	if err := s.progressService.Report(req); err != nil {
 		return nil, err
 	}
 	return nil, nil
@@ -374,6 +1143,7 @@ func (s *SchedulerService) DeleteByEtagsAndFields(ctx context.Context, req *pb.D
 		if err != nil {
 			return nil, fmt.Errorf("删除ModelFileProcess记录失败: %w", err)
 		}
+		s.fileIndex.RemoveProcesses(recordIds, req.InstanceID)
 	}
 
 	if req.InstanceID != "" && req.Datatype != "" && req.Org != "" && req.Repo != "" {
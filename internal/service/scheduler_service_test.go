@@ -0,0 +1,869 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package service
+
+import (
+	"context"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/dao/memory"
+	"dingoscheduler/internal/data"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/model/dto"
+	"dingoscheduler/internal/model/query"
+	"dingoscheduler/internal/selector"
+	"dingoscheduler/internal/session"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/consts"
+	"dingoscheduler/pkg/event"
+	pb "dingoscheduler/pkg/proto/manager"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+type testScheduler struct {
+	*SchedulerService
+	progress *ProgressService
+}
+
+func newTestScheduler(t *testing.T) *testScheduler {
+	t.Helper()
+	config.SysConfig = &config.Config{}
+	config.SysConfig.SetDefaults()
+	db := memory.NewDB()
+	return newTestReplica(t, db, memory.NewRecordStore(db))
+}
+
+// newTestReplica 共用db的调度器副本，各自有独立的缓存和内存索引
+func newTestReplica(t *testing.T, db *memory.DB, records dao.RecordStore) *testScheduler {
+	t.Helper()
+	baseData := &data.BaseData{
+		Cache: data.NewMemoryCache(config.SysConfig.GetDefaultExpiration(), config.SysConfig.GetCleanupInterval()),
+	}
+	processes, repositories := memory.NewProcessStore(db), memory.NewRepositoryStore(db)
+	fileIndex := NewFileIndex(records, processes)
+	if err := fileIndex.Load(); err != nil {
+		t.Fatal(err)
+	}
+	progress := NewProgressService(processes, fileIndex)
+	bus := event.NewBus()
+	integrity := NewIntegrityService(memory.NewIntegrityStore(db), records, processes, progress, fileIndex, bus)
+	scheduler := NewSchedulerService(baseData, memory.NewDingospeedStore(db, baseData), records, processes,
+		repositories, memory.NewCacheJobStore(db, repositories), session.NewManager(), progress, fileIndex, integrity,
+		dao.NewLeaseLocker(memory.NewLeaseStore(db)))
+	return &testScheduler{SchedulerService: scheduler, progress: progress}
+}
+
+func (s *testScheduler) register(t *testing.T, instanceId string, port int32) {
+	t.Helper()
+	if _, err := s.Register(context.Background(), &pb.RegisterRequest{InstanceId: instanceId, Host: "127.0.0.1", Port: port, Online: true}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// lfsEtag LFS文件的etag即内容的SHA-256
+const lfsEtag = "0000000000000000000000000000000000000000000000000000000000000000"
+
+func fileRequest(instanceId string) *pb.SchedulerFileRequest {
+	return &pb.SchedulerFileRequest{DataType: "models", Org: "org", Repo: "repo", Name: "model.bin", Etag: lfsEtag,
+		InstanceId: instanceId, FileSize: 1 << 20}
+}
+
+func TestSchedulerFileFromPeer(t *testing.T) {
+	s := newTestScheduler(t)
+	ctx := context.Background()
+	s.register(t, "speed-a", 8001)
+	s.register(t, "speed-b", 8002)
+
+	first, err := s.SchedulerFile(ctx, fileRequest("speed-a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.SchedulerType != consts.SchedulerNo {
+		t.Fatalf("first download scheduler type = %d, want origin", first.SchedulerType)
+	}
+	if _, err = s.ReportFileProcess(ctx, &pb.FileProcessRequest{ProcessId: first.ProcessId, StaPos: 0, EndPos: 1 << 20,
+		Status: consts.StatusDownloaded}); err != nil {
+		t.Fatal(err)
+	}
+	s.progress.Flush()
+
+	second, err := s.SchedulerFile(ctx, fileRequest("speed-b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.SchedulerType != consts.SchedulerYes || second.MasterInstanceId != "speed-a" || second.Port != 8001 {
+		t.Fatalf("second download = %+v, want peer speed-a", second)
+	}
+	if second.MaxOffset != 1<<20 {
+		t.Fatalf("max offset = %d, want %d", second.MaxOffset, 1<<20)
+	}
+}
+
+func TestSchedulerFileSkipsQuarantinedPeer(t *testing.T) {
+	s := newTestScheduler(t)
+	ctx := context.Background()
+	s.register(t, "speed-a", 8001)
+	s.register(t, "speed-b", 8002)
+
+	first, err := s.SchedulerFile(ctx, fileRequest("speed-a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.ReportFileProcess(ctx, &pb.FileProcessRequest{ProcessId: first.ProcessId, StaPos: 0, EndPos: 1 << 20,
+		Status: consts.StatusDownloaded}); err != nil {
+		t.Fatal(err)
+	}
+	s.progress.Flush()
+
+	// speed-a的数据未校验，speed-b校验失败时隔离speed-a
+	second, err := s.SchedulerFile(ctx, fileRequest("speed-b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.ReportChecksum(ctx, &pb.ChecksumRequest{ProcessId: second.ProcessId, InstanceId: "speed-b",
+		SourceInstanceId: "speed-a", FileSha256: badSha256}); err != nil {
+		t.Fatal(err)
+	}
+
+	third, err := s.SchedulerFile(ctx, fileRequest("speed-c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// speed-a被隔离，speed-b的进度已重置，只能回源
+	if third.SchedulerType != consts.SchedulerNo {
+		t.Fatalf("third download = %+v, want origin", third)
+	}
+}
+
+const badSha256 = "1111111111111111111111111111111111111111111111111111111111111111"
+
+func TestSchedulerFileKeepsVerifiedPeer(t *testing.T) {
+	s := newTestScheduler(t)
+	ctx := context.Background()
+	s.register(t, "speed-a", 8001)
+	s.register(t, "speed-b", 8002)
+
+	first, err := s.SchedulerFile(ctx, fileRequest("speed-a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.ReportFileProcess(ctx, &pb.FileProcessRequest{ProcessId: first.ProcessId, StaPos: 0, EndPos: 1 << 20,
+		Status: consts.StatusDownloaded}); err != nil {
+		t.Fatal(err)
+	}
+	s.progress.Flush()
+	resp, err := s.ReportChecksum(ctx, &pb.ChecksumRequest{ProcessId: first.ProcessId, InstanceId: "speed-a",
+		FileSha256: lfsEtag})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Integrity != consts.IntegrityVerified {
+		t.Fatalf("first checksum integrity = %d, want verified", resp.Integrity)
+	}
+
+	second, err := s.SchedulerFile(ctx, fileRequest("speed-b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 只能上报本节点的进度
+	if _, err = s.ReportChecksum(ctx, &pb.ChecksumRequest{ProcessId: first.ProcessId, InstanceId: "speed-b",
+		SourceInstanceId: "speed-a", FileSha256: badSha256}); err == nil {
+		t.Fatal("checksum of another instance's process accepted")
+	}
+	// speed-a的数据已校验通过，speed-b的校验失败不隔离speed-a
+	if _, err = s.ReportChecksum(ctx, &pb.ChecksumRequest{ProcessId: second.ProcessId, InstanceId: "speed-b",
+		SourceInstanceId: "speed-a", FileSha256: badSha256}); err != nil {
+		t.Fatal(err)
+	}
+
+	third, err := s.SchedulerFile(ctx, fileRequest("speed-c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third.SchedulerType != consts.SchedulerYes || third.MasterInstanceId != "speed-a" {
+		t.Fatalf("third download = %+v, want peer speed-a", third)
+	}
+}
+
+// syncStream 按顺序发送条目的离线同步流
+type syncStream struct {
+	grpc.ServerStream
+	entries []*pb.FileProcessEntry
+	summary *pb.SyncFileProcessSummary
+}
+
+func (s *syncStream) Recv() (*pb.FileProcessEntry, error) {
+	if len(s.entries) == 0 {
+		return nil, io.EOF
+	}
+	entry := s.entries[0]
+	s.entries = s.entries[1:]
+	return entry, nil
+}
+
+func (s *syncStream) SendAndClose(summary *pb.SyncFileProcessSummary) error {
+	s.summary = summary
+	return nil
+}
+
+func TestSyncFileProcessStreamReturnsFailuresOnly(t *testing.T) {
+	s := newTestScheduler(t)
+	config.SysConfig.Scheduler.Progress.BatchSize = 2
+	config.SysConfig.Scheduler.Progress.MaxSyncResults = 2
+	stream := &syncStream{}
+	for i := 0; i < 6; i++ {
+		entry := &pb.FileProcessEntry{DataType: "models", Org: "org", Repo: "repo", Name: "model.bin", Etag: lfsEtag,
+			InstanceId: "speed-a", EndPos: 1 << 20, FileSize: 1 << 20, Status: consts.StatusDownloaded}
+		if i%2 == 1 {
+			// 缺少文件名，无法新建记录
+			entry.Name = ""
+		}
+		stream.entries = append(stream.entries, entry)
+	}
+	if err := s.SyncFileProcessStream(stream); err != nil {
+		t.Fatal(err)
+	}
+	summary := stream.summary
+	if summary.Inserted+summary.Updated != 3 || summary.Failed != 3 {
+		t.Fatalf("summary = %+v, want 3 succeeded and 3 failed", summary)
+	}
+	if !summary.Truncated || len(summary.Results) != 2 {
+		t.Fatalf("got %d results, truncated %v, want 2 truncated", len(summary.Results), summary.Truncated)
+	}
+	for i, result := range summary.Results {
+		if result.Result != consts.SyncFailed || result.Index != int64(2*i+1) {
+			t.Fatalf("result %d = %+v", i, result)
+		}
+	}
+}
+
+// TestReconcileMarksGhostRepoIncomplete 磁盘上缺少的文件使仓库不完整，尚未写入的进度不被当作差异
+func TestReconcileMarksGhostRepoIncomplete(t *testing.T) {
+	s := newTestScheduler(t)
+	ctx := context.Background()
+	s.register(t, "speed-a", 8001)
+	files := map[string]*pb.SchedulerFileResponse{}
+	for _, name := range []string{"model.bin", "config.json", "tokenizer.json"} {
+		req := fileRequest("speed-a")
+		req.Name = name
+		req.Etag = name + "-etag"
+		resp, err := s.SchedulerFile(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[name] = resp
+		if name == "tokenizer.json" {
+			continue
+		}
+		if _, err = s.ReportFileProcess(ctx, &pb.FileProcessRequest{ProcessId: resp.ProcessId, StaPos: 0, EndPos: 1 << 20,
+			Status: consts.StatusDownloaded}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.progress.Flush()
+	if err := s.repositoryDao.PersistRepo(&query.PersistRepoReq{InstanceIds: []string{"speed-a"}, Org: "org", Repo: "repo",
+		OffVerify: true}); err != nil {
+		t.Fatal(err)
+	}
+	since := time.Now()
+	// 对账开始后收到的上报
+	if _, err := s.ReportFileProcess(ctx, &pb.FileProcessRequest{ProcessId: files["tokenizer.json"].ProcessId, StaPos: 0,
+		EndPos: 1024, Status: consts.StatusDownloading}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 磁盘上只有model.bin，config.json已丢失，tokenizer.json的上报晚于盘点
+	resp, err := s.reconcile("speed-a", []*pb.InventoryRepo{{Datatype: "models", Org: "org", Repo: "repo",
+		Files: []*pb.InventoryFile{{Name: "model.bin", Etag: "model.bin-etag", FileSize: 1 << 20, Offset: 1 << 20}}}}, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.ProcessesRemoved != 1 || resp.ReposIncomplete != 1 {
+		t.Fatalf("resp = %+v, want 1 removed and 1 incomplete repo", resp)
+	}
+	repositories, err := s.repositoryDao.ListByInstanceId("speed-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repositories) != 1 || !repositories[0].Incomplete {
+		t.Fatalf("repositories = %+v, want incomplete", repositories)
+	}
+	process, err := s.modelFileProcessDao.GetById(files["tokenizer.json"].ProcessId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if process == nil || process.OffsetNum != 1024 {
+		t.Fatalf("tokenizer.json process = %+v, want offset 1024", process)
+	}
+}
+
+// TestReconcileHAWaitsForOtherReplicas 其他副本尚未写入的上报不被当作差异
+func TestReconcileHAWaitsForOtherReplicas(t *testing.T) {
+	config.SysConfig = &config.Config{}
+	config.SysConfig.SetDefaults()
+	config.SysConfig.Scheduler.HA.Enabled = true
+	config.SysConfig.Scheduler.Progress.FlushInterval = 200
+	db := memory.NewDB()
+	first := newTestReplica(t, db, memory.NewRecordStore(db))
+	second := newTestReplica(t, db, memory.NewRecordStore(db))
+	ctx := context.Background()
+	first.register(t, "speed-a", 8001)
+	resp, err := first.SchedulerFile(ctx, fileRequest("speed-a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 盘点之前上报到second的进度，在对账期间才由second写入
+	if _, err = second.ReportFileProcess(ctx, &pb.FileProcessRequest{ProcessId: resp.ProcessId, StaPos: 0, EndPos: 1024,
+		Status: consts.StatusDownloading}); err != nil {
+		t.Fatal(err)
+	}
+	since := time.Now()
+	flushed := time.AfterFunc(50*time.Millisecond, second.progress.Flush)
+	defer flushed.Stop()
+
+	// 盘点时磁盘上还没有数据
+	inventory := []*pb.InventoryRepo{{Datatype: "models", Org: "org", Repo: "repo",
+		Files: []*pb.InventoryFile{{Name: "model.bin", Etag: lfsEtag, FileSize: 1 << 20}}}}
+	if _, err = first.reconcile("speed-a", inventory, since); err != nil {
+		t.Fatal(err)
+	}
+	process, err := first.modelFileProcessDao.GetById(resp.ProcessId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if process == nil || process.OffsetNum != 1024 {
+		t.Fatalf("process = %+v, want offset 1024", process)
+	}
+}
+
+// staleRecordStore 查询不到其他副本刚新建的记录
+type staleRecordStore struct {
+	dao.RecordStore
+}
+
+func (s *staleRecordStore) FirstModelFileRecord(*query.ModelFileRecordQuery) (*model.ModelFileRecord, error) {
+	return nil, nil
+}
+
+// TestSchedulerFileRecordCreatedByOtherReplica 新建记录时发现其他副本已新建，按已有的下载进度调度
+func TestSchedulerFileRecordCreatedByOtherReplica(t *testing.T) {
+	config.SysConfig = &config.Config{}
+	config.SysConfig.SetDefaults()
+	db := memory.NewDB()
+	first := newTestReplica(t, db, memory.NewRecordStore(db))
+	second := newTestReplica(t, db, &staleRecordStore{RecordStore: memory.NewRecordStore(db)})
+	ctx := context.Background()
+	first.register(t, "speed-a", 8001)
+	first.register(t, "speed-b", 8002)
+
+	resp, err := first.SchedulerFile(ctx, fileRequest("speed-a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = first.ReportFileProcess(ctx, &pb.FileProcessRequest{ProcessId: resp.ProcessId, StaPos: 0, EndPos: 1 << 20,
+		Status: consts.StatusDownloaded}); err != nil {
+		t.Fatal(err)
+	}
+	first.progress.Flush()
+
+	resp, err = second.SchedulerFile(ctx, fileRequest("speed-b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.SchedulerType != consts.SchedulerYes || resp.MasterInstanceId != "speed-a" || resp.ProcessId == 0 {
+		t.Fatalf("resp = %+v, want peer speed-a", resp)
+	}
+	process, err := second.modelFileProcessDao.GetById(resp.ProcessId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexed, ok := second.fileIndex.GetProcesses(process.RecordID)
+	if !ok || len(indexed) != 2 || indexed[0].InstanceID != "speed-a" || indexed[0].OffsetNum != 1<<20 {
+		t.Fatalf("indexed processes = %+v", indexed)
+	}
+}
+
+// TestSchedulerFileHAReadsStore 多副本部署时不使用本副本索引中过期的下载进度
+func TestSchedulerFileHAReadsStore(t *testing.T) {
+	config.SysConfig = &config.Config{}
+	config.SysConfig.SetDefaults()
+	config.SysConfig.Scheduler.HA.Enabled = true
+	db := memory.NewDB()
+	first := newTestReplica(t, db, memory.NewRecordStore(db))
+	second := newTestReplica(t, db, memory.NewRecordStore(db))
+	ctx := context.Background()
+	first.register(t, "speed-a", 8001)
+	first.register(t, "speed-b", 8002)
+	first.register(t, "speed-c", 8003)
+
+	resp, err := first.SchedulerFile(ctx, fileRequest("speed-a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// second在speed-a上报进度之前已将记录加入索引
+	if _, err = second.SchedulerFile(ctx, fileRequest("speed-c")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = first.ReportFileProcess(ctx, &pb.FileProcessRequest{ProcessId: resp.ProcessId, StaPos: 0, EndPos: 1 << 20,
+		Status: consts.StatusDownloaded}); err != nil {
+		t.Fatal(err)
+	}
+	first.progress.Flush()
+
+	resp, err = second.SchedulerFile(ctx, fileRequest("speed-b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.SchedulerType != consts.SchedulerYes || resp.MasterInstanceId != "speed-a" {
+		t.Fatalf("resp = %+v, want peer speed-a", resp)
+	}
+}
+
+// TestChecksumChunksSharedAcrossReplicas 同一进度的区间摘要经不同副本上报，各副本的缓存独立，校验通过后都作为比对基准
+func TestChecksumChunksSharedAcrossReplicas(t *testing.T) {
+	config.SysConfig = &config.Config{}
+	config.SysConfig.SetDefaults()
+	db := memory.NewDB()
+	first := newTestReplica(t, db, memory.NewRecordStore(db))
+	second := newTestReplica(t, db, memory.NewRecordStore(db))
+	ctx := context.Background()
+	first.register(t, "speed-a", 8001)
+	first.register(t, "speed-b", 8002)
+
+	resp, err := first.SchedulerFile(ctx, fileRequest("speed-a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunk := func(start, end int64, digest string) []*pb.ChunkChecksum {
+		return []*pb.ChunkChecksum{{StartPos: start, EndPos: end, Sha256: digest}}
+	}
+	if _, err = first.ReportChecksum(ctx, &pb.ChecksumRequest{ProcessId: resp.ProcessId, InstanceId: "speed-a",
+		Chunks: chunk(0, 1<<19, lfsEtag)}); err != nil {
+		t.Fatal(err)
+	}
+	checksum, err := second.ReportChecksum(ctx, &pb.ChecksumRequest{ProcessId: resp.ProcessId, InstanceId: "speed-a",
+		Chunks: chunk(1<<19, 1<<20, lfsEtag), FileSha256: lfsEtag})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checksum.Integrity != consts.IntegrityVerified {
+		t.Fatalf("speed-a integrity = %d, want verified", checksum.Integrity)
+	}
+
+	resp, err = second.SchedulerFile(ctx, fileRequest("speed-b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 第一个区间经first上报，speed-b的摘要与之不一致
+	if checksum, err = second.ReportChecksum(ctx, &pb.ChecksumRequest{ProcessId: resp.ProcessId, InstanceId: "speed-b",
+		Chunks: chunk(0, 1<<19, badSha256)}); err != nil {
+		t.Fatal(err)
+	}
+	if checksum.Integrity != consts.IntegrityCorrupted {
+		t.Fatalf("speed-b integrity = %d, want corrupted", checksum.Integrity)
+	}
+}
+
+func processDto(id int64, instanceId string, offset int64, ranges string) *dto.ModelFileProcessDto {
+	return &dto.ModelFileProcessDto{ID: id, RecordID: 1, InstanceID: instanceId, OffsetNum: offset, Ranges: ranges}
+}
+
+func peerIds(peers []*selector.Peer) []string {
+	ids := make([]string, 0, len(peers))
+	for _, p := range peers {
+		ids = append(ids, p.InstanceID)
+	}
+	return ids
+}
+
+func TestSelectPeersRanking(t *testing.T) {
+	tests := []struct {
+		name          string
+		processes     []*dto.ModelFileProcessDto
+		startPos      int64
+		exclude       []string
+		maxCandidates int
+		want          []string
+	}{
+		{
+			name:      "more data first",
+			processes: []*dto.ModelFileProcessDto{processDto(1, "speed-b", 1<<19, ""), processDto(2, "speed-a", 1<<20, "")},
+			want:      []string{"speed-a", "speed-b"},
+		},
+		{
+			name: "requester and excluded skipped",
+			processes: []*dto.ModelFileProcessDto{processDto(1, "speed-r", 1<<20, ""), processDto(2, "speed-a", 1<<20, ""),
+				processDto(3, "speed-b", 1<<20, "")},
+			exclude: []string{"speed-b"},
+			want:    []string{"speed-a"},
+		},
+		{
+			name: "no data at start position",
+			processes: []*dto.ModelFileProcessDto{processDto(1, "speed-a", 100, "[[0,100],[600000,1048576]]"),
+				processDto(2, "speed-b", 1<<20, "")},
+			startPos: 500000,
+			want:     []string{"speed-b"},
+		},
+		{
+			name:      "stale peer after fresh",
+			processes: []*dto.ModelFileProcessDto{processDto(1, "speed-x", 1<<20, ""), processDto(2, "speed-a", 1<<19, "")},
+			want:      []string{"speed-a", "speed-x"},
+		},
+		{
+			name: "truncated to max candidates",
+			processes: []*dto.ModelFileProcessDto{processDto(1, "speed-a", 1<<20, ""), processDto(2, "speed-b", 1<<19, ""),
+				processDto(3, "speed-c", 1<<18, "")},
+			maxCandidates: 2,
+			want:          []string{"speed-a", "speed-b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestScheduler(t)
+			config.SysConfig.Scheduler.MaxCandidates = tt.maxCandidates
+			for i, id := range []string{"speed-r", "speed-a", "speed-b", "speed-c"} {
+				s.register(t, id, int32(8000+i))
+			}
+			selReq := &selector.Request{InstanceID: "speed-r", Etag: lfsEtag, StartPos: tt.startPos, FileSize: 1 << 20}
+			ranked, history := s.selectPeers(tt.processes, selReq, tt.exclude...)
+			if got := peerIds(ranked); !slices.Equal(got, tt.want) {
+				t.Fatalf("ranked = %v, want %v", got, tt.want)
+			}
+			if len(history) != len(tt.processes) {
+				t.Fatalf("history has %d processes, want %d", len(history), len(tt.processes))
+			}
+			if len(ranked) > 0 && (!ranked[0].Fresh || ranked[0].Port == 0) {
+				t.Fatalf("first candidate %+v has no heartbeat", ranked[0])
+			}
+		})
+	}
+}
+
+func TestAssignRanges(t *testing.T) {
+	const mb = 1 << 20
+	peer := func(instanceId string, fresh bool, ranges string) *selector.Peer {
+		return &selector.Peer{InstanceID: instanceId, Fresh: fresh, Process: processDto(1, instanceId, 0, ranges)}
+	}
+	tests := []struct {
+		name     string
+		peers    []*selector.Peer
+		startPos int64
+		fileSize int64
+		want     []string // 各区间分配的节点，空串为回源
+	}{
+		{
+			name:     "spread across peers",
+			peers:    []*selector.Peer{peer("speed-a", true, "[[0,4194304]]"), peer("speed-b", true, "[[0,4194304]]")},
+			fileSize: 4 * mb,
+			want:     []string{"speed-a", "speed-b", "speed-a", "speed-b"},
+		},
+		{
+			name:     "ranges no peer holds from origin",
+			peers:    []*selector.Peer{peer("speed-a", true, "[[0,2097152]]")},
+			fileSize: 4 * mb,
+			want:     []string{"speed-a", "speed-a", "", ""},
+		},
+		{
+			name:     "start position and short tail",
+			peers:    []*selector.Peer{peer("speed-a", true, "[[0,3670016]]")},
+			startPos: 2 * mb,
+			fileSize: 3*mb + mb/2,
+			want:     []string{"speed-a", "speed-a"},
+		},
+		{
+			name:     "stale peers not assigned",
+			peers:    []*selector.Peer{peer("speed-a", false, "[[0,4194304]]")},
+			fileSize: 4 * mb,
+		},
+		{
+			name:     "file below min size",
+			peers:    []*selector.Peer{peer("speed-a", true, "[[0,524288]]")},
+			fileSize: mb / 2,
+		},
+	}
+	config.SysConfig = &config.Config{}
+	config.SysConfig.SetDefaults()
+	config.SysConfig.Scheduler.Stripe.MinFileSize = 1
+	config.SysConfig.Scheduler.Stripe.RangeSize = 1
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges := assignRanges(tt.peers, &selector.Request{InstanceID: "speed-r", StartPos: tt.startPos, FileSize: tt.fileSize})
+			if tt.want == nil {
+				if ranges != nil {
+					t.Fatalf("ranges = %v, want nil", ranges)
+				}
+				return
+			}
+			got := make([]string, 0, len(ranges))
+			for i, r := range ranges {
+				if wantStart := tt.startPos + int64(i)*mb; r.StartPos != wantStart || r.EndPos != min(wantStart+mb, tt.fileSize) {
+					t.Fatalf("range %d = [%d, %d)", i, r.StartPos, r.EndPos)
+				}
+				got = append(got, r.InstanceId)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("assigned = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// downloaded 节点完整下载了文件
+func (s *testScheduler) downloaded(t *testing.T, instanceId string) int64 {
+	t.Helper()
+	resp, err := s.SchedulerFile(context.Background(), fileRequest(instanceId))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.ReportFileProcess(context.Background(), &pb.FileProcessRequest{ProcessId: resp.ProcessId, StaPos: 0,
+		EndPos: 1 << 20, Status: consts.StatusDownloaded}); err != nil {
+		t.Fatal(err)
+	}
+	s.progress.Flush()
+	return resp.ProcessId
+}
+
+func TestSchedulerFileSkipsSaturatedMaster(t *testing.T) {
+	s := newTestScheduler(t)
+	ctx := context.Background()
+	registered, err := s.Register(ctx, &pb.RegisterRequest{InstanceId: "speed-a", Host: "127.0.0.1", Port: 8001, Online: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.register(t, "speed-b", 8002)
+	s.downloaded(t, "speed-a")
+	s.downloaded(t, "speed-b")
+	if _, err = s.Heartbeat(ctx, &pb.HeartbeatRequest{Id: registered.Id, InstanceId: "speed-a", Online: true,
+		ActiveUploads: 10, MaxUploads: 10}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := s.SchedulerFile(ctx, fileRequest("speed-c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.MasterInstanceId != "speed-b" {
+		t.Fatalf("master = %s, want unsaturated speed-b", resp.MasterInstanceId)
+	}
+	if len(resp.Candidates) != 2 || resp.Candidates[1].InstanceId != "speed-a" || resp.Candidates[1].Load < 1 {
+		t.Fatalf("candidates = %+v, want saturated speed-a last", resp.Candidates)
+	}
+}
+
+func TestRescheduleFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		instanceId string
+		failed     string
+		offset     int64
+		wantErr    bool
+		wantMaster string
+	}{
+		{name: "failed master replaced", instanceId: "speed-c", failed: "speed-a", wantMaster: "speed-b"},
+		{name: "no peer beyond offset", instanceId: "speed-c", failed: "speed-a", offset: 768 << 10, wantMaster: ""},
+		{name: "without failed master", instanceId: "speed-c", wantMaster: "speed-a"},
+		{name: "partial peer skipped beyond offset", instanceId: "speed-c", offset: 768 << 10, wantMaster: "speed-a"},
+		{name: "process of another instance", instanceId: "speed-b", failed: "speed-a", wantErr: true},
+		{name: "missing instance", failed: "speed-a", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestScheduler(t)
+			ctx := context.Background()
+			for i, id := range []string{"speed-a", "speed-b", "speed-c"} {
+				s.register(t, id, int32(8001+i))
+			}
+			s.downloaded(t, "speed-a")
+			partial, err := s.SchedulerFile(ctx, fileRequest("speed-b"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = s.ReportFileProcess(ctx, &pb.FileProcessRequest{ProcessId: partial.ProcessId, StaPos: 0,
+				EndPos: 512 << 10, Status: consts.StatusDownloading}); err != nil {
+				t.Fatal(err)
+			}
+			s.progress.Flush()
+			first, err := s.SchedulerFile(ctx, fileRequest("speed-c"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := s.RescheduleFile(ctx, &pb.RescheduleFileRequest{InstanceId: tt.instanceId, ProcessId: first.ProcessId,
+				FailedInstanceId: tt.failed, Offset: tt.offset})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", resp)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.ProcessId != first.ProcessId || resp.MasterInstanceId != tt.wantMaster {
+				t.Fatalf("resp = %+v, want master %q", resp, tt.wantMaster)
+			}
+			if tt.wantMaster == "" && resp.SchedulerType != consts.SchedulerNo {
+				t.Fatalf("scheduler type = %d, want origin", resp.SchedulerType)
+			}
+			process, err := s.modelFileProcessDao.GetById(first.ProcessId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if process.MasterInstanceID != tt.wantMaster {
+				t.Fatalf("stored master = %q, want %q", process.MasterInstanceID, tt.wantMaster)
+			}
+		})
+	}
+}
+
+// TestSchedulerFileSameEtagFallback 当前文件没有可用的节点时，使用持有相同etag其他文件的节点，并返回该文件的位置
+func TestSchedulerFileSameEtagFallback(t *testing.T) {
+	const otherEtag = "2222222222222222222222222222222222222222222222222222222222222222"
+	tests := []struct {
+		name       string
+		ha         bool
+		ownPeer    bool   // 当前文件已有节点下载完成
+		etag       string // 请求文件的etag
+		wantMaster string
+		wantSource *pb.FileSource
+	}{
+		{name: "alias peer", etag: lfsEtag, wantMaster: "speed-a",
+			wantSource: &pb.FileSource{Datatype: "models", Org: "org", Repo: "repo", Name: "model.bin"}},
+		{name: "alias peer with HA", ha: true, etag: lfsEtag, wantMaster: "speed-a",
+			wantSource: &pb.FileSource{Datatype: "models", Org: "org", Repo: "repo", Name: "model.bin"}},
+		{name: "own peer preferred", ownPeer: true, etag: lfsEtag, wantMaster: "speed-c"},
+		{name: "different etag", etag: otherEtag},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestScheduler(t)
+			config.SysConfig.Scheduler.HA.Enabled = tt.ha
+			ctx := context.Background()
+			for i, id := range []string{"speed-a", "speed-b", "speed-c"} {
+				s.register(t, id, int32(8001+i))
+			}
+			s.downloaded(t, "speed-a")
+			copyRequest := func(instanceId string) *pb.SchedulerFileRequest {
+				req := fileRequest(instanceId)
+				req.Repo, req.Name, req.Etag = "repo-copy", "weights.bin", tt.etag
+				return req
+			}
+			if tt.ownPeer {
+				resp, err := s.SchedulerFile(ctx, copyRequest("speed-c"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err = s.ReportFileProcess(ctx, &pb.FileProcessRequest{ProcessId: resp.ProcessId, StaPos: 0, EndPos: 1 << 20,
+					Status: consts.StatusDownloaded}); err != nil {
+					t.Fatal(err)
+				}
+				s.progress.Flush()
+			}
+
+			resp, err := s.SchedulerFile(ctx, copyRequest("speed-b"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.MasterInstanceId != tt.wantMaster {
+				t.Fatalf("master = %q, want %q", resp.MasterInstanceId, tt.wantMaster)
+			}
+			if !proto.Equal(resp.MasterSource, tt.wantSource) {
+				t.Fatalf("master source = %+v, want %+v", resp.MasterSource, tt.wantSource)
+			}
+		})
+	}
+}
+
+// TestSchedulerRepo 整仓调度按请求顺序返回各文件的结果，已有记录按下载进度调度，新文件回源，重复的文件单独报错
+func TestSchedulerRepo(t *testing.T) {
+	tests := []struct {
+		name  string
+		stale bool // 发起调度的副本查询不到其他副本新建的记录
+	}{
+		{name: "same replica"},
+		{name: "record created by other replica", stale: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.SysConfig = &config.Config{}
+			config.SysConfig.SetDefaults()
+			db := memory.NewDB()
+			first := newTestReplica(t, db, memory.NewRecordStore(db))
+			second := first
+			if tt.stale {
+				second = newTestReplica(t, db, &staleRecordStore{RecordStore: memory.NewRecordStore(db)})
+			}
+			ctx := context.Background()
+			first.register(t, "speed-a", 8001)
+			first.register(t, "speed-b", 8002)
+			first.downloaded(t, "speed-a")
+
+			req := &pb.SchedulerRepoRequest{DataType: "models", Org: "org", Repo: "repo", InstanceId: "speed-b", Files: []*pb.RepoFile{
+				{Name: "model.bin", Etag: lfsEtag, FileSize: 1 << 20},
+				{Name: "config.json", Etag: "config-etag", FileSize: 100},
+				{Name: "model.bin", Etag: lfsEtag, FileSize: 1 << 20},
+			}}
+			resp, err := second.SchedulerRepo(ctx, req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wants := []struct {
+				master   string
+				errorMsg string
+			}{
+				{master: "speed-a"},
+				{master: ""},
+				{errorMsg: "duplicate file"},
+			}
+			if len(resp.Plans) != len(wants) {
+				t.Fatalf("got %d plans, want %d", len(resp.Plans), len(wants))
+			}
+			processIds := make([]int64, 0, 2)
+			for i, want := range wants {
+				plan := resp.Plans[i]
+				if plan.Name != req.Files[i].Name || plan.ErrorMsg != want.errorMsg {
+					t.Fatalf("plan %d = %+v, want error %q", i, plan, want.errorMsg)
+				}
+				if want.errorMsg != "" {
+					continue
+				}
+				if plan.Result == nil || plan.Result.MasterInstanceId != want.master || plan.Result.ProcessId == 0 {
+					t.Fatalf("plan %d result = %+v, want master %q", i, plan.Result, want.master)
+				}
+				processIds = append(processIds, plan.Result.ProcessId)
+			}
+
+			// 再次调度时复用已有的下载进度
+			again, err := second.SchedulerRepo(ctx, req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, id := range processIds {
+				if got := again.Plans[i].Result.GetProcessId(); got != id {
+					t.Fatalf("plan %d process = %d, want %d", i, got, id)
+				}
+			}
+		})
+	}
+	if _, err := newTestScheduler(t).SchedulerRepo(context.Background(), &pb.SchedulerRepoRequest{DataType: "models"}); err == nil {
+		t.Fatal("expected error for invalid request")
+	}
+}
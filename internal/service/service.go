@@ -17,4 +17,5 @@ package service
 import "github.com/google/wire"
 
 var ServiceProvider = wire.NewSet(NewSchedulerService, NewSysService, NewCacheJobService, NewRepositoryService,
-	NewTagService, NewOrganizationService, NewHfTokenService, NewManagerService)
+	NewTagService, NewOrganizationService, NewHfTokenService, NewManagerService, NewDingospeedService, NewLivenessService, NewProgressService, NewFileIndex, NewIntegrityService,
+	NewLeaderService, NewSessionRelay)
@@ -0,0 +1,241 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/model"
+	"dingoscheduler/internal/session"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/event"
+	pb "dingoscheduler/pkg/proto/manager"
+
+	"github.com/bytedance/sonic"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	sessionOwnerPrefix = "session/"  // 节点长连接归属租约的名称前缀，持有者为建立连接的副本
+	RelayPollInterval  = time.Second // 轮询转发命令及回执的间隔
+)
+
+var ErrRelayTimeout = errors.New("relayed command timeout")
+
+// SessionRelay 向dingospeed下发需要回执的命令。多副本部署时节点的长连接只建立在其中一个副本上，
+// 各副本以租约登记本副本上的连接，本副本没有连接时经事件表转发给持有连接的副本执行，再从事件表读取回执。
+type SessionRelay struct {
+	sessionManager *session.Manager
+	leaseDao       dao.LeaseStore
+	eventDao       dao.EventStore
+	holder         string
+	requestId      atomic.Int64
+	ownedMu        sync.Mutex
+	owned          map[string]struct{}
+	mu             sync.Mutex
+	replies        map[string]chan *event.SessionReply
+}
+
+func NewSessionRelay(sessionManager *session.Manager, leaseDao dao.LeaseStore, eventDao dao.EventStore) *SessionRelay {
+	return &SessionRelay{
+		sessionManager: sessionManager,
+		leaseDao:       leaseDao,
+		eventDao:       eventDao,
+		holder:         dao.Holder(),
+		owned:          make(map[string]struct{}),
+		replies:        make(map[string]chan *event.SessionReply),
+	}
+}
+
+// Call 下发命令并等待回执，本副本及其他副本都没有该节点的长连接时返回session.ErrNoSession
+func (r *SessionRelay) Call(instanceId string, cmd *pb.SessionCommand) error {
+	err := r.sessionManager.Call(instanceId, cmd)
+	if !errors.Is(err, session.ErrNoSession) || !config.SysConfig.GetHAEnabled() {
+		return err
+	}
+	owner, err := r.leaseDao.Holder(sessionOwnerPrefix + instanceId)
+	if err != nil {
+		return err
+	}
+	if owner == "" || owner == r.holder {
+		return session.ErrNoSession
+	}
+	return r.forward(owner, instanceId, cmd)
+}
+
+func (r *SessionRelay) forward(owner, instanceId string, cmd *pb.SessionCommand) error {
+	b, err := proto.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+	requestId := fmt.Sprintf("%s-%d", r.holder, r.requestId.Add(1))
+	payload, err := sonic.Marshal(&event.SessionCommand{RequestID: requestId, From: r.holder, To: owner, InstanceID: instanceId, Command: b})
+	if err != nil {
+		return err
+	}
+	ch := make(chan *event.SessionReply, 1)
+	r.mu.Lock()
+	r.replies[requestId] = ch
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.replies, requestId)
+		r.mu.Unlock()
+	}()
+	if err = r.eventDao.Append(event.TopicSessionCommand, payload); err != nil {
+		return err
+	}
+	// 持有者轮询到命令、执行并写回回执，各需要一个轮询间隔
+	timer := time.NewTimer(config.SysConfig.GetCommandTimeout() + 2*RelayPollInterval)
+	defer timer.Stop()
+	select {
+	case reply := <-ch:
+		if reply.NoSession {
+			return session.ErrNoSession
+		}
+		if reply.ErrorMsg != "" {
+			return errors.New(reply.ErrorMsg)
+		}
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("instance %s command relayed to %s: %w", instanceId, owner, ErrRelayTimeout)
+	}
+}
+
+// Renew 登记或续约本副本上长连接的归属，释放已断开连接的归属
+func (r *SessionRelay) Renew() {
+	r.ownedMu.Lock()
+	defer r.ownedMu.Unlock()
+	lease := config.SysConfig.GetLeaderLease()
+	current := make(map[string]struct{})
+	for _, instanceId := range r.sessionManager.InstanceIds() {
+		current[instanceId] = struct{}{}
+		// 连接从其他副本迁移过来时，等原持有者释放或租约过期后才能取得
+		ok, err := r.leaseDao.Acquire(sessionOwnerPrefix+instanceId, r.holder, lease)
+		if err != nil {
+			zap.S().Errorf("acquire session owner %s err.%v", instanceId, err)
+			continue
+		}
+		if ok {
+			r.owned[instanceId] = struct{}{}
+		}
+	}
+	for instanceId := range r.owned {
+		if _, ok := current[instanceId]; ok {
+			continue
+		}
+		if err := r.leaseDao.Release(sessionOwnerPrefix+instanceId, r.holder); err != nil {
+			zap.S().Errorf("release session owner %s err.%v", instanceId, err)
+			continue
+		}
+		delete(r.owned, instanceId)
+	}
+}
+
+// ReleaseAll 副本停止时释放全部连接的归属
+func (r *SessionRelay) ReleaseAll() {
+	r.ownedMu.Lock()
+	defer r.ownedMu.Unlock()
+	for instanceId := range r.owned {
+		if err := r.leaseDao.Release(sessionOwnerPrefix+instanceId, r.holder); err != nil {
+			zap.S().Errorf("release session owner %s err.%v", instanceId, err)
+		}
+		delete(r.owned, instanceId)
+	}
+}
+
+// LastEventId 返回最新事件的id，副本启动时从此处开始轮询
+func (r *SessionRelay) LastEventId() (int64, error) {
+	return r.eventDao.LastId()
+}
+
+// Poll 执行lastId之后转发给本副本的命令，分发发给本副本的回执，返回已处理的最后一个事件的id
+func (r *SessionRelay) Poll(lastId int64) (int64, error) {
+	for {
+		rows, err := r.eventDao.ListAfter(lastId, eventBatchSize)
+		if err != nil {
+			return lastId, err
+		}
+		for _, row := range rows {
+			lastId = row.ID
+			switch row.Topic {
+			case event.TopicSessionCommand:
+				r.handleCommand(row)
+			case event.TopicSessionReply:
+				r.handleReply(row)
+			}
+		}
+		if len(rows) < eventBatchSize {
+			return lastId, nil
+		}
+	}
+}
+
+func (r *SessionRelay) handleCommand(row *model.SchedulerEvent) {
+	var relayed event.SessionCommand
+	if err := sonic.UnmarshalString(row.Payload, &relayed); err != nil {
+		zap.S().Errorf("unmarshal event %d err.%v", row.ID, err)
+		return
+	}
+	if relayed.To != r.holder {
+		return
+	}
+	// 等待回执期间不阻塞后续事件的处理
+	go func() {
+		reply := &event.SessionReply{RequestID: relayed.RequestID, To: relayed.From}
+		cmd := &pb.SessionCommand{}
+		err := proto.Unmarshal(relayed.Command, cmd)
+		if err == nil {
+			err = r.sessionManager.Call(relayed.InstanceID, cmd)
+		}
+		if errors.Is(err, session.ErrNoSession) {
+			reply.NoSession = true
+		} else if err != nil {
+			reply.ErrorMsg = err.Error()
+		}
+		b, err := sonic.Marshal(reply)
+		if err == nil {
+			err = r.eventDao.Append(event.TopicSessionReply, b)
+		}
+		if err != nil {
+			zap.S().Errorf("reply relayed command %s err.%v", relayed.RequestID, err)
+		}
+	}()
+}
+
+func (r *SessionRelay) handleReply(row *model.SchedulerEvent) {
+	var reply event.SessionReply
+	if err := sonic.UnmarshalString(row.Payload, &reply); err != nil {
+		zap.S().Errorf("unmarshal event %d err.%v", row.ID, err)
+		return
+	}
+	if reply.To != r.holder {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if ch, ok := r.replies[reply.RequestID]; ok {
+		select {
+		case ch <- &reply:
+		default:
+		}
+	}
+}
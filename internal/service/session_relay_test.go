@@ -0,0 +1,151 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"dingoscheduler/internal/dao/memory"
+	"dingoscheduler/internal/session"
+	"dingoscheduler/pkg/config"
+	pb "dingoscheduler/pkg/proto/manager"
+
+	"google.golang.org/grpc"
+)
+
+// ackStream 收到命令后按success回执的长连接
+type ackStream struct {
+	grpc.ServerStream
+	sess     *session.Session
+	success  bool
+	received chan *pb.SessionCommand
+}
+
+func (s *ackStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *ackStream) Send(cmd *pb.SessionCommand) error {
+	s.received <- cmd
+	go s.sess.Ack(&pb.CommandAck{CommandId: cmd.CommandId, Success: s.success, ErrorMsg: "disk full"})
+	return nil
+}
+
+func (s *ackStream) Recv() (*pb.SessionRequest, error) {
+	select {}
+}
+
+func newTestRelay(db *memory.DB, holder string) *SessionRelay {
+	r := NewSessionRelay(session.NewManager(), memory.NewLeaseStore(db), memory.NewEventStore(db))
+	// 同一进程内模拟两个副本，使用不同的持有者标识
+	r.holder = holder
+	return r
+}
+
+// callRelayed 在from上下发命令，同时轮询两个副本的事件表，直到命令返回
+func callRelayed(t *testing.T, from, to *SessionRelay, instanceId string) error {
+	t.Helper()
+	done := make(chan error, 1)
+	go func() {
+		done <- from.Call(instanceId, &pb.SessionCommand{Body: &pb.SessionCommand_EvictRepo{EvictRepo: &pb.EvictRepoCommand{
+			Datatype: "models", Org: "org", Repo: "repo"}}})
+	}()
+	var fromId, toId int64
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-deadline:
+			t.Fatal("relayed command not finished")
+		case <-time.After(10 * time.Millisecond):
+		}
+		var err error
+		if toId, err = to.Poll(toId); err != nil {
+			t.Fatal(err)
+		}
+		if fromId, err = from.Poll(fromId); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSessionRelayAcrossReplicas(t *testing.T) {
+	tests := []struct {
+		name    string
+		success bool
+		wantErr string
+	}{
+		{name: "acked", success: true},
+		{name: "failed ack", success: false, wantErr: "disk full"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.SysConfig = &config.Config{}
+			config.SysConfig.SetDefaults()
+			config.SysConfig.Scheduler.HA.Enabled = true
+			db := memory.NewDB()
+			owner, other := newTestRelay(db, "replica-a"), newTestRelay(db, "replica-b")
+			stream := &ackStream{success: tt.success, received: make(chan *pb.SessionCommand, 1)}
+			stream.sess = owner.sessionManager.Open("speed-a", stream)
+			owner.Renew()
+
+			err := callRelayed(t, other, owner, "speed-a")
+			if tt.wantErr == "" && err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+			select {
+			case cmd := <-stream.received:
+				if cmd.GetEvictRepo().GetRepo() != "repo" {
+					t.Fatalf("command = %+v", cmd)
+				}
+			default:
+				t.Fatal("command not delivered to the owning replica")
+			}
+		})
+	}
+}
+
+func TestSessionRelayClosedSession(t *testing.T) {
+	config.SysConfig = &config.Config{}
+	config.SysConfig.SetDefaults()
+	config.SysConfig.Scheduler.HA.Enabled = true
+	db := memory.NewDB()
+	owner, other := newTestRelay(db, "replica-a"), newTestRelay(db, "replica-b")
+	stream := &ackStream{success: true, received: make(chan *pb.SessionCommand, 1)}
+	stream.sess = owner.sessionManager.Open("speed-a", stream)
+	owner.Renew()
+
+	// 连接已断开但归属尚未释放时，持有者回执没有连接
+	owner.sessionManager.Close(stream.sess)
+	if err := callRelayed(t, other, owner, "speed-a"); !errors.Is(err, session.ErrNoSession) {
+		t.Fatalf("err = %v, want no session", err)
+	}
+	// 释放归属后不再转发
+	owner.Renew()
+	if err := other.Call("speed-a", &pb.SessionCommand{}); !errors.Is(err, session.ErrNoSession) {
+		t.Fatalf("err = %v, want no session", err)
+	}
+	if err := other.Call("speed-b", &pb.SessionCommand{}); !errors.Is(err, session.ErrNoSession) {
+		t.Fatalf("err = %v, want no session", err)
+	}
+}
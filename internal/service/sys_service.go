@@ -16,14 +16,16 @@ import (
 var once sync.Once
 
 type SysService struct {
-	repositoryDao *dao.RepositoryDao
-	cacheJobDao   *dao.CacheJobDao
+	repositoryDao dao.RepositoryStore
+	cacheJobDao   dao.CacheJobStore
+	leaderService *LeaderService
 }
 
-func NewSysService(repositoryDao *dao.RepositoryDao, cacheJobDao *dao.CacheJobDao) *SysService {
+func NewSysService(repositoryDao dao.RepositoryStore, cacheJobDao dao.CacheJobStore, leaderService *LeaderService) *SysService {
 	sysSvc := &SysService{}
 	sysSvc.repositoryDao = repositoryDao
 	sysSvc.cacheJobDao = cacheJobDao
+	sysSvc.leaderService = leaderService
 	once.Do(
 		func() {
 			if config.SysConfig.GetEnablePersistRepo() {
@@ -43,6 +45,10 @@ func NewSysService(repositoryDao *dao.RepositoryDao, cacheJobDao *dao.CacheJobDa
 func (s SysService) startPersistRepo() {
 	c := cron.New(cron.WithSeconds())
 	_, err := c.AddFunc(config.SysConfig.GetPersistRepoCron(), func() {
+		// 每个副本都注册定时任务，只在主副本执行
+		if !s.leaderService.IsLeader() {
+			return
+		}
 		instanceIds := config.SysConfig.Scheduler.PersistRepo.InstanceIds
 		if instanceIds != "" {
 			instanceIdSlice := strings.Split(instanceIds, ",")
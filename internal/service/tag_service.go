@@ -9,10 +9,10 @@ import (
 )
 
 type TagService struct {
-	tagDao *dao.TagDao
+	tagDao dao.TagStore
 }
 
-func NewTagService(tagDao *dao.TagDao) *TagService {
+func NewTagService(tagDao dao.TagStore) *TagService {
 	return &TagService{
 		tagDao: tagDao,
 	}
@@ -0,0 +1,167 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"dingoscheduler/pkg/config"
+	pb "dingoscheduler/pkg/proto/manager"
+
+	"github.com/google/wire"
+	"go.uber.org/zap"
+)
+
+var SessionProvider = wire.NewSet(NewManager)
+
+// ErrNoSession 节点未建立长连接，调用方可退回HTTP方式
+var ErrNoSession = errors.New("instance has no session")
+
+// Session 与一个dingospeed实例之间的长连接
+type Session struct {
+	instanceId string
+	stream     pb.Manager_SessionServer
+	sendMu     sync.Mutex
+	mu         sync.Mutex
+	pending    map[int64]chan *pb.CommandAck
+}
+
+func (s *Session) InstanceId() string {
+	return s.instanceId
+}
+
+func (s *Session) send(cmd *pb.SessionCommand) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	return s.stream.Send(cmd)
+}
+
+// Ack 处理dingospeed返回的指令应答
+func (s *Session) Ack(ack *pb.CommandAck) {
+	s.mu.Lock()
+	ch, ok := s.pending[ack.CommandId]
+	delete(s.pending, ack.CommandId)
+	s.mu.Unlock()
+	if ok {
+		ch <- ack
+	}
+}
+
+func (s *Session) wait(commandId int64) chan *pb.CommandAck {
+	ch := make(chan *pb.CommandAck, 1)
+	s.mu.Lock()
+	s.pending[commandId] = ch
+	s.mu.Unlock()
+	return ch
+}
+
+func (s *Session) cancel(commandId int64) {
+	s.mu.Lock()
+	delete(s.pending, commandId)
+	s.mu.Unlock()
+}
+
+// Manager 管理所有dingospeed的长连接，每个实例只保留最新建立的连接
+type Manager struct {
+	mu        sync.RWMutex
+	sessions  map[string]*Session
+	commandId atomic.Int64
+}
+
+func NewManager() *Manager {
+	return &Manager{
+		sessions: make(map[string]*Session),
+	}
+}
+
+// Open 登记实例的连接，替换该实例已有的连接
+func (m *Manager) Open(instanceId string, stream pb.Manager_SessionServer) *Session {
+	sess := &Session{
+		instanceId: instanceId,
+		stream:     stream,
+		pending:    make(map[int64]chan *pb.CommandAck),
+	}
+	m.mu.Lock()
+	m.sessions[instanceId] = sess
+	m.mu.Unlock()
+	zap.S().Infof("session open, instanceId:%s", instanceId)
+	return sess
+}
+
+// Close 连接断开时移除，已被新连接替换的不做处理
+func (m *Manager) Close(sess *Session) {
+	m.mu.Lock()
+	if m.sessions[sess.instanceId] == sess {
+		delete(m.sessions, sess.instanceId)
+	}
+	m.mu.Unlock()
+	zap.S().Infof("session closed, instanceId:%s", sess.instanceId)
+}
+
+// InstanceIds 在本进程建立了长连接的节点
+func (m *Manager) InstanceIds() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ids := make([]string, 0, len(m.sessions))
+	for id := range m.sessions {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (m *Manager) get(instanceId string) *Session {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.sessions[instanceId]
+}
+
+// Notify 下发指令，不等待应答
+func (m *Manager) Notify(instanceId string, cmd *pb.SessionCommand) error {
+	sess := m.get(instanceId)
+	if sess == nil {
+		return ErrNoSession
+	}
+	cmd.CommandId = m.commandId.Add(1)
+	return sess.send(cmd)
+}
+
+// Call 下发指令并等待dingospeed执行完成的应答，超时时间为scheduler.session.commandTimeout
+func (m *Manager) Call(instanceId string, cmd *pb.SessionCommand) error {
+	sess := m.get(instanceId)
+	if sess == nil {
+		return ErrNoSession
+	}
+	cmd.CommandId = m.commandId.Add(1)
+	ch := sess.wait(cmd.CommandId)
+	defer sess.cancel(cmd.CommandId)
+	if err := sess.send(cmd); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(sess.stream.Context(), config.SysConfig.GetCommandTimeout())
+	defer cancel()
+	select {
+	case ack := <-ch:
+		if !ack.Success {
+			return fmt.Errorf("instance %s command %d failed: %s", instanceId, cmd.CommandId, ack.ErrorMsg)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("instance %s command %d: %w", instanceId, cmd.CommandId, ctx.Err())
+	}
+}
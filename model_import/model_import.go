@@ -225,8 +225,9 @@ func main() {
 
 		zap.S().Infof("成功生成ModelFileRecord CSV文件，包含 %d 条新记录: model_file_record.csv", len(newRecords))
 		zap.S().Infof("=== MySQL导入命令参考 ===")
+		// IGNORE按唯一键跳过已存在的行，重复导入不会产生重复数据
 		zap.S().Infof("LOAD DATA INFILE '/path/to/model_file_record.csv'")
-		zap.S().Infof("INTO TABLE model_file_record")
+		zap.S().Infof("IGNORE INTO TABLE model_file_record")
 		zap.S().Infof("FIELDS TERMINATED BY ',' ENCLOSED BY '\"' ESCAPED BY '\\\\'")
 		zap.S().Infof("LINES TERMINATED BY '\\r\\n'")
 		zap.S().Infof("IGNORE 1 ROWS (datatype, org, repo, name, etag, file_size);")
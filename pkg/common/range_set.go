@@ -0,0 +1,129 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bytedance/sonic"
+)
+
+// MaxStoredRanges 数据库中保存的区间数上限。超出的区间不保存，只会让节点少报已有的数据，不会误报
+const MaxStoredRanges = 1000
+
+// Range 左闭右开的字节区间[Start, End)
+type Range struct {
+	Start int64
+	End   int64
+}
+
+// RangeSet 有序且互不相交、互不相邻的已完成区间集合
+type RangeSet []Range
+
+// ParseRangeSet 解析数据库中保存的区间，格式为[[0,100],[200,300]]，空串表示空集合。
+func ParseRangeSet(s string) (RangeSet, error) {
+	if s == "" {
+		return RangeSet{}, nil
+	}
+	var pairs [][2]int64
+	if err := sonic.UnmarshalString(s, &pairs); err != nil {
+		return nil, fmt.Errorf("parse range set %q: %w", s, err)
+	}
+	rs := RangeSet{}
+	for _, p := range pairs {
+		rs = rs.Add(p[0], p[1])
+	}
+	return rs, nil
+}
+
+// String 序列化为ParseRangeSet可识别的格式
+func (rs RangeSet) String() string {
+	if len(rs) == 0 {
+		return ""
+	}
+	pairs := make([][2]int64, 0, len(rs))
+	for _, r := range rs {
+		pairs = append(pairs, [2]int64{r.Start, r.End})
+	}
+	s, _ := sonic.MarshalString(pairs)
+	return s
+}
+
+// Add 并入区间[start, end)，返回合并后的新集合
+func (rs RangeSet) Add(start, end int64) RangeSet {
+	if start < 0 {
+		start = 0
+	}
+	if end <= start {
+		return rs
+	}
+	merged := make(RangeSet, 0, len(rs)+1)
+	merged = append(merged, rs...)
+	merged = append(merged, Range{Start: start, End: end})
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Start < merged[j].Start })
+	out := merged[:1]
+	for _, r := range merged[1:] {
+		last := &out[len(out)-1]
+		if r.Start <= last.End {
+			if r.End > last.End {
+				last.End = r.End
+			}
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// Truncate 保留起始位置最小的n个区间，从0开始的连续进度总在其中
+func (rs RangeSet) Truncate(n int) RangeSet {
+	if len(rs) <= n {
+		return rs
+	}
+	return rs[:n]
+}
+
+// Contains 判断[start, end)是否已被完整覆盖
+func (rs RangeSet) Contains(start, end int64) bool {
+	if end <= start {
+		return true
+	}
+	return rs.ContiguousFrom(start) >= end
+}
+
+// ContiguousFrom 返回从pos开始连续可用数据的结束位置，pos未被覆盖时返回pos
+func (rs RangeSet) ContiguousFrom(pos int64) int64 {
+	for _, r := range rs {
+		if r.Start <= pos && pos < r.End {
+			return r.End
+		}
+	}
+	return pos
+}
+
+// Prefix 从0开始连续完成的字节数，即兼容原offset_num语义的进度
+func (rs RangeSet) Prefix() int64 {
+	return rs.ContiguousFrom(0)
+}
+
+// Size 已完成的总字节数
+func (rs RangeSet) Size() int64 {
+	var size int64
+	for _, r := range rs {
+		size += r.End - r.Start
+	}
+	return size
+}
@@ -0,0 +1,113 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package common
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRangeSetAdd(t *testing.T) {
+	tests := []struct {
+		name  string
+		input [][2]int64
+		want  RangeSet
+	}{
+		{name: "empty range ignored", input: [][2]int64{{10, 10}, {20, 5}}, want: RangeSet{}},
+		{name: "negative start clamped", input: [][2]int64{{-5, 10}}, want: RangeSet{{0, 10}}},
+		{name: "disjoint kept sorted", input: [][2]int64{{20, 30}, {0, 10}}, want: RangeSet{{0, 10}, {20, 30}}},
+		{name: "overlap merged", input: [][2]int64{{0, 10}, {5, 20}}, want: RangeSet{{0, 20}}},
+		{name: "adjacent merged", input: [][2]int64{{0, 10}, {10, 20}}, want: RangeSet{{0, 20}}},
+		{name: "contained absorbed", input: [][2]int64{{0, 100}, {20, 30}}, want: RangeSet{{0, 100}}},
+		{name: "gap filled", input: [][2]int64{{0, 10}, {20, 30}, {40, 50}, {5, 45}}, want: RangeSet{{0, 50}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := RangeSet{}
+			for _, r := range tt.input {
+				rs = rs.Add(r[0], r[1])
+			}
+			if !slices.Equal(rs, tt.want) {
+				t.Fatalf("got %v, want %v", rs, tt.want)
+			}
+		})
+	}
+}
+
+func TestRangeSetQueries(t *testing.T) {
+	rs := RangeSet{}.Add(0, 100).Add(200, 300)
+	tests := []struct {
+		start, end int64
+		contains   bool
+		contiguous int64
+	}{
+		{start: 0, end: 100, contains: true, contiguous: 100},
+		{start: 50, end: 100, contains: true, contiguous: 100},
+		{start: 50, end: 150, contains: false, contiguous: 100},
+		{start: 100, end: 200, contains: false, contiguous: 100},
+		{start: 250, end: 300, contains: true, contiguous: 300},
+		{start: 300, end: 300, contains: true, contiguous: 300},
+	}
+	for _, tt := range tests {
+		if got := rs.Contains(tt.start, tt.end); got != tt.contains {
+			t.Errorf("Contains(%d, %d) = %v, want %v", tt.start, tt.end, got, tt.contains)
+		}
+		if got := rs.ContiguousFrom(tt.start); got != tt.contiguous {
+			t.Errorf("ContiguousFrom(%d) = %d, want %d", tt.start, got, tt.contiguous)
+		}
+	}
+	if rs.Prefix() != 100 || rs.Size() != 200 {
+		t.Fatalf("prefix %d, size %d", rs.Prefix(), rs.Size())
+	}
+	if prefix := (RangeSet{}.Add(10, 20)).Prefix(); prefix != 0 {
+		t.Fatalf("prefix without byte 0 = %d", prefix)
+	}
+}
+
+func TestRangeSetRoundTrip(t *testing.T) {
+	for _, rs := range []RangeSet{{}, {{0, 100}}, {{0, 100}, {200, 300}}} {
+		parsed, err := ParseRangeSet(rs.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(parsed, rs) {
+			t.Fatalf("round trip %v -> %q -> %v", rs, rs.String(), parsed)
+		}
+	}
+	// 数据库中的区间未排序或有重叠时解析后合并
+	parsed, err := ParseRangeSet("[[200,300],[0,100],[50,150]]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (RangeSet{{0, 150}, {200, 300}}); !slices.Equal(parsed, want) {
+		t.Fatalf("parsed %v, want %v", parsed, want)
+	}
+	if _, err = ParseRangeSet("[[0,"); err == nil {
+		t.Fatal("malformed ranges parsed")
+	}
+}
+
+func TestRangeSetTruncate(t *testing.T) {
+	rs := RangeSet{}
+	for i := int64(0); i < 5; i++ {
+		rs = rs.Add(i*10, i*10+5)
+	}
+	if got := rs.Truncate(3); !slices.Equal(got, rs[:3]) || got.Prefix() != 5 {
+		t.Fatalf("truncated %v", got)
+	}
+	if got := rs.Truncate(10); len(got) != 5 {
+		t.Fatalf("truncated %v", got)
+	}
+}
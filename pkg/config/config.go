@@ -20,6 +20,8 @@ import (
 	"os"
 	"time"
 
+	"dingoscheduler/pkg/consts"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/gommon/log"
 	"go.uber.org/zap"
@@ -39,6 +41,34 @@ type Config struct {
 	Oss         Oss               `json:"oss" yaml:"oss"`
 	Proxy       Proxy             `json:"proxy" yaml:"proxy"`
 	Aidc        map[string]string `json:"aidc" yaml:"aidc"`
+	Topology    Topology          `json:"topology" yaml:"topology"`
+}
+
+// Topology 区域、aidc、实例的归属关系以及aidc/区域之间的链路
+type Topology struct {
+	Regions         []Region `json:"regions" yaml:"regions" validate:"dive"`
+	Links           []Link   `json:"links" yaml:"links" validate:"dive"`
+	SameRegionCost  int      `json:"sameRegionCost" yaml:"sameRegionCost" validate:"min=0"`   // 未配置链路时同区域aidc间的代价
+	CrossRegionCost int      `json:"crossRegionCost" yaml:"crossRegionCost" validate:"min=0"` // 未配置链路时跨区域aidc间的代价
+	OriginCost      int      `json:"originCost" yaml:"originCost" validate:"min=0"`           // 链路代价高于该值的节点不如直接回源，0表示不限制
+}
+
+type Region struct {
+	Name  string         `json:"name" yaml:"name" validate:"required"`
+	Aidcs []TopologyAidc `json:"aidcs" yaml:"aidcs" validate:"dive"`
+}
+
+type TopologyAidc struct {
+	Name      string   `json:"name" yaml:"name" validate:"required"`
+	Instances []string `json:"instances" yaml:"instances"`
+}
+
+// Link 两个aidc或两个区域之间的链路，双向生效
+type Link struct {
+	From      string `json:"from" yaml:"from" validate:"required"`
+	To        string `json:"to" yaml:"to" validate:"required"`
+	Cost      int    `json:"cost" yaml:"cost" validate:"min=0"`
+	Bandwidth int64  `json:"bandwidth" yaml:"bandwidth" validate:"min=0"` // 单位MB/s，0表示未知
 }
 
 type ServerConfig struct {
@@ -75,8 +105,17 @@ type Avatar struct {
 	Path string `yaml:"path"`
 }
 type Cache struct {
-	DefaultExpiration int `json:"defaultExpiration" yaml:"defaultExpiration" `
-	CleanupInterval   int `json:"cleanupInterval" yaml:"cleanupInterval"`
+	Type              string `json:"type" yaml:"type" validate:"omitempty,oneof=memory redis"` // memory、redis，多副本部署时使用redis共享缓存
+	DefaultExpiration int    `json:"defaultExpiration" yaml:"defaultExpiration" `
+	CleanupInterval   int    `json:"cleanupInterval" yaml:"cleanupInterval"`
+	Redis             Redis  `json:"redis" yaml:"redis"`
+}
+
+type Redis struct {
+	Addr     string `json:"addr" yaml:"addr"`
+	Password string `json:"password" yaml:"password"`
+	DB       int    `json:"db" yaml:"db" validate:"min=0"`
+	Prefix   string `json:"prefix" yaml:"prefix"` // 缓存key的前缀，多套环境共用一个redis时区分，默认为dingoscheduler:
 }
 
 type Oss struct {
@@ -94,6 +133,68 @@ type Scheduler struct {
 	Port          int32       `json:"port" yaml:"port"`
 	PersistRepo   PersistRepo `json:"persistRepo" yaml:"persistRepo"`
 	GlobalHfToken string      `json:"globalHfToken" yaml:"globalHfToken"`
+	MaxCandidates int         `json:"maxCandidates" yaml:"maxCandidates" validate:"min=0,max=50"`
+	Stripe        Stripe      `json:"stripe" yaml:"stripe"`
+	Load          Load        `json:"load" yaml:"load"`
+	Selector      Selector    `json:"selector" yaml:"selector"`
+	Liveness      Liveness    `json:"liveness" yaml:"liveness"`
+	Session       Session     `json:"session" yaml:"session"`
+	Progress      Progress    `json:"progress" yaml:"progress"`
+	Index         Index       `json:"index" yaml:"index"`
+	Integrity     Integrity   `json:"integrity" yaml:"integrity"`
+	HA            HA          `json:"ha" yaml:"ha"`
+	LockTimeout   int         `json:"lockTimeout" yaml:"lockTimeout" validate:"min=0"` // 单位秒，等待文件调度、缓存任务锁的最长时间
+}
+
+// HA 多副本部署，副本间通过数据库中的租约选主及互斥，各副本的时钟需保持同步
+type HA struct {
+	Enabled       bool   `json:"enabled" yaml:"enabled"`
+	NodeId        string `json:"nodeId" yaml:"nodeId"`                                // 副本标识，默认为主机名
+	LeaderLease   int    `json:"leaderLease" yaml:"leaderLease" validate:"min=0"`     // 单位秒，主副本失联超过该时间后由其他副本接替
+	RenewInterval int    `json:"renewInterval" yaml:"renewInterval" validate:"min=0"` // 单位秒，续约及竞选的间隔，须小于leaderLease
+	LockLease     int    `json:"lockLease" yaml:"lockLease" validate:"min=0"`         // 单位秒，分布式锁的租约时长，持有期间自动续约
+	LockWait      int    `json:"lockWait" yaml:"lockWait" validate:"min=0"`           // 单位秒，等待分布式锁的最长时间
+}
+
+type Integrity struct {
+	QuarantineTTL int    `json:"quarantineTTL" yaml:"quarantineTTL" validate:"min=0"` // 单位秒，提供损坏数据的节点不再作为该etag来源的时长
+	AlertWebhook  string `json:"alertWebhook" yaml:"alertWebhook"`                    // 节点被隔离时以JSON POST告警的地址，为空时只记录日志
+}
+
+type Index struct {
+	RefreshInterval int `json:"refreshInterval" yaml:"refreshInterval" validate:"min=0"` // 单位秒，文件索引全量刷新的间隔
+}
+
+type Progress struct {
+	FlushInterval  int `json:"flushInterval" yaml:"flushInterval" validate:"min=0"`   // 单位毫秒，进度上报合并后写入数据库的间隔
+	BatchSize      int `json:"batchSize" yaml:"batchSize" validate:"min=0"`           // 每个事务写入的进度数
+	MaxSyncResults int `json:"maxSyncResults" yaml:"maxSyncResults" validate:"min=0"` // 离线同步响应中返回的失败条目数上限
+	MaxPending     int `json:"maxPending" yaml:"maxPending" validate:"min=0"`         // 等待写入的进度数上限，超过后拒绝新进度的上报
+	MaxAttempts    int `json:"maxAttempts" yaml:"maxAttempts" validate:"min=0"`       // 单个进度写入失败达到该次数后丢弃
+}
+
+type Session struct {
+	CommandTimeout int `json:"commandTimeout" yaml:"commandTimeout" validate:"min=0"` // 单位秒，等待dingospeed应答指令的超时时间
+}
+
+type Liveness struct {
+	Lease         int `json:"lease" yaml:"lease" validate:"min=0"`                 // 单位秒，心跳超过该时间视为失效
+	SweepInterval int `json:"sweepInterval" yaml:"sweepInterval" validate:"min=0"` // 单位秒
+}
+
+type Selector struct {
+	Strategy       string            `json:"strategy" yaml:"strategy" validate:"omitempty,oneof=topology score first-fit least-loaded same-aidc-first weighted-random"`
+	AidcStrategies map[string]string `json:"aidcStrategies" yaml:"aidcStrategies" validate:"dive,oneof=topology score first-fit least-loaded same-aidc-first weighted-random"` // 按发起方aidc单独配置策略
+}
+
+type Load struct {
+	DefaultMaxUploads int32   `json:"defaultMaxUploads" yaml:"defaultMaxUploads"` // 节点未上报上限时使用的对外下载连接数上限
+	Saturation        float64 `json:"saturation" yaml:"saturation" validate:"min=0,max=1"`
+}
+
+type Stripe struct {
+	MinFileSize int64 `json:"minFileSize" yaml:"minFileSize"` // 单位MB，小于该值的文件不分段
+	RangeSize   int64 `json:"rangeSize" yaml:"rangeSize"`     // 单位MB
 }
 
 type PersistRepo struct {
@@ -103,15 +204,17 @@ type PersistRepo struct {
 }
 
 type DBConfig struct {
-	Type        string `yaml:"type"`
+	Type        string `yaml:"type"` // mysql、sqlite、postgres
 	User        string `yaml:"user"`
 	Password    string `yaml:"password"`
 	Host        string `yaml:"host"`
 	Port        int    `yaml:"port"`
-	Database    string `yaml:"database"`
+	Database    string `yaml:"database"` // sqlite时为数据库文件路径
+	SslMode     string `yaml:"sslMode"`  // postgres的sslmode，默认为disable
 	Timeout     string `yaml:"timeout"`
 	MaxConn     int    `yaml:"maxConn"`
 	MaxIdleConn int    `yaml:"maxIdleConn"`
+	AutoMigrate bool   `yaml:"autoMigrate"` // 启动时执行内置的表结构迁移
 }
 
 func (c *Config) GetHFURLBase() string {
@@ -159,6 +262,20 @@ func (c *Config) GetCleanupInterval() time.Duration {
 	return time.Duration(c.Cache.CleanupInterval) * time.Hour
 }
 
+func (c *Config) GetCacheType() string {
+	if c.Cache.Type == "" {
+		return consts.CacheMemory
+	}
+	return c.Cache.Type
+}
+
+func (c *Config) GetCachePrefix() string {
+	if c.Cache.Redis.Prefix == "" {
+		return "dingoscheduler:"
+	}
+	return c.Cache.Redis.Prefix
+}
+
 func (c *Config) GetEnablePersistRepo() bool {
 	return c.Scheduler.PersistRepo.Enabled
 }
@@ -171,6 +288,213 @@ func (c *Config) GetSpeedExpiration() time.Duration {
 	return time.Duration(5) * time.Minute
 }
 
+// GetHeartbeatLease 心跳租约，超过该时长未收到心跳的节点视为过期
+func (c *Config) GetHeartbeatLease() time.Duration {
+	if c.Scheduler.Liveness.Lease <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(c.Scheduler.Liveness.Lease) * time.Second
+}
+
+func (c *Config) GetSweepInterval() time.Duration {
+	if c.Scheduler.Liveness.SweepInterval <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.Scheduler.Liveness.SweepInterval) * time.Second
+}
+
+func (c *Config) GetCommandTimeout() time.Duration {
+	if c.Scheduler.Session.CommandTimeout <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(c.Scheduler.Session.CommandTimeout) * time.Second
+}
+
+func (c *Config) GetProgressFlushInterval() time.Duration {
+	if c.Scheduler.Progress.FlushInterval <= 0 {
+		return 500 * time.Millisecond
+	}
+	return time.Duration(c.Scheduler.Progress.FlushInterval) * time.Millisecond
+}
+
+func (c *Config) GetProgressBatchSize() int {
+	if c.Scheduler.Progress.BatchSize <= 0 {
+		return 500
+	}
+	return c.Scheduler.Progress.BatchSize
+}
+
+func (c *Config) GetMaxSyncResults() int {
+	if c.Scheduler.Progress.MaxSyncResults <= 0 {
+		return 1000
+	}
+	return c.Scheduler.Progress.MaxSyncResults
+}
+
+func (c *Config) GetMaxPendingReports() int {
+	if c.Scheduler.Progress.MaxPending <= 0 {
+		return 100000
+	}
+	return c.Scheduler.Progress.MaxPending
+}
+
+func (c *Config) GetProgressMaxAttempts() int {
+	if c.Scheduler.Progress.MaxAttempts <= 0 {
+		return 5
+	}
+	return c.Scheduler.Progress.MaxAttempts
+}
+
+func (c *Config) GetQuarantineTTL() time.Duration {
+	if c.Scheduler.Integrity.QuarantineTTL <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(c.Scheduler.Integrity.QuarantineTTL) * time.Second
+}
+
+func (c *Config) GetIndexRefreshInterval() time.Duration {
+	if c.Scheduler.Index.RefreshInterval <= 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(c.Scheduler.Index.RefreshInterval) * time.Second
+}
+
+// GetMaxCandidates 调度响应中返回的候选节点数量上限
+func (c *Config) GetMaxCandidates() int {
+	if c.Scheduler.MaxCandidates <= 0 {
+		return 5
+	}
+	return c.Scheduler.MaxCandidates
+}
+
+func (c *Config) GetStripeMinFileSize() int64 {
+	if c.Scheduler.Stripe.MinFileSize <= 0 {
+		return 1024 * 1024 * 1024
+	}
+	return c.Scheduler.Stripe.MinFileSize * 1024 * 1024
+}
+
+func (c *Config) GetStripeRangeSize() int64 {
+	if c.Scheduler.Stripe.RangeSize <= 0 {
+		return 256 * 1024 * 1024
+	}
+	return c.Scheduler.Stripe.RangeSize * 1024 * 1024
+}
+
+func (c *Config) GetDefaultMaxUploads() int32 {
+	if c.Scheduler.Load.DefaultMaxUploads <= 0 {
+		return 64
+	}
+	return c.Scheduler.Load.DefaultMaxUploads
+}
+
+func (c *Config) GetSaturation() float64 {
+	if c.Scheduler.Load.Saturation <= 0 {
+		return 0.9
+	}
+	return c.Scheduler.Load.Saturation
+}
+
+// GetSelectorStrategy 获取aidc使用的节点选择策略，未单独配置时使用全局策略
+func (c *Config) GetSelectorStrategy(aidc string) string {
+	if strategy, ok := c.Scheduler.Selector.AidcStrategies[aidc]; ok && aidc != "" {
+		return strategy
+	}
+	if c.Scheduler.Selector.Strategy == "" {
+		return consts.SelectorTopology
+	}
+	return c.Scheduler.Selector.Strategy
+}
+
+// GetInstanceAidc 优先按拓扑配置查找实例所属的aidc，否则根据aidc配置反查，
+// 多个aidc指向同一实例时优先取与实例同名的，否则取名称最小的
+func (c *Config) GetInstanceAidc(instanceId string) string {
+	for _, region := range c.Topology.Regions {
+		for _, aidc := range region.Aidcs {
+			for _, id := range aidc.Instances {
+				if id == instanceId {
+					return aidc.Name
+				}
+			}
+		}
+	}
+	if id, ok := c.Aidc[instanceId]; ok && id == instanceId {
+		return instanceId
+	}
+	aidc := ""
+	for code, id := range c.Aidc {
+		if id == instanceId && (aidc == "" || code < aidc) {
+			aidc = code
+		}
+	}
+	return aidc
+}
+
+func (c *Config) GetSameRegionCost() int {
+	if c.Topology.SameRegionCost <= 0 {
+		return 10
+	}
+	return c.Topology.SameRegionCost
+}
+
+func (c *Config) GetCrossRegionCost() int {
+	if c.Topology.CrossRegionCost <= 0 {
+		return 100
+	}
+	return c.Topology.CrossRegionCost
+}
+
+func (c *Config) GetLockTimeout() time.Duration {
+	if c.Scheduler.LockTimeout <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.Scheduler.LockTimeout) * time.Second
+}
+
+func (c *Config) GetHAEnabled() bool {
+	return c.Scheduler.HA.Enabled
+}
+
+func (c *Config) GetNodeId() string {
+	if c.Scheduler.HA.NodeId != "" {
+		return c.Scheduler.HA.NodeId
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		return hostname
+	}
+	return "dingoscheduler"
+}
+
+func (c *Config) GetLeaderLease() time.Duration {
+	if c.Scheduler.HA.LeaderLease <= 0 {
+		return 15 * time.Second
+	}
+	return time.Duration(c.Scheduler.HA.LeaderLease) * time.Second
+}
+
+// GetRenewInterval 续约间隔，未配置或不小于选主租约时取租约的三分之一
+func (c *Config) GetRenewInterval() time.Duration {
+	interval := time.Duration(c.Scheduler.HA.RenewInterval) * time.Second
+	if interval <= 0 || interval >= c.GetLeaderLease() {
+		return c.GetLeaderLease() / 3
+	}
+	return interval
+}
+
+func (c *Config) GetLockLease() time.Duration {
+	if c.Scheduler.HA.LockLease <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.Scheduler.HA.LockLease) * time.Second
+}
+
+func (c *Config) GetLockWait() time.Duration {
+	if c.Scheduler.HA.LockWait <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(c.Scheduler.HA.LockWait) * time.Second
+}
+
 func (c *Config) GetCacheExpiration() time.Duration {
 	return time.Duration(30) * time.Minute
 }
@@ -31,12 +31,51 @@ const (
 
 const (
 	// 支持的数据库
-	DB_MYSQL = "mysql"
+	DB_MYSQL    = "mysql"
+	DB_SQLITE   = "sqlite"
+	DB_POSTGRES = "postgres"
 )
 
+// 缓存实现
 const (
-	SchedulerNo  = 1
-	SchedulerYes = 2
+	CacheMemory = "memory"
+	CacheRedis  = "redis"
+)
+
+const (
+	SchedulerNo     = 1
+	SchedulerYes    = 2
+	SchedulerStripe = 3 // 分段并行下载
+)
+
+// 下载进度的完整性
+const (
+	IntegrityUnverified = 0
+	IntegrityVerified   = 1
+	IntegrityCorrupted  = 2
+)
+
+// 离线同步进度的结果
+const (
+	SyncInserted = 1
+	SyncUpdated  = 2
+	SyncFailed   = 3
+)
+
+// dingospeed存活状态
+const (
+	SpeedStatusAlive   = 1
+	SpeedStatusExpired = 2
+)
+
+// 节点选择策略
+const (
+	SelectorTopology       = "topology"
+	SelectorScore          = "score"
+	SelectorFirstFit       = "first-fit"
+	SelectorLeastLoaded    = "least-loaded"
+	SelectorSameAidcFirst  = "same-aidc-first"
+	SelectorWeightedRandom = "weighted-random"
 )
 
 const PromSource = "source"
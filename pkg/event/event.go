@@ -0,0 +1,113 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package event
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/wire"
+	"go.uber.org/zap"
+)
+
+var EventProvider = wire.NewSet(NewBus)
+
+const (
+	TopicInstanceExpired = "instance.expired"
+	TopicPeerQuarantined = "peer.quarantined"
+	TopicSessionCommand  = "session.command"
+	TopicSessionReply    = "session.reply"
+)
+
+// PeerQuarantined 节点提供的数据校验失败，ExpiresAt之前不再作为该etag的来源
+type PeerQuarantined struct {
+	InstanceID string
+	Etag       string
+	ProcessID  int64 // 校验失败的下载进度
+	Datatype   string
+	Org        string
+	Repo       string
+	Name       string
+	ExpiresAt  time.Time
+}
+
+// InstanceExpired 实例心跳超时被判定失效，ProcessIds为以其为master、需重新调度的下载进度，按下载方实例分组
+type InstanceExpired struct {
+	InstanceID string
+	ProcessIds map[string][]int64
+}
+
+// SessionCommand 多副本部署时转发给持有节点长连接的副本执行的命令
+type SessionCommand struct {
+	RequestID  string
+	From       string // 发起转发的副本
+	To         string // 持有长连接的副本
+	InstanceID string
+	Command    []byte // proto编码的SessionCommand
+}
+
+// SessionReply 转发命令的执行结果，写回给发起转发的副本
+type SessionReply struct {
+	RequestID string
+	To        string
+	NoSession bool // 持有者上的长连接已断开
+	ErrorMsg  string
+}
+
+// Bus 进程内的事件总线，订阅方处理过慢时丢弃事件，不阻塞发布方
+type Bus struct {
+	mu     sync.RWMutex
+	nextId int
+	subs   map[string]map[int]chan interface{}
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		subs: make(map[string]map[int]chan interface{}),
+	}
+}
+
+// Subscribe 订阅topic，返回事件通道和取消订阅函数
+func (b *Bus) Subscribe(topic string, size int) (<-chan interface{}, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subs[topic]; !ok {
+		b.subs[topic] = make(map[int]chan interface{})
+	}
+	b.nextId++
+	id := b.nextId
+	ch := make(chan interface{}, size)
+	b.subs[topic][id] = ch
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if c, ok := b.subs[topic][id]; ok {
+			delete(b.subs[topic], id)
+			close(c)
+		}
+	}
+}
+
+func (b *Bus) Publish(topic string, ev interface{}) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, ch := range b.subs[topic] {
+		select {
+		case ch <- ev:
+		default:
+			zap.S().Warnf("event %s dropped, subscriber is full", topic)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package gorm
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"dingoscheduler/pkg/config"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// postgresDSN 拼接postgres://形式的连接串，用户名、密码、库名中的空格、引号、反斜杠等字符由url转义
+func postgresDSN(config *config.DBConfig) string {
+	sslMode := config.SslMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+	params := url.Values{}
+	params.Set("sslmode", sslMode)
+	params.Set("TimeZone", "Asia/Shanghai")
+	if timeout, err := time.ParseDuration(config.Timeout); err == nil && timeout >= time.Second {
+		params.Set("connect_timeout", strconv.Itoa(int(timeout.Seconds())))
+	}
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(config.User, config.Password),
+		Host:     net.JoinHostPort(config.Host, strconv.Itoa(config.Port)),
+		Path:     "/" + config.Database,
+		RawQuery: params.Encode(),
+	}
+	return dsn.String()
+}
+
+func NewPostgresClient(config *config.DBConfig) (*gorm.DB, error) {
+	_db, err := gorm.Open(postgres.Open(postgresDSN(config)), &gorm.Config{
+		SkipDefaultTransaction: false,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("连接postgres数据库失败: %w", err)
+	}
+
+	sqlDB, err := _db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(config.MaxConn)
+	sqlDB.SetMaxIdleConns(config.MaxIdleConn)
+	return _db, nil
+}
@@ -0,0 +1,27 @@
+package gorm
+
+import (
+	"testing"
+
+	"dingoscheduler/pkg/config"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestPostgresDSNEscapesCredentials(t *testing.T) {
+	conf := &config.DBConfig{User: "dingo user", Password: `p@ss w'o\rd:/?#%`, Host: "127.0.0.1", Port: 5432,
+		Database: "dingo db", Timeout: "10s"}
+	parsed, err := pgconn.ParseConfig(postgresDSN(conf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.User != conf.User || parsed.Password != conf.Password || parsed.Database != conf.Database {
+		t.Fatalf("parsed user %q, password %q, database %q", parsed.User, parsed.Password, parsed.Database)
+	}
+	if parsed.Host != conf.Host || parsed.Port != 5432 || parsed.ConnectTimeout.Seconds() != 10 {
+		t.Fatalf("parsed host %s:%d, timeout %s", parsed.Host, parsed.Port, parsed.ConnectTimeout)
+	}
+	if parsed.RuntimeParams["TimeZone"] != "Asia/Shanghai" {
+		t.Fatalf("runtime params = %v", parsed.RuntimeParams)
+	}
+}
@@ -0,0 +1,37 @@
+package gorm
+
+import (
+	"fmt"
+
+	"dingoscheduler/pkg/config"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+const sqliteMemory = ":memory:"
+
+// NewSqliteClient 适用于单节点部署及测试，database为数据库文件路径，为:memory:时使用内存数据库
+func NewSqliteClient(config *config.DBConfig) (*gorm.DB, error) {
+	// 写冲突时等待而不是直接返回database is locked，时间按sqlite可解析的格式保存
+	dsn := fmt.Sprintf("%s?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_time_format=sqlite", config.Database)
+	_db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		SkipDefaultTransaction: false,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("连接sqlite数据库失败: %w", err)
+	}
+
+	sqlDB, err := _db.DB()
+	if err != nil {
+		return nil, err
+	}
+	if config.Database == sqliteMemory {
+		// 内存数据库每个连接相互独立，只能使用一个连接
+		sqlDB.SetMaxOpenConns(1)
+	} else {
+		sqlDB.SetMaxOpenConns(config.MaxConn)
+		sqlDB.SetMaxIdleConns(config.MaxIdleConn)
+	}
+	return _db, nil
+}
@@ -0,0 +1,129 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package keylock 按key互斥的锁，锁只在有人持有或等待时存在，引用计数归零后即删除，
+// 不会像放在带过期时间的缓存中那样在持有期间被替换，也不会随调度过的文件无限增长。
+package keylock
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"dingoscheduler/pkg/prom"
+)
+
+type entry struct {
+	sem  chan struct{} // 容量为1，写入即加锁
+	refs int           // 持有及等待的数量，由Manager.mu保护
+}
+
+// Manager 一组按key互斥的锁，name用作监控指标的标签
+type Manager struct {
+	name    string
+	timeout time.Duration
+	mu      sync.Mutex
+	locks   map[string]*entry
+}
+
+// New timeout为等待锁的最长时间，0表示只受ctx限制
+func New(name string, timeout time.Duration) *Manager {
+	return &Manager{name: name, timeout: timeout, locks: make(map[string]*entry)}
+}
+
+// Lock 获取key的锁，返回释放函数，释放函数可重复调用；等待超时或ctx结束时返回错误
+func (m *Manager) Lock(ctx context.Context, key string) (func(), error) {
+	e := m.acquire(key)
+	select {
+	case e.sem <- struct{}{}:
+		prom.PromKeyLockAcquired(m.name, 0, false)
+	default:
+		start := time.Now()
+		if m.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, m.timeout)
+			defer cancel()
+		}
+		select {
+		case e.sem <- struct{}{}:
+			prom.PromKeyLockAcquired(m.name, time.Since(start), true)
+		case <-ctx.Done():
+			m.release(key, e)
+			prom.PromKeyLockTimeout(m.name)
+			return nil, fmt.Errorf("lock %s: %w", key, ctx.Err())
+		}
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-e.sem
+			m.release(key, e)
+		})
+	}, nil
+}
+
+// LockAll 按排序后的固定顺序获取多个key的锁，避免与其他批量加锁的请求死锁，失败时释放已取得的锁
+func (m *Manager) LockAll(ctx context.Context, keys []string) (func(), error) {
+	keys = slices.Clone(keys)
+	sort.Strings(keys)
+	keys = slices.Compact(keys)
+	unlocks := make([]func(), 0, len(keys))
+	unlockAll := func() {
+		for i := len(unlocks) - 1; i >= 0; i-- {
+			unlocks[i]()
+		}
+	}
+	for _, key := range keys {
+		unlock, err := m.Lock(ctx, key)
+		if err != nil {
+			unlockAll()
+			return nil, err
+		}
+		unlocks = append(unlocks, unlock)
+	}
+	return unlockAll, nil
+}
+
+// Len 当前被持有或等待中的key数量
+func (m *Manager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.locks)
+}
+
+func (m *Manager) acquire(key string) *entry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.locks[key]
+	if !ok {
+		e = &entry{sem: make(chan struct{}, 1)}
+		m.locks[key] = e
+		prom.PromKeyLockKeys(m.name, len(m.locks))
+	}
+	e.refs++
+	return e
+}
+
+func (m *Manager) release(key string, e *entry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e.refs--
+	if e.refs == 0 {
+		delete(m.locks, key)
+		prom.PromKeyLockKeys(m.name, len(m.locks))
+	}
+}
@@ -0,0 +1,117 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package keylock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLockExcludes(t *testing.T) {
+	m := New("test", 0)
+	var holders, maxHolders atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := m.Lock(context.Background(), "file")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			n := holders.Add(1)
+			for {
+				old := maxHolders.Load()
+				if n <= old || maxHolders.CompareAndSwap(old, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			holders.Add(-1)
+			unlock()
+		}()
+	}
+	wg.Wait()
+	if maxHolders.Load() != 1 {
+		t.Fatalf("%d goroutines held the lock at once", maxHolders.Load())
+	}
+	// 无人持有或等待的key不再保留
+	if m.Len() != 0 {
+		t.Fatalf("%d keys left", m.Len())
+	}
+}
+
+func TestLockTimeout(t *testing.T) {
+	m := New("test", 20*time.Millisecond)
+	unlock, err := m.Lock(context.Background(), "file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = m.Lock(context.Background(), "file"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want deadline exceeded", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = m.Lock(ctx, "file"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want canceled", err)
+	}
+	// 其他key不受影响
+	other, err := m.Lock(context.Background(), "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other()
+	unlock()
+	unlock()
+	if m.Len() != 0 {
+		t.Fatalf("%d keys left", m.Len())
+	}
+	if unlock, err = m.Lock(context.Background(), "file"); err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+}
+
+func TestLockAllOrder(t *testing.T) {
+	m := New("test", time.Second)
+	var wg sync.WaitGroup
+	// 两组请求以相反顺序传入同样的key，按排序加锁不会死锁
+	for i := 0; i < 20; i++ {
+		keys := []string{"a", "b", "c", "a"}
+		if i%2 == 1 {
+			keys = []string{"c", "b", "a"}
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := m.LockAll(context.Background(), keys)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			time.Sleep(time.Millisecond)
+			unlock()
+		}()
+	}
+	wg.Wait()
+	if m.Len() != 0 {
+		t.Fatalf("%d keys left", m.Len())
+	}
+}
@@ -1,6 +1,8 @@
 package prom
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -57,6 +59,125 @@ var (
 	}, []string{"source", "orgRepo"})
 )
 
+var (
+	// dingospeed负载，来自心跳上报
+
+	SpeedActiveUploads = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "speed_active_uploads",
+		Help: "Number of uploads a dingospeed is serving",
+	}, []string{"instanceId"})
+
+	SpeedOutboundBandwidth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "speed_outbound_bandwidth",
+		Help: "Outbound bandwidth of a dingospeed in bytes per second",
+	}, []string{"instanceId"})
+
+	SpeedFreeDisk = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "speed_free_disk",
+		Help: "Free disk of a dingospeed in bytes",
+	}, []string{"instanceId"})
+
+	SpeedQueuedCacheJobs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "speed_queued_cache_jobs",
+		Help: "Number of cache jobs queued on a dingospeed",
+	}, []string{"instanceId"})
+
+	// 心跳超时被判定失效的次数
+
+	SpeedExpiredCnt = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "speed_expired_cnt",
+		Help: "Total number of times a dingospeed lease expired",
+	}, []string{"instanceId"})
+
+	// 提供的数据校验失败被隔离的次数
+
+	SpeedQuarantinedCnt = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "speed_quarantined_cnt",
+		Help: "Total number of times a dingospeed served corrupted data",
+	}, []string{"instanceId"})
+
+	// 本副本是否为主副本，1为主
+
+	SchedulerLeader = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "scheduler_leader",
+		Help: "Whether this dingoscheduler replica is the elected leader",
+	})
+
+	// 按key加锁的次数、需要等待的次数、等待超时的次数、等待时长及当前被持有或等待中的key数量
+
+	KeyLockAcquireCnt = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "keylock_acquire_cnt",
+		Help: "Total number of keyed locks acquired",
+	}, []string{"name"})
+
+	KeyLockContendedCnt = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "keylock_contended_cnt",
+		Help: "Total number of keyed lock acquisitions that had to wait",
+	}, []string{"name"})
+
+	KeyLockTimeoutCnt = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "keylock_timeout_cnt",
+		Help: "Total number of keyed lock acquisitions that timed out or were canceled",
+	}, []string{"name"})
+
+	KeyLockWaitSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "keylock_wait_seconds",
+		Help:    "Time spent waiting for a contended keyed lock",
+		Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30},
+	}, []string{"name"})
+
+	KeyLockKeys = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "keylock_keys",
+		Help: "Number of keys currently held or waited on",
+	}, []string{"name"})
+
+	// 丢弃的进度上报数，reason为backlog表示等待写入的进度过多，attempts表示多次写入失败
+
+	ProgressDroppedCnt = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "progress_dropped_cnt",
+		Help: "Total number of progress reports dropped before being written",
+	}, []string{"reason"})
+)
+
+func PromSpeedLoad(instanceId string, activeUploads int32, outboundBandwidth, freeDisk int64, queuedCacheJobs int32) {
+	labels := prometheus.Labels{"instanceId": instanceId}
+	SpeedActiveUploads.With(labels).Set(float64(activeUploads))
+	SpeedOutboundBandwidth.With(labels).Set(float64(outboundBandwidth))
+	SpeedFreeDisk.With(labels).Set(float64(freeDisk))
+	SpeedQueuedCacheJobs.With(labels).Set(float64(queuedCacheJobs))
+}
+
+// PromSpeedExpired 节点失效后清除负载指标，避免继续展示过期数据
+func PromSpeedExpired(instanceId string) {
+	labels := prometheus.Labels{"instanceId": instanceId}
+	SpeedExpiredCnt.With(labels).Inc()
+	SpeedActiveUploads.Delete(labels)
+	SpeedOutboundBandwidth.Delete(labels)
+	SpeedFreeDisk.Delete(labels)
+	SpeedQueuedCacheJobs.Delete(labels)
+}
+
+func PromKeyLockAcquired(name string, wait time.Duration, contended bool) {
+	labels := prometheus.Labels{"name": name}
+	KeyLockAcquireCnt.With(labels).Inc()
+	if contended {
+		KeyLockContendedCnt.With(labels).Inc()
+		KeyLockWaitSeconds.With(labels).Observe(wait.Seconds())
+	}
+}
+
+func PromKeyLockTimeout(name string) {
+	KeyLockTimeoutCnt.With(prometheus.Labels{"name": name}).Inc()
+}
+
+func PromKeyLockKeys(name string, n int) {
+	KeyLockKeys.With(prometheus.Labels{"name": name}).Set(float64(n))
+}
+
+func PromProgressDropped(reason string, n int) {
+	ProgressDroppedCnt.With(prometheus.Labels{"reason": reason}).Add(float64(n))
+}
+
 func PromSourceCounter(vec *prometheus.GaugeVec, source string) {
 	labels := prometheus.Labels{}
 	labels["source"] = source
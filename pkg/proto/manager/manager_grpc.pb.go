@@ -23,8 +23,14 @@ const (
 	Manager_Register_FullMethodName                    = "/manager.Manager/Register"
 	Manager_Heartbeat_FullMethodName                   = "/manager.Manager/Heartbeat"
 	Manager_SchedulerFile_FullMethodName               = "/manager.Manager/SchedulerFile"
+	Manager_Session_FullMethodName                     = "/manager.Manager/Session"
+	Manager_SchedulerRepo_FullMethodName               = "/manager.Manager/SchedulerRepo"
+	Manager_RescheduleFile_FullMethodName              = "/manager.Manager/RescheduleFile"
 	Manager_ReportFileProcess_FullMethodName           = "/manager.Manager/ReportFileProcess"
 	Manager_SyncFileProcess_FullMethodName             = "/manager.Manager/SyncFileProcess"
+	Manager_ReportInventory_FullMethodName             = "/manager.Manager/ReportInventory"
+	Manager_ReportChecksum_FullMethodName              = "/manager.Manager/ReportChecksum"
+	Manager_SyncFileProcessStream_FullMethodName       = "/manager.Manager/SyncFileProcessStream"
 	Manager_DeleteByEtagsAndFields_FullMethodName      = "/manager.Manager/DeleteByEtagsAndFields"
 	Manager_CreateCacheJob_FullMethodName              = "/manager.Manager/CreateCacheJob"
 	Manager_UpdateCacheJobStatus_FullMethodName        = "/manager.Manager/UpdateCacheJobStatus"
@@ -43,10 +49,22 @@ type ManagerClient interface {
 	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// 下载文件开始时，触发调度
 	SchedulerFile(ctx context.Context, in *SchedulerFileRequest, opts ...grpc.CallOption) (*SchedulerFileResponse, error)
+	// dingospeed与调度器之间的长连接，心跳、进度上报以及调度器下发的指令都通过该连接传递
+	Session(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SessionRequest, SessionCommand], error)
+	// 一次调度仓库内的多个文件
+	SchedulerRepo(ctx context.Context, in *SchedulerRepoRequest, opts ...grpc.CallOption) (*SchedulerRepoResponse, error)
+	// 下载过程中master失效时重新调度，保留已下载的进度
+	RescheduleFile(ctx context.Context, in *RescheduleFileRequest, opts ...grpc.CallOption) (*SchedulerFileResponse, error)
 	// 文件下载中或结束时，信息上报
 	ReportFileProcess(ctx context.Context, in *FileProcessRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// 离线同步文件下载进度
 	SyncFileProcess(ctx context.Context, in *SyncFileProcessReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// dingospeed上报磁盘上的全部缓存文件，调度器据此修正文件记录、下载进度和仓库
+	ReportInventory(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[InventoryRequest, InventoryResponse], error)
+	// 上报已完成区间或整个文件的SHA-256，校验数据完整性
+	ReportChecksum(ctx context.Context, in *ChecksumRequest, opts ...grpc.CallOption) (*ChecksumResponse, error)
+	// 离线较久的节点重新同步下载进度，条目按批在事务中写入
+	SyncFileProcessStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileProcessEntry, SyncFileProcessSummary], error)
 	// 文件删除，同步删除记录
 	DeleteByEtagsAndFields(ctx context.Context, in *DeleteByEtagsAndFieldsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// 缓存预热状态
@@ -93,6 +111,39 @@ func (c *managerClient) SchedulerFile(ctx context.Context, in *SchedulerFileRequ
 	return out, nil
 }
 
+func (c *managerClient) Session(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SessionRequest, SessionCommand], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Manager_ServiceDesc.Streams[0], Manager_Session_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SessionRequest, SessionCommand]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Manager_SessionClient = grpc.BidiStreamingClient[SessionRequest, SessionCommand]
+
+func (c *managerClient) SchedulerRepo(ctx context.Context, in *SchedulerRepoRequest, opts ...grpc.CallOption) (*SchedulerRepoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SchedulerRepoResponse)
+	err := c.cc.Invoke(ctx, Manager_SchedulerRepo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) RescheduleFile(ctx context.Context, in *RescheduleFileRequest, opts ...grpc.CallOption) (*SchedulerFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SchedulerFileResponse)
+	err := c.cc.Invoke(ctx, Manager_RescheduleFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *managerClient) ReportFileProcess(ctx context.Context, in *FileProcessRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -113,6 +164,42 @@ func (c *managerClient) SyncFileProcess(ctx context.Context, in *SyncFileProcess
 	return out, nil
 }
 
+func (c *managerClient) ReportInventory(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[InventoryRequest, InventoryResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Manager_ServiceDesc.Streams[1], Manager_ReportInventory_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[InventoryRequest, InventoryResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Manager_ReportInventoryClient = grpc.ClientStreamingClient[InventoryRequest, InventoryResponse]
+
+func (c *managerClient) ReportChecksum(ctx context.Context, in *ChecksumRequest, opts ...grpc.CallOption) (*ChecksumResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChecksumResponse)
+	err := c.cc.Invoke(ctx, Manager_ReportChecksum_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) SyncFileProcessStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileProcessEntry, SyncFileProcessSummary], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Manager_ServiceDesc.Streams[2], Manager_SyncFileProcessStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FileProcessEntry, SyncFileProcessSummary]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Manager_SyncFileProcessStreamClient = grpc.ClientStreamingClient[FileProcessEntry, SyncFileProcessSummary]
+
 func (c *managerClient) DeleteByEtagsAndFields(ctx context.Context, in *DeleteByEtagsAndFieldsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -165,10 +252,22 @@ type ManagerServer interface {
 	Heartbeat(context.Context, *HeartbeatRequest) (*emptypb.Empty, error)
 	// 下载文件开始时，触发调度
 	SchedulerFile(context.Context, *SchedulerFileRequest) (*SchedulerFileResponse, error)
+	// dingospeed与调度器之间的长连接，心跳、进度上报以及调度器下发的指令都通过该连接传递
+	Session(grpc.BidiStreamingServer[SessionRequest, SessionCommand]) error
+	// 一次调度仓库内的多个文件
+	SchedulerRepo(context.Context, *SchedulerRepoRequest) (*SchedulerRepoResponse, error)
+	// 下载过程中master失效时重新调度，保留已下载的进度
+	RescheduleFile(context.Context, *RescheduleFileRequest) (*SchedulerFileResponse, error)
 	// 文件下载中或结束时，信息上报
 	ReportFileProcess(context.Context, *FileProcessRequest) (*emptypb.Empty, error)
 	// 离线同步文件下载进度
 	SyncFileProcess(context.Context, *SyncFileProcessReq) (*emptypb.Empty, error)
+	// dingospeed上报磁盘上的全部缓存文件，调度器据此修正文件记录、下载进度和仓库
+	ReportInventory(grpc.ClientStreamingServer[InventoryRequest, InventoryResponse]) error
+	// 上报已完成区间或整个文件的SHA-256，校验数据完整性
+	ReportChecksum(context.Context, *ChecksumRequest) (*ChecksumResponse, error)
+	// 离线较久的节点重新同步下载进度，条目按批在事务中写入
+	SyncFileProcessStream(grpc.ClientStreamingServer[FileProcessEntry, SyncFileProcessSummary]) error
 	// 文件删除，同步删除记录
 	DeleteByEtagsAndFields(context.Context, *DeleteByEtagsAndFieldsRequest) (*emptypb.Empty, error)
 	// 缓存预热状态
@@ -194,12 +293,30 @@ func (UnimplementedManagerServer) Heartbeat(context.Context, *HeartbeatRequest)
 func (UnimplementedManagerServer) SchedulerFile(context.Context, *SchedulerFileRequest) (*SchedulerFileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SchedulerFile not implemented")
 }
+func (UnimplementedManagerServer) Session(grpc.BidiStreamingServer[SessionRequest, SessionCommand]) error {
+	return status.Errorf(codes.Unimplemented, "method Session not implemented")
+}
+func (UnimplementedManagerServer) SchedulerRepo(context.Context, *SchedulerRepoRequest) (*SchedulerRepoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SchedulerRepo not implemented")
+}
+func (UnimplementedManagerServer) RescheduleFile(context.Context, *RescheduleFileRequest) (*SchedulerFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RescheduleFile not implemented")
+}
 func (UnimplementedManagerServer) ReportFileProcess(context.Context, *FileProcessRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReportFileProcess not implemented")
 }
 func (UnimplementedManagerServer) SyncFileProcess(context.Context, *SyncFileProcessReq) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SyncFileProcess not implemented")
 }
+func (UnimplementedManagerServer) ReportInventory(grpc.ClientStreamingServer[InventoryRequest, InventoryResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ReportInventory not implemented")
+}
+func (UnimplementedManagerServer) ReportChecksum(context.Context, *ChecksumRequest) (*ChecksumResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportChecksum not implemented")
+}
+func (UnimplementedManagerServer) SyncFileProcessStream(grpc.ClientStreamingServer[FileProcessEntry, SyncFileProcessSummary]) error {
+	return status.Errorf(codes.Unimplemented, "method SyncFileProcessStream not implemented")
+}
 func (UnimplementedManagerServer) DeleteByEtagsAndFields(context.Context, *DeleteByEtagsAndFieldsRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteByEtagsAndFields not implemented")
 }
@@ -287,6 +404,49 @@ func _Manager_SchedulerFile_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Manager_Session_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ManagerServer).Session(&grpc.GenericServerStream[SessionRequest, SessionCommand]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Manager_SessionServer = grpc.BidiStreamingServer[SessionRequest, SessionCommand]
+
+func _Manager_SchedulerRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulerRepoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).SchedulerRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Manager_SchedulerRepo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).SchedulerRepo(ctx, req.(*SchedulerRepoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Manager_RescheduleFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RescheduleFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).RescheduleFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Manager_RescheduleFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).RescheduleFile(ctx, req.(*RescheduleFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Manager_ReportFileProcess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FileProcessRequest)
 	if err := dec(in); err != nil {
@@ -323,6 +483,38 @@ func _Manager_SyncFileProcess_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Manager_ReportInventory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ManagerServer).ReportInventory(&grpc.GenericServerStream[InventoryRequest, InventoryResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Manager_ReportInventoryServer = grpc.ClientStreamingServer[InventoryRequest, InventoryResponse]
+
+func _Manager_ReportChecksum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChecksumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).ReportChecksum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Manager_ReportChecksum_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).ReportChecksum(ctx, req.(*ChecksumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Manager_SyncFileProcessStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ManagerServer).SyncFileProcessStream(&grpc.GenericServerStream[FileProcessEntry, SyncFileProcessSummary]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Manager_SyncFileProcessStreamServer = grpc.ClientStreamingServer[FileProcessEntry, SyncFileProcessSummary]
+
 func _Manager_DeleteByEtagsAndFields_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteByEtagsAndFieldsRequest)
 	if err := dec(in); err != nil {
@@ -414,6 +606,14 @@ var Manager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SchedulerFile",
 			Handler:    _Manager_SchedulerFile_Handler,
 		},
+		{
+			MethodName: "SchedulerRepo",
+			Handler:    _Manager_SchedulerRepo_Handler,
+		},
+		{
+			MethodName: "RescheduleFile",
+			Handler:    _Manager_RescheduleFile_Handler,
+		},
 		{
 			MethodName: "ReportFileProcess",
 			Handler:    _Manager_ReportFileProcess_Handler,
@@ -422,6 +622,10 @@ var Manager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SyncFileProcess",
 			Handler:    _Manager_SyncFileProcess_Handler,
 		},
+		{
+			MethodName: "ReportChecksum",
+			Handler:    _Manager_ReportChecksum_Handler,
+		},
 		{
 			MethodName: "DeleteByEtagsAndFields",
 			Handler:    _Manager_DeleteByEtagsAndFields_Handler,
@@ -439,6 +643,23 @@ var Manager_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Manager_UpdateRepositoryMountStatus_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Session",
+			Handler:       _Manager_Session_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ReportInventory",
+			Handler:       _Manager_ReportInventory_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SyncFileProcessStream",
+			Handler:       _Manager_SyncFileProcessStream_Handler,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "manager.proto",
 }
@@ -290,3 +290,6 @@ func ZeroForTime() time.Time {
 	now := time.Now()
 	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 }
+
+// Now 调度及内存存储取当前时间的函数，回放trace时替换为按录制时间推进的时钟
+var Now = time.Now
@@ -1,3 +1,5 @@
+// Deprecated: dingospeed通过ReportInventory上报磁盘上的缓存文件，由调度器自动对账修正，
+// 无需再扫描目录生成CSV手工导入，该工具仅保留用于无法升级的旧版本节点。
 package main
 
 import (
@@ -76,6 +78,7 @@ func main() {
 	}
 	defer logger.Sync()
 	zap.ReplaceGlobals(logger)
+	zap.S().Warn("process_import已废弃，请升级dingospeed后使用ReportInventory自动对账")
 
 	fileInfos, err := processDirectory(repoPathParam)
 	if err != nil {
@@ -299,7 +302,8 @@ func main() {
 		}
 
 		zap.S().Infof("生成CSV文件：model_file_process.csv（%d 条新记录）", len(newProcessRecords))
-		zap.S().Infof("MySQL导入命令：\nLOAD DATA INFILE '/path/to/model_file_process.csv'\nINTO TABLE model_file_process\nFIELDS TERMINATED BY ',' ENCLOSED BY '\"' ESCAPED BY '\\\\'\nLINES TERMINATED BY '\\r\\n'\nIGNORE 1 ROWS (record_id, instance_id, offset_num, status);")
+		// IGNORE按唯一键跳过已存在的行，重复导入不会产生重复数据
+		zap.S().Infof("MySQL导入命令：\nLOAD DATA INFILE '/path/to/model_file_process.csv'\nIGNORE INTO TABLE model_file_process\nFIELDS TERMINATED BY ',' ENCLOSED BY '\"' ESCAPED BY '\\\\'\nLINES TERMINATED BY '\\r\\n'\nIGNORE 1 ROWS (record_id, instance_id, offset_num, status);")
 	} else {
 		zap.S().Info("所有Process记录均已存在，无需生成CSV")
 	}
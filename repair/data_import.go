@@ -1,3 +1,5 @@
+// Deprecated: dingospeed通过ReportInventory上报磁盘上的缓存文件，由调度器自动对账修正，
+// 无需再扫描目录生成CSV手工导入，该工具仅保留用于无法升级的旧版本节点。
 package main
 
 import (
@@ -70,6 +72,7 @@ func main() {
 	}
 	defer logger.Sync()
 	zap.ReplaceGlobals(logger)
+	zap.S().Warn("repair已废弃，请升级dingospeed后使用ReportInventory自动对账")
 
 	fileInfos, err := processDirectory(repoPathParam)
 	if err != nil {
@@ -195,8 +198,9 @@ func main() {
 
 		zap.S().Infof("成功生成ModelFileRecord CSV文件，包含 %d 条新记录: model_file_record.csv", len(newRecords))
 		zap.S().Infof("=== MySQL导入命令参考 ===")
+		// IGNORE按唯一键跳过已存在的行，重复导入不会产生重复数据
 		zap.S().Infof("LOAD DATA INFILE '/path/to/model_file_record.csv'")
-		zap.S().Infof("INTO TABLE model_file_record")
+		zap.S().Infof("IGNORE INTO TABLE model_file_record")
 		zap.S().Infof("FIELDS TERMINATED BY ',' ENCLOSED BY '\"' ESCAPED BY '\\\\'")
 		zap.S().Infof("LINES TERMINATED BY '\\r\\n'")
 		zap.S().Infof("IGNORE 1 ROWS (datatype, org, repo, name, etag, file_size);")
@@ -334,8 +338,9 @@ func main() {
 		zap.S().Infof("成功生成ModelFileProcess CSV文件，包含 %d 条新记录: model_file_process.csv", len(newProcessRecords))
 		// 输出MySQL导入命令
 		zap.S().Infof("=== MySQL导入命令参考 ===")
+		// IGNORE按唯一键跳过已存在的行，重复导入不会产生重复数据
 		zap.S().Infof("LOAD DATA INFILE '/path/to/model_file_process.csv'")
-		zap.S().Infof("INTO TABLE model_file_process")
+		zap.S().Infof("IGNORE INTO TABLE model_file_process")
 		zap.S().Infof("FIELDS TERMINATED BY ',' ENCLOSED BY '\"' ESCAPED BY '\\\\'")
 		zap.S().Infof("LINES TERMINATED BY '\\r\\n'")
 		zap.S().Infof("IGNORE 1 ROWS (record_id, instance_id, offset_num, status);")
@@ -0,0 +1,78 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"dingoscheduler/internal/selector"
+	"dingoscheduler/pkg/config"
+)
+
+var (
+	configPath string
+	tracePath  string
+	strategies string
+)
+
+func init() {
+	flag.StringVar(&configPath, "config", "./config/config.yaml", "配置文件路径，拓扑和aidc配置影响调度结果")
+	flag.StringVar(&tracePath, "trace", "", "录制的调用trace文件（必填），每行一个含time、method、request、processId的JSON")
+	flag.StringVar(&strategies, "strategies", "", "逗号分隔的节点选择策略，默认回放全部策略")
+}
+
+func main() {
+	flag.Parse()
+	if tracePath == "" {
+		fmt.Fprintln(os.Stderr, "必须提供trace参数，请使用 -trace 选项")
+		os.Exit(2)
+	}
+	if _, err := config.Scan(configPath); err != nil {
+		fmt.Fprintf(os.Stderr, "读取配置文件失败: %v\n", err)
+		os.Exit(1)
+	}
+	entries, err := readTrace(tracePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "读取trace失败: %v\n", err)
+		os.Exit(1)
+	}
+
+	names := selector.Names()
+	if strategies != "" {
+		names = strings.Split(strategies, ",")
+	}
+	results := make([]*stats, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if _, err = selector.New(name); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		// 回放期间所有aidc使用同一策略
+		config.SysConfig.Scheduler.Selector = config.Selector{Strategy: name}
+		r, err := newReplayer()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "初始化回放失败: %v\n", err)
+			os.Exit(1)
+		}
+		st := r.replay(entries)
+		st.strategy = name
+		results = append(results, st)
+	}
+	printStats(os.Stdout, len(entries), results)
+}
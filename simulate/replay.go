@@ -0,0 +1,276 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"dingoscheduler/internal/dao"
+	"dingoscheduler/internal/dao/memory"
+	"dingoscheduler/internal/data"
+	"dingoscheduler/internal/service"
+	"dingoscheduler/internal/session"
+	"dingoscheduler/pkg/config"
+	"dingoscheduler/pkg/consts"
+	"dingoscheduler/pkg/event"
+	pb "dingoscheduler/pkg/proto/manager"
+	"dingoscheduler/pkg/util"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// traceEntry 录制的一次调用，Time为调用发生的时间，回放时据此推进时钟，使心跳新鲜度与录制时一致
+type traceEntry struct {
+	Time      time.Time       `json:"time"`
+	Method    string          `json:"method"`
+	Request   json.RawMessage `json:"request"`
+	ProcessId int64           `json:"processId"`
+}
+
+// traceClock 按录制时间推进的时钟，只向前推进，没有录制时间的调用沿用上一次调用的时间
+type traceClock struct {
+	now time.Time
+}
+
+func (c *traceClock) Now() time.Time {
+	return c.now
+}
+
+func (c *traceClock) advance(t time.Time) {
+	if t.After(c.now) {
+		c.now = t
+	}
+}
+
+func readTrace(path string) ([]*traceEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	entries := make([]*traceEntry, 0)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		entry := &traceEntry{}
+		if err = json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+type speedKey struct {
+	instanceId string
+	online     bool
+}
+
+// replayer 每个策略使用一套全新的内存存储，互不影响
+type replayer struct {
+	clock      *traceClock
+	scheduler  *service.SchedulerService
+	progress   *service.ProgressService
+	speedIds   map[speedKey]int32
+	processIds map[int64]int64 // 录制的进度id -> 回放中的进度id
+}
+
+func newReplayer() (*replayer, error) {
+	db := memory.NewDB()
+	baseData := &data.BaseData{
+		Cache: data.NewMemoryCache(config.SysConfig.GetDefaultExpiration(), config.SysConfig.GetCleanupInterval()),
+	}
+	records := memory.NewRecordStore(db)
+	processes := memory.NewProcessStore(db)
+	repositories := memory.NewRepositoryStore(db)
+	fileIndex := service.NewFileIndex(records, processes)
+	if err := fileIndex.Load(); err != nil {
+		return nil, err
+	}
+	progress := service.NewProgressService(processes, fileIndex)
+	bus := event.NewBus()
+	integrity := service.NewIntegrityService(memory.NewIntegrityStore(db), records, processes, progress, fileIndex, bus)
+	scheduler := service.NewSchedulerService(baseData, memory.NewDingospeedStore(db, baseData), records, processes,
+		repositories, memory.NewCacheJobStore(db, repositories), session.NewManager(), progress, fileIndex, integrity,
+		dao.NewLeaseLocker(memory.NewLeaseStore(db)))
+	return &replayer{
+		clock:      &traceClock{now: time.Now()},
+		scheduler:  scheduler,
+		progress:   progress,
+		speedIds:   make(map[speedKey]int32),
+		processIds: make(map[int64]int64),
+	}, nil
+}
+
+type stats struct {
+	strategy    string
+	calls       int
+	peerHits    int
+	originBytes int64
+	totalBytes  int64
+	errors      int
+	skipped     int // 无法映射进度id或方法不支持的调用
+	latencies   []time.Duration
+}
+
+func (r *replayer) replay(entries []*traceEntry) *stats {
+	st := &stats{}
+	ctx := context.Background()
+	// 调度及内存存储读取录制的时间，延迟仍以实际耗时统计
+	if len(entries) > 0 && !entries[0].Time.IsZero() {
+		r.clock.now = entries[0].Time
+	}
+	now := util.Now
+	util.Now = r.clock.Now
+	defer func() { util.Now = now }()
+	for _, entry := range entries {
+		r.clock.advance(entry.Time)
+		switch entry.Method {
+		case "Register":
+			req := &pb.RegisterRequest{}
+			if !decode(entry, req, st) {
+				continue
+			}
+			resp, err := r.scheduler.Register(ctx, req)
+			if err != nil {
+				st.errors++
+				continue
+			}
+			r.speedIds[speedKey{req.InstanceId, req.Online}] = resp.Id
+		case "Heartbeat":
+			req := &pb.HeartbeatRequest{}
+			if !decode(entry, req, st) {
+				continue
+			}
+			id, ok := r.speedIds[speedKey{req.InstanceId, req.Online}]
+			if !ok {
+				st.skipped++
+				continue
+			}
+			req.Id = id
+			if _, err := r.scheduler.Heartbeat(ctx, req); err != nil {
+				st.errors++
+			}
+		case "SchedulerFile":
+			req := &pb.SchedulerFileRequest{}
+			if !decode(entry, req, st) {
+				continue
+			}
+			start := time.Now()
+			resp, err := r.scheduler.SchedulerFile(ctx, req)
+			st.latencies = append(st.latencies, time.Since(start))
+			st.calls++
+			if err != nil {
+				st.errors++
+				continue
+			}
+			if entry.ProcessId != 0 {
+				r.processIds[entry.ProcessId] = resp.ProcessId
+			}
+			if resp.SchedulerType != consts.SchedulerNo {
+				st.peerHits++
+			}
+			st.originBytes += originBytes(req, resp)
+			st.totalBytes += max(req.FileSize-req.StartPos, 0)
+		case "ReportFileProcess":
+			req := &pb.FileProcessRequest{}
+			if !decode(entry, req, st) {
+				continue
+			}
+			id, ok := r.processIds[req.ProcessId]
+			if !ok {
+				st.skipped++
+				continue
+			}
+			req.ProcessId = id
+			if _, err := r.scheduler.ReportFileProcess(ctx, req); err != nil {
+				st.errors++
+				continue
+			}
+			r.progress.Flush()
+		default:
+			st.skipped++
+		}
+	}
+	return st
+}
+
+func decode(entry *traceEntry, m proto.Message, st *stats) bool {
+	if err := protojson.Unmarshal(entry.Request, m); err != nil {
+		st.skipped++
+		return false
+	}
+	return true
+}
+
+// originBytes 按调度结果需要回源下载的字节数
+func originBytes(req *pb.SchedulerFileRequest, resp *pb.SchedulerFileResponse) int64 {
+	remain := max(req.FileSize-req.StartPos, 0)
+	switch resp.SchedulerType {
+	case consts.SchedulerStripe:
+		var bytes int64
+		for _, r := range resp.Ranges {
+			if r.InstanceId == "" {
+				bytes += r.EndPos - r.StartPos
+			}
+		}
+		return bytes
+	case consts.SchedulerYes:
+		return max(req.FileSize-max(resp.MaxOffset, req.StartPos), 0)
+	default:
+		return remain
+	}
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(float64(len(sorted))*p+0.5) - 1
+	return sorted[min(max(i, 0), len(sorted)-1)]
+}
+
+func printStats(w io.Writer, entries int, results []*stats) {
+	fmt.Fprintf(w, "replayed %d calls\n\n", entries)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "strategy\tcalls\tpeer hit\torigin MiB\torigin ratio\tp50\tp95\tp99\tmax\terrors\tskipped\t")
+	for _, st := range results {
+		sort.Slice(st.latencies, func(i, j int) bool { return st.latencies[i] < st.latencies[j] })
+		hitRate, originRatio := 0.0, 0.0
+		if st.calls > 0 {
+			hitRate = float64(st.peerHits) / float64(st.calls)
+		}
+		if st.totalBytes > 0 {
+			originRatio = float64(st.originBytes) / float64(st.totalBytes)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%.2f%%\t%.1f\t%.2f%%\t%s\t%s\t%s\t%s\t%d\t%d\t\n",
+			st.strategy, st.calls, hitRate*100, float64(st.originBytes)/(1<<20), originRatio*100,
+			percentile(st.latencies, 0.5), percentile(st.latencies, 0.95), percentile(st.latencies, 0.99),
+			percentile(st.latencies, 1), st.errors, st.skipped)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,108 @@
+//  Copyright (c) 2025 dingodb.com, Inc. All Rights Reserved
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http:www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"dingoscheduler/pkg/config"
+)
+
+// writeTrace 按录制格式写入trace文件，at为相对start的偏移，小于0时不记录时间
+func writeTrace(t *testing.T, start time.Time, calls []string, at []time.Duration) string {
+	t.Helper()
+	lines := make([]string, 0, len(calls))
+	for i, call := range calls {
+		ts := ""
+		if at[i] >= 0 {
+			ts = fmt.Sprintf(`"time":"%s",`, start.Add(at[i]).Format(time.RFC3339Nano))
+		}
+		lines = append(lines, "{"+ts+call+"}")
+	}
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReplayDrivesClockFromTrace(t *testing.T) {
+	calls := []string{
+		`"method":"Register","request":{"instanceId":"speed-a","host":"127.0.0.1","port":8001,"online":true}`,
+		`"method":"SchedulerFile","processId":1,"request":{"dataType":"models","org":"org","repo":"repo","name":"model.bin","etag":"etag","instanceId":"speed-a","fileSize":1048576}`,
+		`"method":"ReportFileProcess","request":{"processId":1,"staPos":0,"endPos":1048576,"status":3}`,
+		`"method":"SchedulerFile","request":{"dataType":"models","org":"org","repo":"repo","name":"model.bin","etag":"etag","instanceId":"speed-b","fileSize":1048576}`,
+		`"method":"SchedulerFile","request":{"dataType":"models","org":"org","repo":"repo","name":"model.bin","etag":"etag","instanceId":"speed-c","fileSize":1048576}`,
+	}
+	start := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		at       []time.Duration
+		peerHits int
+	}{
+		// speed-c的调度发生在speed-a心跳过期之后，只能回源
+		{name: "recorded time", at: []time.Duration{0, time.Second, 2 * time.Second, 3 * time.Second, time.Hour}, peerHits: 1},
+		// 没有录制时间时所有调用沿用开始时间，speed-a的心跳一直有效
+		{name: "no recorded time", at: []time.Duration{-1, -1, -1, -1, -1}, peerHits: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.SysConfig = &config.Config{}
+			config.SysConfig.SetDefaults()
+			entries, err := readTrace(writeTrace(t, start, calls, tt.at))
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := newReplayer()
+			if err != nil {
+				t.Fatal(err)
+			}
+			st := r.replay(entries)
+			if st.calls != 3 || st.errors != 0 || st.skipped != 0 {
+				t.Fatalf("stats = %+v", st)
+			}
+			if st.peerHits != tt.peerHits {
+				t.Fatalf("peer hits = %d, want %d", st.peerHits, tt.peerHits)
+			}
+			if tt.at[0] >= 0 && !r.clock.Now().Equal(start.Add(tt.at[len(tt.at)-1])) {
+				t.Fatalf("clock = %s, want last recorded time", r.clock.Now())
+			}
+		})
+	}
+}
+
+func TestTraceClockOnlyAdvances(t *testing.T) {
+	start := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+	c := &traceClock{now: start}
+	for _, tt := range []struct {
+		t    time.Time
+		want time.Time
+	}{
+		{start.Add(time.Second), start.Add(time.Second)},
+		{start, start.Add(time.Second)},
+		{time.Time{}, start.Add(time.Second)},
+		{start.Add(time.Minute), start.Add(time.Minute)},
+	} {
+		c.advance(tt.t)
+		if !c.Now().Equal(tt.want) {
+			t.Fatalf("advance(%s) = %s, want %s", tt.t, c.Now(), tt.want)
+		}
+	}
+}